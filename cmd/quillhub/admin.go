@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/repository"
+	"github.com/britinogn/quillhub/internal/services"
+)
+
+// runCreateAdmin - Create an admin account without an existing admin, which the
+// POST /admins endpoint requires; --promote makes an existing account an admin instead
+func runCreateAdmin(ctx context.Context, args []string) error {
+	fs := newFlagSet("create-admin", "--email EMAIL [--name NAME] [--username USERNAME] [--promote]")
+	email := fs.String("email", "", "email address of the admin (required)")
+	name := fs.String("name", "", "display name (default: the part of the email before @)")
+	username := fs.String("username", "", "username (default: the part of the email before @)")
+	promote := fs.Bool("promote", false, "give the existing account with this email the admin role")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	*email = strings.ToLower(strings.TrimSpace(*email))
+	if *email == "" {
+		fs.Usage()
+		return errors.New("--email is required")
+	}
+
+	cfg, dbPool, err := connectDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	userRepo := repository.NewUserRepository(dbPool)
+	auditService := services.NewAuditService(repository.NewAuditRepository(dbPool))
+	// Promotion revokes the user's sessions; no token is ever signed here
+	sessionService := services.NewSessionService(repository.NewSessionRepository(dbPool), auditService, nil)
+	authService := services.NewAuthService(userRepo, sessionService, auditService, repository.NewInviteRepository(dbPool), services.LoadRegistrationPolicy(cfg.Registration))
+
+	if *promote {
+		existing, err := userRepo.FindByEmail(ctx, *email)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("no account uses %s", *email)
+		}
+		user, err := authService.ChangeUserRole(ctx, existing.ID.String(), "admin", "")
+		if err != nil {
+			return err
+		}
+		log.Printf("✓ %s (%s) is now an admin", user.Email, user.ID.String())
+		return nil
+	}
+
+	localPart, _, _ := strings.Cut(*email, "@")
+	if *name == "" {
+		*name = localPart
+	}
+	if *username == "" {
+		*username = localPart
+	}
+
+	password, err := readAdminPassword(cfg.Admin.Password)
+	if err != nil {
+		return err
+	}
+
+	user := &model.User{Name: *name, Username: *username, Email: *email, Password: password}
+	if err := authService.CreateAdmin(ctx, user); err != nil {
+		if errors.Is(err, services.ErrEmailAlreadyRegistered) {
+			return fmt.Errorf("%w; use --promote to make that account an admin", err)
+		}
+		return err
+	}
+
+	log.Printf("✓ Admin %s created (%s)", user.Email, user.ID.String())
+	return nil
+}
+
+// readAdminPassword - QUILLHUB_ADMIN_PASSWORD (configured), or one line from standard input.
+// There is deliberately no flag: it would end up in the shell history.
+func readAdminPassword(configured string) (string, error) {
+	if configured != "" {
+		return configured, nil
+	}
+
+	fmt.Fprint(os.Stderr, "Password for the new admin: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("no password given; type it or set QUILLHUB_ADMIN_PASSWORD")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
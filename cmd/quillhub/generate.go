@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/britinogn/quillhub/internal/repository"
+	"github.com/britinogn/quillhub/internal/services"
+)
+
+// runGeneratePost - Generate one AI post about a chosen topic and wait for it, the same way
+// the auto-poster does: recorded as a generation job, within the AI budget, and queued for
+// review when AUTO_POSTER_REQUIRE_REVIEW is set
+func runGeneratePost(ctx context.Context, args []string) error {
+	fs := newFlagSet("generate-post", `--topic "TOPIC" [--category CATEGORY] [--persona PERSONA_ID]`)
+	topic := fs.String("topic", "", "what the post is about; a topic from the pool also starts its cooldown (required)")
+	category := fs.String("category", "", "category of the post (default: the topic's, then a weighted pick)")
+	personaID := fs.String("persona", "", "ID of the bot persona that writes it (default: the default persona)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if strings.TrimSpace(*topic) == "" {
+		fs.Usage()
+		return errors.New("--topic is required")
+	}
+
+	cfg, dbPool, err := connectDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	llmProvider, err := services.NewLLMProvider(ctx, services.LoadLLMConfig(cfg.LLM))
+	if err != nil {
+		return fmt.Errorf("LLM provider unavailable: %w", err)
+	}
+
+	userRepo := repository.NewUserRepository(dbPool)
+	postRepo := repository.NewPostRepository(dbPool)
+
+	auditService := services.NewAuditService(repository.NewAuditRepository(dbPool))
+	aiUsageService := services.NewAIUsageService(repository.NewAIUsageRepository(dbPool), services.LoadAIUsageConfig(cfg.AIUsage))
+	aiService := services.NewAIService(llmProvider, aiUsageService)
+	defer aiService.Close()
+
+	generationJobService := services.NewGenerationJobService(repository.NewGenerationJobRepository(dbPool), aiService, cfg.Generation)
+	topicService := services.NewTopicService(repository.NewTopicRepository(dbPool), auditService)
+	personaService := services.NewPersonaService(repository.NewPersonaRepository(dbPool), userRepo, auditService)
+
+	// The default persona is created by the server on first start; make sure it exists
+	botUserID, err := userRepo.GetOrCreateAIBot(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to setup AI bot user: %w", err)
+	}
+	if _, err := personaService.EnsureDefault(ctx, botUserID); err != nil {
+		return fmt.Errorf("failed to setup default persona: %w", err)
+	}
+
+	autoPoster := services.NewAutoPosterService(generationJobService, topicService, postRepo, personaService, auditService, aiUsageService, cfg.AutoPoster)
+	postID, err := autoPoster.PostAbout(ctx, *personaID, *topic, *category)
+	if err != nil {
+		return err
+	}
+
+	post, err := postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post != nil && !post.IsPublished {
+		log.Printf("✓ Generated %q (%s), waiting for review", post.Title, postID)
+		return nil
+	}
+	if post != nil {
+		log.Printf("✓ Published %q (%s)", post.Title, postID)
+		return nil
+	}
+	log.Printf("✓ Generated post %s", postID)
+	return nil
+}
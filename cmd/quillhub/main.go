@@ -0,0 +1,142 @@
+// Command quillhub runs the API server and the tasks operators need around it.
+//
+//	quillhub serve                          run the HTTP API (the default)
+//	quillhub migrate up|down|status [N]     apply, revert or list database migrations
+//	quillhub create-admin --email ...       create the first admin, or promote an account
+//	quillhub seed                           fill a development database with demo content
+//	quillhub reindex-search [--all]         embed posts for semantic search
+//	quillhub recount-stats                  rebuild derived counters and print the totals
+//	quillhub generate-post --topic "..."    write an AI post now, as a bot persona
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/internal/database"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/britinogn/quillhub/pkg/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+var commands = []command{
+	{"serve", "run the HTTP API server", runServe},
+	{"migrate", "apply, revert or list database migrations (up [N] | down [N] | status)", runMigrate},
+	{"create-admin", "create an admin account, or promote an existing one with --promote", runCreateAdmin},
+	{"seed", "fill a development database with demo users, posts, comments and likes", runSeed},
+	{"reindex-search", "embed posts that are missing or stale; --all rebuilds every embedding", runReindexSearch},
+	{"recount-stats", "rebuild derived counters and print the site totals", runRecountStats},
+	{"generate-post", "generate an AI post on --topic now, as a bot persona", runGeneratePost},
+}
+
+func main() {
+	// Create root context cancelled on OS signals (SIGINT, SIGTERM, SIGQUIT)
+	ctx, stop := signal.NotifyContext(context.Background(),
+		syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	defer stop()
+
+	name, args := "serve", os.Args[1:]
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+
+	if name == "help" || name == "-h" || name == "--help" {
+		usage(os.Stdout)
+		return
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		runCtx := ctx
+		if cmd.name != "serve" {
+			runCtx = auditContext(ctx, cmd.name)
+		}
+		if err := cmd.run(runCtx, args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return
+			}
+			log.Fatalf("%s: %v", name, err)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	usage(os.Stderr)
+	os.Exit(2)
+}
+
+func usage(w *os.File) {
+	fmt.Fprintln(w, "usage: quillhub <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-15s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "quillhub <command> -h" for the flags of a command.`)
+}
+
+// auditContext - Audit entries written by a task command record the actor role "cli" and
+// the command as their request ID, so they can be told apart from API calls
+func auditContext(ctx context.Context, name string) context.Context {
+	ctx = utils.WithActor(ctx, utils.Actor{Role: "cli"})
+	return utils.WithRequestInfo(ctx, utils.RequestInfo{RequestID: "cli:" + name})
+}
+
+// newFlagSet - Flags for one command; parse errors are returned instead of exiting
+func newFlagSet(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: quillhub %s %s\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// connectDatabase - Settings with only the database required, and a pool to it.
+// For every command except serve, which needs the full configuration.
+func connectDatabase(ctx context.Context) (*config.Config, *pgxpool.Pool, error) {
+	cfg, err := config.LoadDatabase("")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := logger.Setup(logger.Options{Level: cfg.Log.Level, Format: cfg.Log.Format}); err != nil {
+		return nil, nil, err
+	}
+	logConfigSources(ctx, cfg)
+
+	dbCtx, cancel := context.WithTimeout(ctx, cfg.Database.ConnectTimeout)
+	defer cancel()
+
+	dbPool, err := database.ConnectPostgres(dbCtx, cfg.Database)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return cfg, dbPool, nil
+}
+
+// logConfigSources - Where the settings came from, once the logger is set up
+func logConfigSources(ctx context.Context, cfg *config.Config) {
+	if len(cfg.Sources) == 0 {
+		logger.Infof(ctx, "[CONFIG] No config file or .env found; using defaults and the environment")
+		return
+	}
+	logger.Infof(ctx, "[CONFIG] Loaded settings from %s", strings.Join(cfg.Sources, ", "))
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/britinogn/quillhub/internal/database"
+	"github.com/britinogn/quillhub/migrations"
+)
+
+const migrateUsage = "up [N] | down [N] | status"
+
+// runMigrate - up applies pending migrations (all, or the next N), down reverts the
+// last N (default 1), status lists applied and pending ones
+func runMigrate(ctx context.Context, args []string) error {
+	fs := newFlagSet("migrate", migrateUsage)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return fmt.Errorf("expected %s", migrateUsage)
+	}
+	action := fs.Arg(0)
+	steps := 0
+	if fs.NArg() == 2 {
+		n, err := strconv.Atoi(fs.Arg(1))
+		if err != nil || n < 1 || action == "status" {
+			return fmt.Errorf("expected %s", migrateUsage)
+		}
+		steps = n
+	}
+
+	_, dbPool, err := connectDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	migrator, err := database.NewMigrator(dbPool, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx, steps)
+		if err != nil {
+			return err
+		}
+		log.Printf("✓ Applied %d migration(s)", len(applied))
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		log.Printf("✓ Reverted %d migration(s)", len(reverted))
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(statuses)
+	default:
+		return fmt.Errorf("unknown migrate command %q, expected %s", action, migrateUsage)
+	}
+	return nil
+}
+
+func printMigrationStatus(statuses []database.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, s := range statuses {
+		state, appliedAt := "pending", "-"
+		if s.Applied {
+			state = "applied"
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		switch {
+		case s.Missing:
+			state += " (file missing)"
+		case s.Modified:
+			state += " (file changed)"
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+	}
+	w.Flush()
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/britinogn/quillhub/internal/repository"
+	"github.com/britinogn/quillhub/internal/services"
+)
+
+// runReindexSearch - Embed posts for related posts and semantic search now, instead of
+// waiting for the server's background backfill; --all drops every embedding first,
+// e.g. after changing EMBEDDING_PROVIDER or EMBEDDING_MODEL
+func runReindexSearch(ctx context.Context, args []string) error {
+	fs := newFlagSet("reindex-search", "[--all]")
+	all := fs.Bool("all", false, "delete every embedding and rebuild them all")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, dbPool, err := connectDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	provider, err := services.NewEmbeddingProvider(ctx, services.LoadEmbeddingConfig(cfg.LLM, cfg.Embedding))
+	if err != nil {
+		return err
+	}
+	if provider != nil {
+		defer provider.Close()
+	}
+
+	embeddingService := services.NewEmbeddingService(repository.NewSearchRepository(dbPool), repository.NewPostRepository(dbPool), provider)
+
+	var embedded int
+	if *all {
+		embedded, err = embeddingService.Reindex(ctx)
+	} else {
+		embedded, err = embeddingService.Backfill(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✓ Embedded %d post(s)", embedded)
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/britinogn/quillhub/internal/repository"
+	"github.com/britinogn/quillhub/internal/services"
+)
+
+var errSeedProduction = errors.New("refusing to seed demo data with ENVIRONMENT=production")
+
+// runSeed - Demo users, posts, comments and likes for a development database; refuses
+// to run twice and in production
+func runSeed(ctx context.Context, args []string) error {
+	fs := newFlagSet("seed", "[--password PASSWORD]")
+	password := fs.String("password", "quillhub-demo", "password of every demo account")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, dbPool, err := connectDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	if cfg.IsProduction() {
+		return errSeedProduction
+	}
+
+	seedService := services.NewSeedService(repository.NewSeedRepository(dbPool))
+	result, err := seedService.Seed(ctx, *password)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✓ Seeded %d users, %d posts, %d comments and %d likes", result.Users, result.Posts, result.Comments, result.Likes)
+	log.Printf("  Sign in as any of %v with password %q", result.Emails, *password)
+	return nil
+}
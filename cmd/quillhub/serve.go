@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/internal/database"
+	"github.com/britinogn/quillhub/internal/handlers"
+	"github.com/britinogn/quillhub/internal/middleware"
+	"github.com/britinogn/quillhub/internal/repository"
+	"github.com/britinogn/quillhub/internal/routes"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/britinogn/quillhub/pkg/ratelimit"
+	"github.com/britinogn/quillhub/pkg/utils"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// runServe - Run the HTTP API until ctx is cancelled, then shut down gracefully
+func runServe(ctx context.Context, args []string) error {
+	if err := newFlagSet("serve", "").Parse(args); err != nil {
+		return err
+	}
+
+	// Settings from config/config.yaml (or CONFIG_FILE), .env and the environment, in that order
+	cfg, err := config.Load("")
+	if err != nil {
+		return err
+	}
+	if err := logger.Setup(logger.Options{Level: cfg.Log.Level, Format: cfg.Log.Format}); err != nil {
+		return err
+	}
+	logConfigSources(ctx, cfg)
+
+	// Connect to PostgreSQL with timeout
+	dbCtx, cancel := context.WithTimeout(ctx, cfg.Database.ConnectTimeout)
+	defer cancel()
+
+	dbPool, err := database.ConnectPostgres(dbCtx, cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer dbPool.Close()
+
+	logger.Infof(ctx, "✓ Database connected successfully")
+
+	// Apply pending migrations; replicas starting together wait on the migration lock
+	if cfg.Database.AutoMigrate {
+		if err := database.RunMigrations(ctx, dbPool); err != nil {
+			return fmt.Errorf("failed to run migrations: %w", err)
+		}
+	}
+
+	// Rate limiting: Redis shares the counters between instances, memory keeps them per process
+	var rateLimitStore ratelimit.Store
+	if cfg.RateLimit.Enabled {
+		switch cfg.RateLimit.Backend {
+		case "redis":
+			redisClient, err := database.ConnectRedis(ctx, cfg.Redis)
+			if err != nil {
+				return fmt.Errorf("failed to connect to redis: %w", err)
+			}
+			defer redisClient.Close()
+			rateLimitStore = ratelimit.NewRedisStore(redisClient)
+		default:
+			memoryStore := ratelimit.NewMemoryStore()
+			defer memoryStore.Stop()
+			rateLimitStore = memoryStore
+		}
+		logger.Infof(ctx, "✓ Rate limiting enabled (%s backend)", cfg.RateLimit.Backend)
+	}
+
+	// Initialize Cloudinary client
+	cld, err := database.NewCloudinary(cfg.Cloudinary)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Cloudinary: %w", err)
+	}
+	logger.Infof(ctx, "✓ Cloudinary initialized successfully")
+
+	// Initialize repositories
+	userRepo := repository.NewUserRepository(dbPool)
+	postRepo := repository.NewPostRepository(dbPool)
+	commentRepo := repository.NewCommentRepository(dbPool)
+	dashboardRepo := repository.NewDashboardRepository(dbPool)
+	identityRepo := repository.NewIdentityRepository(dbPool)
+	sessionRepo := repository.NewSessionRepository(dbPool)
+	auditRepo := repository.NewAuditRepository(dbPool)
+	inviteRepo := repository.NewInviteRepository(dbPool)
+	accountRepo := repository.NewAccountRepository(dbPool)
+	topicRepo := repository.NewTopicRepository(dbPool)
+	generationJobRepo := repository.NewGenerationJobRepository(dbPool)
+	assistantRepo := repository.NewAssistantRepository(dbPool)
+	searchRepo := repository.NewSearchRepository(dbPool)
+	aiUsageRepo := repository.NewAIUsageRepository(dbPool)
+	personaRepo := repository.NewPersonaRepository(dbPool)
+	translationRepo := repository.NewTranslationRepository(dbPool)
+	postImageRepo := repository.NewPostImageRepository(dbPool)
+
+	// Get or create AI bot user
+	botUserID, err := userRepo.GetOrCreateAIBot(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to setup AI bot user: %w", err)
+	}
+
+	// Initialize services
+	auditService := services.NewAuditService(auditRepo)
+	sessionService := services.NewSessionService(sessionRepo, auditService, utils.NewTokenSigner(cfg.JWT.Secret, cfg.JWT.ExpiresIn))
+	registrationPolicy := services.LoadRegistrationPolicy(cfg.Registration)
+	authService := services.NewAuthService(userRepo, sessionService, auditService, inviteRepo, registrationPolicy)
+	inviteService := services.NewInviteService(inviteRepo, auditService)
+	// Post embeddings for related posts and semantic search (see EMBEDDING_PROVIDER)
+	embeddingProvider, err := services.NewEmbeddingProvider(ctx, services.LoadEmbeddingConfig(cfg.LLM, cfg.Embedding))
+	if err != nil {
+		logger.Warnf(ctx, "⚠️  Embedding provider unavailable, semantic search disabled: %v", err)
+	}
+	if embeddingProvider != nil {
+		defer embeddingProvider.Close()
+	}
+	embeddingService := services.NewEmbeddingService(searchRepo, postRepo, embeddingProvider)
+	embeddingService.Start()
+	defer embeddingService.Stop()
+	// Initialize LLM provider (gemini, openai-compatible or fake, see LLM_PROVIDER)
+	llmProvider, err := services.NewLLMProvider(ctx, services.LoadLLMConfig(cfg.LLM))
+	if err != nil {
+		logger.Warnf(ctx, "⚠️  LLM provider unavailable, AI features disabled: %v", err)
+	}
+	// Every LLM call is recorded with its tokens and cost; monthly budgets see AI_MONTHLY_BUDGET_USD
+	aiUsageService := services.NewAIUsageService(aiUsageRepo, services.LoadAIUsageConfig(cfg.AIUsage))
+	aiService := services.NewAIService(llmProvider, aiUsageService)
+	// Post translations by the AI or by hand; readers pick a language with ?lang= or Accept-Language
+	translationService := services.NewTranslationService(translationRepo, postRepo, aiService, auditService)
+	translationService.RecoverStale(ctx)
+	// Alt text and captions for uploaded images, written in the background (see IMAGE_DESCRIPTIONS)
+	postImageService := services.NewPostImageService(postImageRepo, postRepo, aiService, services.LoadPostImageConfig(cfg.Images))
+	postImageService.Start()
+	defer postImageService.Stop()
+	postService := services.NewPostService(postRepo, cld, auditService, embeddingService, translationService, postImageService)
+	// With BOT_REPLIES=true, bot personas answer new comments on their own posts
+	botReplyService := services.NewBotReplyService(commentRepo, postRepo, personaRepo, aiService, services.LoadBotReplyConfig(cfg.BotReplies))
+	// New comments pass local rules and, with COMMENT_MODERATION_LLM=true, an AI classifier
+	moderationService := services.NewModerationService(commentRepo, aiService, auditService, botReplyService, services.LoadModerationConfig(cfg.Moderation))
+	commentService := services.NewCommentService(commentRepo, postRepo, auditService, moderationService, botReplyService)
+	topicService := services.NewTopicService(topicRepo, auditService)
+	generationJobService := services.NewGenerationJobService(generationJobRepo, aiService, cfg.Generation)
+	generationJobService.RecoverStale(ctx)
+	// Bot personas: the original bot user becomes the default persona
+	personaService := services.NewPersonaService(personaRepo, userRepo, auditService)
+	if _, err := personaService.EnsureDefault(ctx, botUserID); err != nil {
+		return fmt.Errorf("failed to setup default persona: %w", err)
+	}
+	postReviewService := services.NewPostReviewService(postRepo, generationJobService, personaService, auditService)
+	assistantService := services.NewAssistantService(aiService, assistantRepo, cfg.Assistant.DailyQuota)
+
+	// Personal data exports and account deletion run in a background worker
+	accountService := services.NewAccountService(accountRepo, postRepo, cld, auditService, services.LoadAccountConfig(cfg.Account, cfg.Cloudinary))
+	accountService.Start()
+	defer accountService.Stop()
+
+	// Initialize OIDC social login providers
+	var oidcProviders []services.OIDCProvider
+	for _, providerCfg := range services.LoadOIDCProviderConfigs(cfg.OIDC) {
+		oidcProviders = append(oidcProviders, services.NewGenericOIDCProvider(providerCfg))
+	}
+	oidcService := services.NewOIDCService(userRepo, identityRepo, sessionService, auditService, registrationPolicy, oidcProviders...)
+
+	// Create auto-poster service
+	autoPoster := services.NewAutoPosterService(generationJobService, topicService, postRepo, personaService, auditService, aiUsageService, cfg.AutoPoster)
+
+	// Start auto-poster (admins can start/stop it at runtime)
+	if cfg.AutoPoster.Enabled {
+		if err := autoPoster.Start(); err != nil {
+			logger.Warnf(ctx, "⚠️  Auto-poster not started, start it from the admin API: %v", err)
+		}
+	}
+	defer autoPoster.Stop()
+	defer aiService.Close() // ✅ Clean up client
+
+	// Initialize dashboard service 
+	dashboardService := services.NewDashboardService(dashboardRepo, aiUsageService)
+
+	// Initialize handlers
+	authHandler := handlers.NewAuthHandler(authService)
+	postHandler := handlers.NewPostHandler(postService)
+	commentHandler := handlers.NewCommentHandler(commentService)
+	dashboardHandler := handlers.NewDashboardHandler(dashboardService)
+	oidcHandler := handlers.NewOIDCHandler(oidcService)
+	sessionHandler := handlers.NewSessionHandler(sessionService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	inviteHandler := handlers.NewInviteHandler(inviteService)
+	accountHandler := handlers.NewAccountHandler(accountService)
+	autoPosterHandler := handlers.NewAutoPosterHandler(autoPoster)
+	topicHandler := handlers.NewTopicHandler(topicService)
+	generationJobHandler := handlers.NewGenerationJobHandler(generationJobService)
+	postReviewHandler := handlers.NewPostReviewHandler(postReviewService)
+	assistantHandler := handlers.NewAssistantHandler(assistantService)
+	searchHandler := handlers.NewSearchHandler(embeddingService)
+	moderationHandler := handlers.NewModerationHandler(moderationService)
+	aiUsageHandler := handlers.NewAIUsageHandler(aiUsageService)
+	personaHandler := handlers.NewPersonaHandler(personaService)
+	translationHandler := handlers.NewTranslationHandler(translationService)
+	postImageHandler := handlers.NewPostImageHandler(postImageService)
+
+	// Configure Gin router
+	gin.SetMode(cfg.Server.GinMode)
+
+	// Request IDs first so the access log and any panic carry them
+	router := gin.New()
+	router.Use(middleware.RequestInfo(), middleware.AccessLog(), middleware.Recovery())
+
+	// Client IPs feed the access log, audit log, sessions and rate limits, so only named
+	// proxies may set them through X-Forwarded-For; with none, the peer address is used
+	var trustedProxies []string
+	if len(cfg.Server.TrustedProxies) > 0 {
+		trustedProxies = cfg.Server.TrustedProxies
+	}
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		return fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+
+	// Add CORS middleware with explicit config
+	router.Use(cors.New(cors.Config{
+		AllowOrigins:     cfg.CORS.AllowedOrigins,
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "X-Request-ID"},
+		ExposeHeaders:    append([]string{"Content-Length", "X-Request-ID"}, middleware.RateLimitHeaders...),
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
+	}))
+
+	// Register all application routes
+	routes.RegisterRoutes(
+		router,
+		sessionService,
+		middleware.NewRateLimits(rateLimitStore, cfg.RateLimit),
+		authHandler,
+		postHandler,
+		commentHandler,
+		dashboardHandler,
+		oidcHandler,
+		sessionHandler,
+		auditHandler,
+		inviteHandler,
+		accountHandler,
+		autoPosterHandler,
+		topicHandler,
+		generationJobHandler,
+		postReviewHandler,
+		assistantHandler,
+		searchHandler,
+		moderationHandler,
+		aiUsageHandler,
+		personaHandler,
+		translationHandler,
+		postImageHandler,
+	)
+
+	// Configure HTTP server with timeouts
+	port := cfg.Server.Port
+	srv := &http.Server{
+		Addr:         ":" + port,
+		Handler:      router,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
+
+	// Start server in background goroutine
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Infof(ctx, "🚀 QuillHub API server starting on http://localhost:%s", port)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serveErr <- err
+		}
+	}()
+
+	// Wait for shutdown signal
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server failed to start: %w", err)
+	case <-ctx.Done():
+	}
+	logger.Infof(ctx, "⏳ Shutdown signal received")
+
+	// Graceful shutdown with timeout
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Errorf(ctx, "Server forced shutdown: %v", err)
+	}
+
+	logger.Infof(ctx, "✓ Server shutdown complete")
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/britinogn/quillhub/internal/repository"
+	"github.com/britinogn/quillhub/internal/services"
+)
+
+// runRecountStats - Rebuild stored values derived from other tables and print the totals
+func runRecountStats(ctx context.Context, args []string) error {
+	if err := newFlagSet("recount-stats", "").Parse(args); err != nil {
+		return err
+	}
+
+	_, dbPool, err := connectDatabase(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	dashboardService := services.NewDashboardService(repository.NewDashboardRepository(dbPool), nil)
+	statsService := services.NewStatsService(repository.NewStatsRepository(dbPool), dashboardService)
+
+	result, err := statsService.Recount(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✓ Topic usage corrected for %d topic(s)", result.TopicsUpdated)
+	log.Printf("✓ Assistant token totals corrected for %d user-day(s)", result.AssistantDaysUpdated)
+	log.Printf("  Users: %d | Posts: %d | Comments: %d | Likes: %d",
+		result.TotalUsers, result.TotalPosts, result.TotalComments, result.TotalLikes)
+	return nil
+}
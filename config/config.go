@@ -1,115 +1,958 @@
 package config
 
 import (
-    "fmt"
-    "os"
-    "strconv"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/joho/godotenv"
+	"github.com/robfig/cron/v3"
 )
 
+// DefaultFile is read when CONFIG_FILE is not set; it is optional
+const DefaultFile = "config/config.yaml"
+
+// Config - Application settings, loaded once at startup.
+// Precedence, lowest to highest: built-in defaults, the YAML file, .env, the process environment.
 type Config struct {
-    Server   ServerConfig
-    Database DatabaseConfig
-    Redis    RedisConfig
-    JWT      JWTConfig
-    Email    EmailConfig
-    Cloudinary CloudinaryConfig
+	Server     ServerConfig     `yaml:"server"`
+	Log        LogConfig        `yaml:"log"`
+	CORS       CORSConfig       `yaml:"cors"`
+	Database   DatabaseConfig   `yaml:"database"`
+	Redis      RedisConfig      `yaml:"redis"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	JWT        JWTConfig        `yaml:"jwt"`
+	Email      EmailConfig      `yaml:"email"`
+	Cloudinary CloudinaryConfig `yaml:"cloudinary"`
+	LLM        LLMConfig        `yaml:"llm"`
+	AutoPoster AutoPosterConfig `yaml:"auto_poster"`
+
+	Registration RegistrationConfig `yaml:"registration"`
+	OIDC         OIDCConfig         `yaml:"oidc"`
+	Account      AccountConfig      `yaml:"account"`
+	Embedding    EmbeddingConfig    `yaml:"embedding"`
+	Generation   GenerationConfig   `yaml:"generation"`
+	Assistant    AssistantConfig    `yaml:"assistant"`
+	AIUsage      AIUsageConfig      `yaml:"ai_usage"`
+	Images       ImageConfig        `yaml:"image_descriptions"`
+	Moderation   ModerationConfig   `yaml:"moderation"`
+	BotReplies   BotReplyConfig     `yaml:"bot_replies"`
+	Admin        AdminConfig        `yaml:"-"`
+
+	// Sources - The files the settings were read from, lowest precedence first; config
+	// loads before the logger is set up, so the caller logs them
+	Sources []string `yaml:"-"`
 }
 
 type ServerConfig struct {
-    Port        string
-    Environment string
-    FrontendURL string
+	Port            string        `yaml:"port"`
+	Environment     string        `yaml:"environment"`
+	GinMode         string        `yaml:"gin_mode"` // debug | release | test
+	FrontendURL     string        `yaml:"frontend_url"`
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	TrustedProxies  []string      `yaml:"trusted_proxies"` // whose X-Forwarded-For is believed; empty trusts none
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level"`  // debug | info | warn | error
+	Format string `yaml:"format"` // text | json
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins"`
+	AllowCredentials bool          `yaml:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age"`
 }
 
 type DatabaseConfig struct {
-    Host     string
-    Port     string
-    User     string
-    Password string
-    DBName   string
-    SSLMode  string
+	Host              string        `yaml:"host"`
+	Port              string        `yaml:"port"`
+	User              string        `yaml:"user"`
+	Password          string        `yaml:"password"`
+	DBName            string        `yaml:"name"`
+	SSLMode           string        `yaml:"ssl_mode"`
+	MaxConns          int32         `yaml:"max_conns"`
+	MinConns          int32         `yaml:"min_conns"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period"`
+	ConnectTimeout    time.Duration `yaml:"connect_timeout"`
+	AutoMigrate       bool          `yaml:"auto_migrate"` // apply pending migrations when the server starts
 }
 
 type RedisConfig struct {
-    Host string
-    Port string
-    URL  string
+	Host string `yaml:"host"`
+	Port string `yaml:"port"`
+	URL  string `yaml:"url"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool            `yaml:"enabled"`
+	Backend string          `yaml:"backend"` // memory | redis
+	Auth    RateLimitPolicy `yaml:"auth"`    // signup, login and social sign-in
+	Comment RateLimitPolicy `yaml:"comment"` // new comments
+	Write   RateLimitPolicy `yaml:"write"`   // every other authenticated POST, PUT, PATCH and DELETE
+	Read    RateLimitPolicy `yaml:"read"`    // every GET
+}
+
+type RateLimitPolicy struct {
+	Requests  int           `yaml:"requests"`
+	Window    time.Duration `yaml:"window"`
+	Algorithm string        `yaml:"algorithm"` // sliding_window | token_bucket
+	Key       string        `yaml:"key"`       // ip | user | token; user and token fall back to ip
 }
 
 type JWTConfig struct {
-    Secret    string
-    ExpiresIn string
+	Secret    string        `yaml:"secret"`
+	ExpiresIn time.Duration `yaml:"expires_in"`
 }
 
 type EmailConfig struct {
-    User string
-    Pass string
+	User string `yaml:"user"`
+	Pass string `yaml:"pass"`
 }
 
 type CloudinaryConfig struct {
-    CloudName string
-    APIKey    string
-    APISecret string
-}
-
-// Load reads configuration from environment variables
-func Load() (*Config, error) {
-    cfg := &Config{
-        Server: ServerConfig{
-            Port:        getEnv("PORT", "8080"),
-            Environment: getEnv("ENVIRONMENT", "development"),
-            FrontendURL: getEnv("FRONTEND_URL", "http://localhost:3000"),
-        },
-        Database: DatabaseConfig{
-            Host:     getEnv("DB_HOST", "localhost"),
-            Port:     getEnv("DB_PORT", "5432"),
-            User:     getEnv("DB_USER", "admin"),
-            Password: getEnv("DB_PASSWORD", "password123"),
-            DBName:   getEnv("DB_NAME", "databaseName"),
-            SSLMode:  getEnv("DB_SSLMODE", "disable"),
-        },
-        Redis: RedisConfig{
-            Host: getEnv("REDIS_HOST", "localhost"),
-            Port: getEnv("REDIS_PORT", "6379"),
-            URL:  getEnv("REDIS_URL", ""),
-        },
-        JWT: JWTConfig{
-            Secret:    getEnv("JWT_SECRET", ""),
-            ExpiresIn: getEnv("JWT_EXPIRES_IN", "24h"),
-        },
-        Email: EmailConfig{
-            User: getEnv("EMAIL_USER", ""),
-            Pass: getEnv("EMAIL_PASS", ""),
-        },
-        Cloudinary: CloudinaryConfig{
-            CloudName: getEnv("CLOUDINARY_CLOUD_NAME", ""),
-            APIKey:    getEnv("CLOUDINARY_API_KEY", ""),
-            APISecret: getEnv("CLOUDINARY_API_SECRET", ""),
-        },
-    }
-
-    // Validate required fields
-    if cfg.JWT.Secret == "" {
-        return nil, fmt.Errorf("JWT_SECRET is required")
-    }
-
-    return cfg, nil
-}
-
-// getEnv reads an environment variable or returns a default value
-func getEnv(key, defaultValue string) string {
-    if value := os.Getenv(key); value != "" {
-        return value
-    }
-    return defaultValue
-}
-
-// getEnvAsInt reads an environment variable as integer or returns default
-func getEnvAsInt(key string, defaultValue int) int {
-    if value := os.Getenv(key); value != "" {
-        if intVal, err := strconv.Atoi(value); err == nil {
-            return intVal
-        }
-    }
-    return defaultValue
-}
\ No newline at end of file
+	CloudName string `yaml:"cloud_name"`
+	APIKey    string `yaml:"api_key"`
+	APISecret string `yaml:"api_secret"`
+}
+
+type LLMConfig struct {
+	Provider      string `yaml:"provider"` // gemini | openai | fake
+	Model         string `yaml:"model"`
+	GeminiAPIKey  string `yaml:"gemini_api_key"`
+	OpenAIAPIKey  string `yaml:"openai_api_key"`
+	OpenAIBaseURL string `yaml:"openai_base_url"`
+}
+
+type AutoPosterConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	RequireReview bool          `yaml:"require_review"`
+	Interval      time.Duration `yaml:"interval"`
+	Cron          string        `yaml:"cron"` // wins over interval when set
+}
+
+type RegistrationConfig struct {
+	Mode           string   `yaml:"mode"` // open | invite_only | domain_restricted
+	AllowedDomains []string `yaml:"allowed_domains"`
+}
+
+type OIDCConfig struct {
+	Providers []OIDCProviderConfig `yaml:"providers"`
+}
+
+type OIDCProviderConfig struct {
+	Name         string   `yaml:"name"`
+	DisplayName  string   `yaml:"display_name"`
+	IssuerURL    string   `yaml:"issuer_url"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	RedirectURL  string   `yaml:"redirect_url"` // empty uses this server's callback on localhost:8080
+	Scopes       []string `yaml:"scopes"`       // empty asks for openid, email and profile
+}
+
+type AccountConfig struct {
+	ExportDir       string        `yaml:"export_dir"`
+	ExportTTL       time.Duration `yaml:"export_ttl"`
+	DeletionCoolOff time.Duration `yaml:"deletion_cool_off"` // 0 makes deletions final immediately
+}
+
+type EmbeddingConfig struct {
+	Provider string `yaml:"provider"` // gemini | openai | local | none; empty follows llm.provider
+	Model    string `yaml:"model"`
+}
+
+type GenerationConfig struct {
+	MaxAttempts  int `yaml:"max_attempts"` // per generation job
+	PostMinWords int `yaml:"post_min_words"`
+	PostMaxWords int `yaml:"post_max_words"`
+}
+
+type AssistantConfig struct {
+	DailyQuota int `yaml:"daily_quota"` // requests per user per UTC day, 0 = unlimited
+}
+
+type AIUsageConfig struct {
+	MonthlyBudget  float64               `yaml:"monthly_budget_usd"` // across all features, 0 = no budget
+	FeatureBudgets map[string]float64    `yaml:"feature_budgets_usd"`
+	ModelPrices    map[string]ModelPrice `yaml:"model_prices"` // added to or replacing the built-in prices
+}
+
+// ModelPrice - USD per million tokens
+type ModelPrice struct {
+	Input  float64 `yaml:"input"`
+	Output float64 `yaml:"output"`
+}
+
+type ImageConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Model   string `yaml:"model"` // a model that accepts images; empty uses llm.model
+}
+
+type ModerationConfig struct {
+	Threshold float64  `yaml:"threshold"` // hold a comment when toxicity or spam reaches this score
+	UseLLM    bool     `yaml:"use_llm"`
+	Blocklist []string `yaml:"blocklist"`
+}
+
+type BotReplyConfig struct {
+	Enabled         bool          `yaml:"enabled"`
+	RequireApproval bool          `yaml:"require_approval"`
+	MaxPerPost      int           `yaml:"max_per_post"` // replies per post within window
+	Window          time.Duration `yaml:"window"`
+	Model           string        `yaml:"model"` // empty uses the persona's model, then llm.model
+}
+
+// AdminConfig - Read from the environment only: a password does not belong in a file
+type AdminConfig struct {
+	Password string // QUILLHUB_ADMIN_PASSWORD, used by create-admin instead of prompting
+}
+
+// Smallest auto-poster interval; shorter ones would burn through the AI budget
+const MinAutoPosterInterval = time.Minute
+
+// Defaults - Settings used when neither the YAML file nor the environment sets them
+func Defaults() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:            "8080",
+			Environment:     "development",
+			GinMode:         "debug",
+			FrontendURL:     "http://localhost:3000",
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    15 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 5 * time.Second,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "text",
+		},
+		CORS: CORSConfig{
+			AllowedOrigins:   []string{"http://localhost:3000", "http://localhost:5173", "https://quill-hub-blog.vercel.app"},
+			AllowCredentials: true,
+			MaxAge:           12 * time.Hour,
+		},
+		Database: DatabaseConfig{
+			SSLMode:           "require",
+			MaxConns:          25,
+			MinConns:          5,
+			MaxConnLifetime:   5 * time.Minute,
+			MaxConnIdleTime:   30 * time.Minute,
+			HealthCheckPeriod: time.Minute,
+			ConnectTimeout:    10 * time.Second,
+		},
+		Redis: RedisConfig{
+			Host: "localhost",
+			Port: "6379",
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Backend: "memory",
+			Auth:    RateLimitPolicy{Requests: 10, Window: time.Minute, Algorithm: "sliding_window", Key: "ip"},
+			Comment: RateLimitPolicy{Requests: 5, Window: time.Minute, Algorithm: "token_bucket", Key: "user"},
+			Write:   RateLimitPolicy{Requests: 60, Window: time.Minute, Algorithm: "token_bucket", Key: "user"},
+			Read:    RateLimitPolicy{Requests: 300, Window: time.Minute, Algorithm: "sliding_window", Key: "ip"},
+		},
+		JWT: JWTConfig{
+			ExpiresIn: 24 * time.Hour,
+		},
+		LLM: LLMConfig{
+			Provider: "gemini",
+		},
+		AutoPoster: AutoPosterConfig{
+			Enabled:  true,
+			Interval: time.Hour,
+		},
+		Registration: RegistrationConfig{
+			Mode: "open",
+		},
+		Account: AccountConfig{
+			ExportDir:       filepath.Join("data", "exports"),
+			ExportTTL:       72 * time.Hour,
+			DeletionCoolOff: 14 * 24 * time.Hour,
+		},
+		Generation: GenerationConfig{
+			MaxAttempts:  3,
+			PostMinWords: 150,
+			PostMaxWords: 350,
+		},
+		Assistant: AssistantConfig{
+			DailyQuota: 20,
+		},
+		Images: ImageConfig{
+			Enabled: true,
+		},
+		Moderation: ModerationConfig{
+			Threshold: 0.7,
+		},
+		BotReplies: BotReplyConfig{
+			MaxPerPost: 5,
+			Window:     24 * time.Hour,
+		},
+	}
+}
+
+// Load - Read .env, the YAML file and the environment, then validate the result.
+// path "" uses CONFIG_FILE, then DefaultFile; only an explicitly named file has to exist.
+func Load(path string) (*Config, error) {
+	return load(path, (*Config).validate)
+}
+
+// LoadDatabase - Like Load, but only the database settings have to be valid.
+// For command-line tools such as the migrator that never start the server.
+func LoadDatabase(path string) (*Config, error) {
+	return load(path, func(c *Config) []string { return c.Database.validate() })
+}
+
+func load(path string, validate func(*Config) []string) (*Config, error) {
+	dotEnv := loadDotEnv()
+
+	cfg := Defaults()
+
+	explicit := path != ""
+	if !explicit {
+		path = os.Getenv("CONFIG_FILE")
+		explicit = path != ""
+	}
+	if !explicit {
+		path = DefaultFile
+	}
+	if err := cfg.loadFile(path, explicit); err != nil {
+		return nil, err
+	}
+	if dotEnv != "" {
+		cfg.Sources = append(cfg.Sources, dotEnv)
+	}
+
+	var problems []string
+	cfg.applyEnv(&problems)
+	problems = append(problems, cfg.Log.validate()...)
+	problems = append(problems, validate(cfg)...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	return cfg, nil
+}
+
+// ValidationError - Every problem found in the configuration, reported together
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// IsProduction - Whether the server runs with production settings
+func (c *Config) IsProduction() bool {
+	return strings.EqualFold(c.Server.Environment, "production")
+}
+
+// loadDotEnv - Copy .env into the environment; variables that are already set win.
+// Returns the file loaded, "" when there is none.
+func loadDotEnv() string {
+	for _, path := range []string{".env", "../.env", "../../.env"} {
+		if err := godotenv.Load(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func (c *Config) loadFile(path string, required bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !required {
+			return nil
+		}
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// Strict: a misspelled key is an error instead of a silently ignored setting
+	if err := yaml.UnmarshalWithOptions(data, c, yaml.Strict()); err != nil {
+		return fmt.Errorf("failed to parse %s:\n%w", path, err)
+	}
+
+	c.Sources = append(c.Sources, path)
+	return nil
+}
+
+// applyEnv - Environment variables override the file; values that do not parse are reported
+func (c *Config) applyEnv(problems *[]string) {
+	envString("PORT", &c.Server.Port)
+	envString("ENVIRONMENT", &c.Server.Environment)
+	envString("GIN_MODE", &c.Server.GinMode)
+	envString("FRONTEND_URL", &c.Server.FrontendURL)
+	envDuration("SERVER_READ_TIMEOUT", &c.Server.ReadTimeout, problems)
+	envDuration("SERVER_WRITE_TIMEOUT", &c.Server.WriteTimeout, problems)
+	envDuration("SERVER_IDLE_TIMEOUT", &c.Server.IdleTimeout, problems)
+	envDuration("SERVER_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout, problems)
+	envList("TRUSTED_PROXIES", &c.Server.TrustedProxies)
+
+	envString("LOG_LEVEL", &c.Log.Level)
+	envString("LOG_FORMAT", &c.Log.Format)
+
+	envList("CORS_ALLOWED_ORIGINS", &c.CORS.AllowedOrigins)
+	envBool("CORS_ALLOW_CREDENTIALS", &c.CORS.AllowCredentials, problems)
+	envDuration("CORS_MAX_AGE", &c.CORS.MaxAge, problems)
+
+	envString("DB_HOST", &c.Database.Host)
+	envString("DB_PORT", &c.Database.Port)
+	envString("DB_USER", &c.Database.User)
+	envString("DB_PASSWORD", &c.Database.Password)
+	envString("DB_NAME", &c.Database.DBName)
+	envString("DB_SSLMODE", &c.Database.SSLMode)
+	envInt32("DB_MAX_CONNS", &c.Database.MaxConns, problems)
+	envInt32("DB_MIN_CONNS", &c.Database.MinConns, problems)
+	envDuration("DB_MAX_CONN_LIFETIME", &c.Database.MaxConnLifetime, problems)
+	envDuration("DB_MAX_CONN_IDLE_TIME", &c.Database.MaxConnIdleTime, problems)
+	envDuration("DB_HEALTH_CHECK_PERIOD", &c.Database.HealthCheckPeriod, problems)
+	envDuration("DB_CONNECT_TIMEOUT", &c.Database.ConnectTimeout, problems)
+	envBool("DB_AUTO_MIGRATE", &c.Database.AutoMigrate, problems)
+
+	envString("REDIS_HOST", &c.Redis.Host)
+	envString("REDIS_PORT", &c.Redis.Port)
+	envString("REDIS_URL", &c.Redis.URL)
+
+	envBool("RATE_LIMIT_ENABLED", &c.RateLimit.Enabled, problems)
+	envString("RATE_LIMIT_BACKEND", &c.RateLimit.Backend)
+	envRate("RATE_LIMIT_AUTH", &c.RateLimit.Auth, problems)
+	envRate("RATE_LIMIT_COMMENT", &c.RateLimit.Comment, problems)
+	envRate("RATE_LIMIT_WRITE", &c.RateLimit.Write, problems)
+	envRate("RATE_LIMIT_READ", &c.RateLimit.Read, problems)
+
+	envString("JWT_SECRET", &c.JWT.Secret)
+	envDuration("JWT_EXPIRES_IN", &c.JWT.ExpiresIn, problems)
+
+	envString("EMAIL_USER", &c.Email.User)
+	envString("EMAIL_PASS", &c.Email.Pass)
+
+	envString("CLOUDINARY_CLOUD_NAME", &c.Cloudinary.CloudName)
+	envString("CLOUDINARY_API_KEY", &c.Cloudinary.APIKey)
+	envString("CLOUDINARY_API_SECRET", &c.Cloudinary.APISecret)
+
+	envString("LLM_PROVIDER", &c.LLM.Provider)
+	envString("LLM_MODEL", &c.LLM.Model)
+	envString("GEMINI_API_KEY", &c.LLM.GeminiAPIKey)
+	envString("OPENAI_API_KEY", &c.LLM.OpenAIAPIKey)
+	envString("OPENAI_BASE_URL", &c.LLM.OpenAIBaseURL)
+
+	envBool("AUTO_POSTER_ENABLED", &c.AutoPoster.Enabled, problems)
+	envBool("AUTO_POSTER_REQUIRE_REVIEW", &c.AutoPoster.RequireReview, problems)
+	envDuration("AUTO_POSTER_INTERVAL", &c.AutoPoster.Interval, problems)
+	envString("AUTO_POSTER_CRON", &c.AutoPoster.Cron)
+
+	envString("REGISTRATION_MODE", &c.Registration.Mode)
+	envList("REGISTRATION_ALLOWED_DOMAINS", &c.Registration.AllowedDomains)
+
+	c.applyOIDCEnv()
+
+	envString("DATA_EXPORT_DIR", &c.Account.ExportDir)
+	envDurationIn("DATA_EXPORT_TTL_HOURS", time.Hour, &c.Account.ExportTTL, problems)
+	envDurationIn("ACCOUNT_DELETION_COOLOFF_DAYS", 24*time.Hour, &c.Account.DeletionCoolOff, problems)
+
+	envString("EMBEDDING_PROVIDER", &c.Embedding.Provider)
+	envString("EMBEDDING_MODEL", &c.Embedding.Model)
+
+	envInt("AI_GENERATION_MAX_ATTEMPTS", &c.Generation.MaxAttempts, problems)
+	envInt("AI_POST_MIN_WORDS", &c.Generation.PostMinWords, problems)
+	envInt("AI_POST_MAX_WORDS", &c.Generation.PostMaxWords, problems)
+	envInt("AI_ASSISTANT_DAILY_QUOTA", &c.Assistant.DailyQuota, problems)
+
+	envFloat("AI_MONTHLY_BUDGET_USD", &c.AIUsage.MonthlyBudget, problems)
+	envFloatMap("AI_FEATURE_BUDGETS_USD", &c.AIUsage.FeatureBudgets, problems)
+	envModelPrices("AI_MODEL_PRICES", &c.AIUsage.ModelPrices, problems)
+
+	envBool("IMAGE_DESCRIPTIONS", &c.Images.Enabled, problems)
+	envString("IMAGE_DESCRIPTION_MODEL", &c.Images.Model)
+
+	envFloat("COMMENT_MODERATION_THRESHOLD", &c.Moderation.Threshold, problems)
+	envBool("COMMENT_MODERATION_LLM", &c.Moderation.UseLLM, problems)
+	envList("COMMENT_MODERATION_BLOCKLIST", &c.Moderation.Blocklist)
+
+	envBool("BOT_REPLIES", &c.BotReplies.Enabled, problems)
+	envBool("BOT_REPLY_REQUIRE_APPROVAL", &c.BotReplies.RequireApproval, problems)
+	envInt("BOT_REPLY_MAX_PER_POST", &c.BotReplies.MaxPerPost, problems)
+	envDuration("BOT_REPLY_WINDOW", &c.BotReplies.Window, problems)
+	envString("BOT_REPLY_MODEL", &c.BotReplies.Model)
+
+	envString("QUILLHUB_ADMIN_PASSWORD", &c.Admin.Password)
+
+	c.Log.Level = strings.ToLower(c.Log.Level)
+	c.Log.Format = strings.ToLower(c.Log.Format)
+	c.LLM.Provider = strings.ToLower(c.LLM.Provider)
+	c.RateLimit.Backend = strings.ToLower(c.RateLimit.Backend)
+	c.Registration.Mode = strings.ToLower(c.Registration.Mode)
+	for i, domain := range c.Registration.AllowedDomains {
+		c.Registration.AllowedDomains[i] = strings.ToLower(strings.TrimPrefix(domain, "@"))
+	}
+	c.Embedding.Provider = strings.ToLower(c.Embedding.Provider)
+	c.LLM.OpenAIBaseURL = strings.TrimRight(c.LLM.OpenAIBaseURL, "/")
+}
+
+func (c *Config) validate() []string {
+	var problems []string
+	requireSet := func(value, name string) {
+		if value == "" {
+			problems = append(problems, name+" is required")
+		}
+	}
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		problems = append(problems, fmt.Sprintf("PORT %q is not a valid port", c.Server.Port))
+	}
+	switch c.Server.GinMode {
+	case "debug", "release", "test":
+	default:
+		problems = append(problems, fmt.Sprintf("GIN_MODE %q must be debug, release or test", c.Server.GinMode))
+	}
+	requirePositive := func(d time.Duration, name string) {
+		if d <= 0 {
+			problems = append(problems, name+" must be positive")
+		}
+	}
+	requirePositive(c.Server.ReadTimeout, "SERVER_READ_TIMEOUT")
+	requirePositive(c.Server.WriteTimeout, "SERVER_WRITE_TIMEOUT")
+	requirePositive(c.Server.IdleTimeout, "SERVER_IDLE_TIMEOUT")
+	requirePositive(c.Server.ShutdownTimeout, "SERVER_SHUTDOWN_TIMEOUT")
+	requirePositive(c.JWT.ExpiresIn, "JWT_EXPIRES_IN")
+	for _, proxy := range c.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			problems = append(problems, fmt.Sprintf("TRUSTED_PROXIES entry %q is not an IP address or CIDR", proxy))
+		}
+	}
+
+	if len(c.CORS.AllowedOrigins) == 0 {
+		problems = append(problems, "CORS_ALLOWED_ORIGINS needs at least one origin")
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			if c.CORS.AllowCredentials {
+				problems = append(problems, `CORS_ALLOWED_ORIGINS "*" cannot be used with CORS_ALLOW_CREDENTIALS=true`)
+			}
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			problems = append(problems, fmt.Sprintf("CORS origin %q must look like https://example.com", origin))
+		}
+	}
+
+	problems = append(problems, c.Database.validate()...)
+	if c.RateLimit.Enabled {
+		problems = append(problems, c.RateLimit.validate(c.Redis)...)
+	}
+
+	requireSet(c.JWT.Secret, "JWT_SECRET")
+	if c.IsProduction() && c.JWT.Secret != "" && len(c.JWT.Secret) < 32 {
+		problems = append(problems, "JWT_SECRET must be at least 32 characters in production")
+	}
+
+	requireSet(c.Cloudinary.CloudName, "CLOUDINARY_CLOUD_NAME")
+	requireSet(c.Cloudinary.APIKey, "CLOUDINARY_API_KEY")
+	requireSet(c.Cloudinary.APISecret, "CLOUDINARY_API_SECRET")
+
+	// A missing API key only turns the AI features off; an unknown provider is a typo
+	switch c.LLM.Provider {
+	case "gemini", "openai", "fake":
+	default:
+		problems = append(problems, fmt.Sprintf("LLM_PROVIDER %q must be gemini, openai or fake", c.LLM.Provider))
+	}
+
+	if c.AutoPoster.Cron != "" {
+		if _, err := cron.ParseStandard(c.AutoPoster.Cron); err != nil {
+			problems = append(problems, fmt.Sprintf("AUTO_POSTER_CRON %q: %v", c.AutoPoster.Cron, err))
+		}
+	} else if c.AutoPoster.Interval < MinAutoPosterInterval {
+		problems = append(problems, fmt.Sprintf("AUTO_POSTER_INTERVAL must be at least %s", MinAutoPosterInterval))
+	}
+
+	problems = append(problems, c.validateFeatures()...)
+
+	return problems
+}
+
+// validateFeatures - Settings of the optional features; each one is off or has sane limits
+func (c *Config) validateFeatures() []string {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	switch c.Registration.Mode {
+	case "open", "invite_only", "domain_restricted":
+	default:
+		add("REGISTRATION_MODE %q must be open, invite_only or domain_restricted", c.Registration.Mode)
+	}
+
+	seen := make(map[string]bool)
+	for _, provider := range c.OIDC.Providers {
+		if provider.Name == "" {
+			add("every OIDC provider needs a name")
+			continue
+		}
+		if seen[provider.Name] {
+			add("OIDC provider %q is configured twice", provider.Name)
+		}
+		seen[provider.Name] = true
+
+		prefix := oidcEnvPrefix(provider.Name)
+		if u, err := url.Parse(provider.IssuerURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("%sISSUER_URL %q must look like https://accounts.example.com", prefix, provider.IssuerURL)
+		}
+		if provider.ClientID == "" {
+			add("%sCLIENT_ID is required", prefix)
+		}
+		if provider.RedirectURL != "" {
+			if u, err := url.Parse(provider.RedirectURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				add("%sREDIRECT_URL %q must be an absolute URL", prefix, provider.RedirectURL)
+			}
+		}
+	}
+
+	if c.Account.ExportDir == "" {
+		add("DATA_EXPORT_DIR must not be empty")
+	}
+	if c.Account.ExportTTL <= 0 {
+		add("DATA_EXPORT_TTL_HOURS must be positive")
+	}
+	if c.Account.DeletionCoolOff < 0 {
+		add("ACCOUNT_DELETION_COOLOFF_DAYS must not be negative")
+	}
+
+	switch c.Embedding.Provider {
+	case "", "gemini", "openai", "local", "none":
+	default:
+		add("EMBEDDING_PROVIDER %q must be gemini, openai, local or none", c.Embedding.Provider)
+	}
+
+	if c.Generation.MaxAttempts < 1 {
+		add("AI_GENERATION_MAX_ATTEMPTS must be at least 1")
+	}
+	if c.Generation.PostMinWords < 1 {
+		add("AI_POST_MIN_WORDS must be at least 1")
+	}
+	if c.Generation.PostMaxWords < c.Generation.PostMinWords {
+		add("AI_POST_MAX_WORDS must not be below AI_POST_MIN_WORDS")
+	}
+	if c.Assistant.DailyQuota < 0 {
+		add("AI_ASSISTANT_DAILY_QUOTA must not be negative")
+	}
+
+	if c.AIUsage.MonthlyBudget < 0 {
+		add("AI_MONTHLY_BUDGET_USD must not be negative")
+	}
+	for feature, budget := range c.AIUsage.FeatureBudgets {
+		if budget < 0 {
+			add("AI_FEATURE_BUDGETS_USD budget for %q must not be negative", feature)
+		}
+	}
+	for model, price := range c.AIUsage.ModelPrices {
+		if price.Input < 0 || price.Output < 0 {
+			add("AI_MODEL_PRICES prices for %q must not be negative", model)
+		}
+	}
+
+	if c.Moderation.Threshold <= 0 || c.Moderation.Threshold > 1 {
+		add("COMMENT_MODERATION_THRESHOLD must be above 0 and at most 1")
+	}
+
+	if c.BotReplies.MaxPerPost < 1 {
+		add("BOT_REPLY_MAX_PER_POST must be at least 1")
+	}
+	if c.BotReplies.Window <= 0 {
+		add("BOT_REPLY_WINDOW must be positive")
+	}
+
+	return problems
+}
+
+// validate - Checked for every command, since all of them log
+func (l *LogConfig) validate() []string {
+	var problems []string
+	switch l.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("LOG_LEVEL %q must be debug, info, warn or error", l.Level))
+	}
+	switch l.Format {
+	case "text", "json":
+	default:
+		problems = append(problems, fmt.Sprintf("LOG_FORMAT %q must be text or json", l.Format))
+	}
+	return problems
+}
+
+func (r *RateLimitConfig) validate(redis RedisConfig) []string {
+	var problems []string
+	switch r.Backend {
+	case "memory":
+	case "redis":
+		if redis.URL == "" && (redis.Host == "" || redis.Port == "") {
+			problems = append(problems, "RATE_LIMIT_BACKEND=redis needs REDIS_URL or REDIS_HOST and REDIS_PORT")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("RATE_LIMIT_BACKEND %q must be memory or redis", r.Backend))
+	}
+
+	for _, policy := range []struct {
+		RateLimitPolicy
+		name string
+	}{
+		{r.Auth, "auth"}, {r.Comment, "comment"}, {r.Write, "write"}, {r.Read, "read"},
+	} {
+		if policy.Requests < 1 {
+			problems = append(problems, fmt.Sprintf("rate_limit.%s.requests must be at least 1", policy.name))
+		}
+		if policy.Window < time.Second {
+			problems = append(problems, fmt.Sprintf("rate_limit.%s.window must be at least 1s", policy.name))
+		}
+		switch policy.Algorithm {
+		case "sliding_window", "token_bucket":
+		default:
+			problems = append(problems, fmt.Sprintf("rate_limit.%s.algorithm %q must be sliding_window or token_bucket", policy.name, policy.Algorithm))
+		}
+		switch policy.Key {
+		case "ip", "user", "token":
+		default:
+			problems = append(problems, fmt.Sprintf("rate_limit.%s.key %q must be ip, user or token", policy.name, policy.Key))
+		}
+	}
+	return problems
+}
+
+// validate - The database settings alone; tools that only migrate need nothing else
+func (d *DatabaseConfig) validate() []string {
+	var problems []string
+	for _, field := range []struct{ value, name string }{
+		{d.Host, "DB_HOST"}, {d.Port, "DB_PORT"}, {d.User, "DB_USER"}, {d.Password, "DB_PASSWORD"}, {d.DBName, "DB_NAME"},
+	} {
+		if field.value == "" {
+			problems = append(problems, field.name+" is required")
+		}
+	}
+	switch d.SSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		problems = append(problems, fmt.Sprintf("DB_SSLMODE %q is not a PostgreSQL sslmode", d.SSLMode))
+	}
+	if d.MaxConns < 1 {
+		problems = append(problems, "DB_MAX_CONNS must be at least 1")
+	}
+	if d.MinConns < 0 || d.MinConns > d.MaxConns {
+		problems = append(problems, "DB_MIN_CONNS must be between 0 and DB_MAX_CONNS")
+	}
+	if d.ConnectTimeout <= 0 {
+		problems = append(problems, "DB_CONNECT_TIMEOUT must be positive")
+	}
+	return problems
+}
+
+func envString(key string, target *string) {
+	if value, ok := os.LookupEnv(key); ok && strings.TrimSpace(value) != "" {
+		*target = strings.TrimSpace(value)
+	}
+}
+
+func envList(key string, target *[]string) {
+	value, ok := os.LookupEnv(key)
+	if !ok || strings.TrimSpace(value) == "" {
+		return
+	}
+	list := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	*target = list
+}
+
+func envBool(key string, target *bool, problems *[]string) {
+	var raw string
+	if envString(key, &raw); raw == "" {
+		return
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s %q is not true or false", key, raw))
+		return
+	}
+	*target = value
+}
+
+func envInt32(key string, target *int32, problems *[]string) {
+	var raw string
+	if envString(key, &raw); raw == "" {
+		return
+	}
+	value, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s %q is not a whole number", key, raw))
+		return
+	}
+	*target = int32(value)
+}
+
+func envDuration(key string, target *time.Duration, problems *[]string) {
+	var raw string
+	if envString(key, &raw); raw == "" {
+		return
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s %q is not a duration like 30s or 5m", key, raw))
+		return
+	}
+	*target = value
+}
+
+// envRate - A policy's size as requests/window, e.g. 10/1m; the algorithm and key come from the file
+func envRate(key string, target *RateLimitPolicy, problems *[]string) {
+	var raw string
+	if envString(key, &raw); raw == "" {
+		return
+	}
+	count, window, ok := strings.Cut(raw, "/")
+	requests, err := strconv.Atoi(strings.TrimSpace(count))
+	if !ok || err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s %q is not a rate like 10/1m", key, raw))
+		return
+	}
+	duration, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s %q is not a rate like 10/1m", key, raw))
+		return
+	}
+	target.Requests = requests
+	target.Window = duration
+}
+
+// applyOIDCEnv - OIDC_PROVIDERS=google,mock replaces the list of providers, keeping the file's
+// settings for names it already has; OIDC_<NAME>_ISSUER_URL, _CLIENT_ID, _CLIENT_SECRET,
+// _REDIRECT_URL, _SCOPES and _DISPLAY_NAME then override each provider's fields
+func (c *Config) applyOIDCEnv() {
+	var names []string
+	if envList("OIDC_PROVIDERS", &names); names != nil {
+		providers := make([]OIDCProviderConfig, 0, len(names))
+		for _, name := range names {
+			provider := OIDCProviderConfig{Name: strings.ToLower(name)}
+			for _, existing := range c.OIDC.Providers {
+				if strings.EqualFold(existing.Name, name) {
+					provider = existing
+				}
+			}
+			providers = append(providers, provider)
+		}
+		c.OIDC.Providers = providers
+	}
+
+	for i := range c.OIDC.Providers {
+		provider := &c.OIDC.Providers[i]
+		provider.Name = strings.ToLower(strings.TrimSpace(provider.Name))
+		prefix := oidcEnvPrefix(provider.Name)
+		envString(prefix+"DISPLAY_NAME", &provider.DisplayName)
+		envString(prefix+"ISSUER_URL", &provider.IssuerURL)
+		envString(prefix+"CLIENT_ID", &provider.ClientID)
+		envString(prefix+"CLIENT_SECRET", &provider.ClientSecret)
+		envString(prefix+"REDIRECT_URL", &provider.RedirectURL)
+		envList(prefix+"SCOPES", &provider.Scopes)
+	}
+}
+
+// oidcEnvPrefix - OIDC_MY_IDP_ for the provider my-idp
+func oidcEnvPrefix(name string) string {
+	return "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+}
+
+func envInt(key string, target *int, problems *[]string) {
+	var raw string
+	if envString(key, &raw); raw == "" {
+		return
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s %q is not a whole number", key, raw))
+		return
+	}
+	*target = value
+}
+
+// envDurationIn - A whole number of units, for settings named like DATA_EXPORT_TTL_HOURS
+func envDurationIn(key string, unit time.Duration, target *time.Duration, problems *[]string) {
+	var raw string
+	if envString(key, &raw); raw == "" {
+		return
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s %q is not a whole number", key, raw))
+		return
+	}
+	*target = time.Duration(value) * unit
+}
+
+func envFloat(key string, target *float64, problems *[]string) {
+	var raw string
+	if envString(key, &raw); raw == "" {
+		return
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s %q is not a number", key, raw))
+		return
+	}
+	*target = value
+}
+
+// envFloatMap - name=number pairs, e.g. post_generation=5,assistant=10
+func envFloatMap(key string, target *map[string]float64, problems *[]string) {
+	var entries []string
+	if envList(key, &entries); entries == nil {
+		return
+	}
+	values := make(map[string]float64, len(entries))
+	for _, entry := range entries {
+		name, raw, ok := strings.Cut(entry, "=")
+		value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if !ok || err != nil || strings.TrimSpace(name) == "" {
+			*problems = append(*problems, fmt.Sprintf("%s entry %q is not like name=5", key, entry))
+			continue
+		}
+		values[strings.TrimSpace(name)] = value
+	}
+	*target = values
+}
+
+// envModelPrices - model=input:output pairs in USD per million tokens, e.g. gpt-4o=2.5:10
+func envModelPrices(key string, target *map[string]ModelPrice, problems *[]string) {
+	var entries []string
+	if envList(key, &entries); entries == nil {
+		return
+	}
+	prices := make(map[string]ModelPrice, len(entries))
+	for _, entry := range entries {
+		name, pair, ok := strings.Cut(entry, "=")
+		in, out, ok2 := strings.Cut(pair, ":")
+		input, err1 := strconv.ParseFloat(strings.TrimSpace(in), 64)
+		output, err2 := strconv.ParseFloat(strings.TrimSpace(out), 64)
+		if !ok || !ok2 || err1 != nil || err2 != nil || strings.TrimSpace(name) == "" {
+			*problems = append(*problems, fmt.Sprintf("%s entry %q is not like model=input:output", key, entry))
+			continue
+		}
+		prices[strings.TrimSpace(name)] = ModelPrice{Input: input, Output: output}
+	}
+	*target = prices
+}
@@ -2,23 +2,19 @@ package database
 
 import (
 	"fmt"
-	"os"
 
+	"github.com/britinogn/quillhub/config"
 	"github.com/cloudinary/cloudinary-go/v2"
 )
 
 // NewCloudinary returns a configured Cloudinary instance
-func NewCloudinary() (*cloudinary.Cloudinary, error) {
-	cloudName := os.Getenv("CLOUDINARY_CLOUD_NAME")
-	apiKey := os.Getenv("CLOUDINARY_API_KEY")
-	apiSecret := os.Getenv("CLOUDINARY_API_SECRET")
-
-	if cloudName == "" || apiKey == "" || apiSecret == "" {
-		return nil, fmt.Errorf("missing Cloudinary credentials in environment variables")
+func NewCloudinary(cfg config.CloudinaryConfig) (*cloudinary.Cloudinary, error) {
+	if cfg.CloudName == "" || cfg.APIKey == "" || cfg.APISecret == "" {
+		return nil, fmt.Errorf("missing Cloudinary credentials")
 	}
 
 	// Build Cloudinary URL
-	cldURL := fmt.Sprintf("cloudinary://%s:%s@%s", apiKey, apiSecret, cloudName)
+	cldURL := fmt.Sprintf("cloudinary://%s:%s@%s", cfg.APIKey, cfg.APISecret, cfg.CloudName)
 
 	cld, err := cloudinary.NewFromURL(cldURL)
 	if err != nil {
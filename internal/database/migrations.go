@@ -2,84 +2,353 @@ package database
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"log"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/britinogn/quillhub/migrations"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-//var db *pgxpool.Pool
+// migrationLockID - Postgres advisory lock held while migrating, so replicas starting
+// together apply each migration once
+const migrationLockID int64 = 0x71756c6c6869 // "qullhi"
 
-// RunMigrations - Create tables if they don't exist
+var ErrNoDownMigration = errors.New("migration has no down file")
+
+// Migration - One version of the schema: NNN_name.sql and, optionally, NNN_name.down.sql
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // sha256 of the up file; a change after it was applied is reported
+}
+
+// MigrationStatus - A migration and whether this database has it
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	Modified  bool // the up file changed after it was applied
+	Missing   bool // applied, but the file is no longer in the binary
+}
+
+// Migrator - Applies and reverts the embedded migrations, recorded in schema_migrations
+type Migrator struct {
+	db         *pgxpool.Pool
+	migrations []Migration
+}
+
+// NewMigrator - A migrator for the migrations in source (migrations.FS in the app)
+func NewMigrator(db *pgxpool.Pool, source fs.FS) (*Migrator, error) {
+	list, err := LoadMigrations(source)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: list}, nil
+}
+
+// RunMigrations - Apply every pending embedded migration
 func RunMigrations(ctx context.Context, db *pgxpool.Pool) error {
-	log.Println("🔄 Running database migrations...")
-
-	migrations := `
-	-- Users table
-	CREATE TABLE IF NOT EXISTS users (
-		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-		name VARCHAR(250) NOT NULL,
-		username VARCHAR(255) UNIQUE NOT NULL,
-		email VARCHAR(255) UNIQUE NOT NULL,
-		password TEXT NOT NULL,
-		role VARCHAR(50) NOT NULL DEFAULT 'user',
-		gender VARCHAR(25),
-		profile_url VARCHAR(255),
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		CONSTRAINT check_role CHECK (role IN ('user', 'admin', 'moderator'))
-	);
-
-	-- Posts table
-	CREATE TABLE IF NOT EXISTS posts (
-		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-		title TEXT NOT NULL,
-		content TEXT NOT NULL,
-		author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-		image_url TEXT[],
-		tags TEXT[],
-		category VARCHAR(100),
-		is_published BOOLEAN DEFAULT true,
-		view_count INTEGER DEFAULT 0,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-
-	-- Comments table
-	CREATE TABLE IF NOT EXISTS comments (
-		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-		text TEXT NOT NULL,
-		post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
-		author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-
-	-- Likes table
-	CREATE TABLE IF NOT EXISTS likes (
-		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-		post_id UUID REFERENCES posts(id) ON DELETE CASCADE,
-		comment_id UUID REFERENCES comments(id) ON DELETE CASCADE,
-		author_id UUID REFERENCES users(id) ON DELETE CASCADE,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		CONSTRAINT one_like_per_user_per_post UNIQUE (author_id, post_id),
-		CONSTRAINT one_like_per_user_per_comment UNIQUE (author_id, comment_id)
-	);
-
-	-- Indexes
-	CREATE INDEX IF NOT EXISTS idx_posts_author ON posts(author_id);
-	CREATE INDEX IF NOT EXISTS idx_posts_created ON posts(created_at DESC);
-	CREATE INDEX IF NOT EXISTS idx_comments_post ON comments(post_id);
-	CREATE INDEX IF NOT EXISTS idx_comments_user ON comments(author_id);
-	CREATE INDEX IF NOT EXISTS idx_likes_post ON likes(post_id);
-	CREATE INDEX IF NOT EXISTS idx_likes_user ON likes(author_id);
-	`
-
-	_, err := db.Exec(ctx, migrations)
+	migrator, err := NewMigrator(db, migrations.FS)
 	if err != nil {
-		return fmt.Errorf("migration failed: %w", err)
+		return err
 	}
+	_, err = migrator.Up(ctx, 0)
+	return err
+}
+
+// LoadMigrations - Read NNN_name.sql and NNN_name.down.sql files, ordered by version
+func LoadMigrations(source fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := map[int]*Migration{}
+	downs := map[int]string{}
+	for _, entry := range entries {
+		file := entry.Name()
+		if entry.IsDir() || path.Ext(file) != ".sql" {
+			continue
+		}
+
+		base, isDown := strings.CutSuffix(strings.TrimSuffix(file, ".sql"), ".down")
+		prefix, name, ok := strings.Cut(base, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration file %q must be named NNN_name.sql", file)
+		}
+
+		data, err := fs.ReadFile(source, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", file, err)
+		}
+
+		if isDown {
+			if _, dup := downs[version]; dup {
+				return nil, fmt.Errorf("more than one down migration for version %03d", version)
+			}
+			downs[version] = string(data)
+			continue
+		}
+
+		if existing, dup := byVersion[version]; dup {
+			return nil, fmt.Errorf("migrations %03d_%s and %s share a version", version, existing.Name, file)
+		}
+		sum := sha256.Sum256(data)
+		byVersion[version] = &Migration{
+			Version:  version,
+			Name:     name,
+			Up:       string(data),
+			Checksum: hex.EncodeToString(sum[:]),
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for version, migration := range byVersion {
+		migration.Down = downs[version]
+		delete(downs, version)
+		list = append(list, *migration)
+	}
+	for version := range downs {
+		return nil, fmt.Errorf("down migration for version %03d has no up file", version)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+type appliedMigration struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Up - Apply pending migrations in version order; steps 0 applies all of them
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if steps > 0 && len(done) == steps {
+				break
+			}
+			if previous, ok := applied[migration.Version]; ok {
+				if previous.checksum != migration.Checksum {
+					logger.Warnf(ctx, "[MIGRATE] ⚠️  %03d_%s changed after it was applied", migration.Version, migration.Name)
+				}
+				continue
+			}
+
+			start := time.Now()
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			logger.Infof(ctx, "[MIGRATE] ✓ %03d_%s (%s)", migration.Version, migration.Name, time.Since(start).Round(time.Millisecond))
+			done = append(done, migration)
+		}
+		return nil
+	})
+
+	if err == nil && len(done) == 0 {
+		logger.Infof(ctx, "[MIGRATE] Database schema is up to date")
+	}
+	return done, err
+}
+
+// Down - Revert the most recently applied migrations, newest first; steps below 1 reverts one
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		steps = 1
+	}
+
+	byVersion := make(map[int]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		versions := make([]int, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+		for _, version := range versions {
+			if len(done) == steps {
+				break
+			}
+			migration, ok := byVersion[version]
+			if !ok {
+				return fmt.Errorf("migration %03d_%s is applied but not in this build", version, applied[version].name)
+			}
+			if strings.TrimSpace(migration.Down) == "" {
+				return fmt.Errorf("%w: %03d_%s", ErrNoDownMigration, migration.Version, migration.Name)
+			}
+
+			start := time.Now()
+			if err := m.revert(ctx, conn, migration); err != nil {
+				return err
+			}
+			logger.Infof(ctx, "[MIGRATE] ↩ %03d_%s (%s)", migration.Version, migration.Name, time.Since(start).Round(time.Millisecond))
+			done = append(done, migration)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Status - Every known migration, plus applied ones missing from this build
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if previous, ok := applied[migration.Version]; ok {
+				appliedAt := previous.appliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Modified = previous.checksum != migration.Checksum
+				delete(applied, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+
+		for version, previous := range applied {
+			appliedAt := previous.appliedAt
+			statuses = append(statuses, MigrationStatus{
+				Version:   version,
+				Name:      previous.name,
+				Applied:   true,
+				AppliedAt: &appliedAt,
+				Missing:   true,
+			})
+		}
+		return nil
+	})
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, err
+}
+
+// withLock - Run fn on one connection holding the migration lock, with schema_migrations in place
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, migrationLockID).Scan(&locked); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	if !locked {
+		logger.Infof(ctx, "[MIGRATE] Another instance is migrating, waiting for it to finish...")
+		if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+			return fmt.Errorf("failed to take migration lock: %w", err)
+		}
+	}
+	defer func() {
+		// The context may be cancelled by now; the lock must still be released
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			logger.Warnf(ctx, "[MIGRATE] ⚠️  Failed to release migration lock: %v", err)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			checksum CHAR(64) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *pgxpool.Conn) (map[int]appliedMigration, error) {
+	rows, err := conn.Query(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int]appliedMigration{}
+	for rows.Next() {
+		var (
+			version int
+			row     appliedMigration
+		)
+		if err := rows.Scan(&version, &row.name, &row.checksum, &row.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[version] = row
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating schema_migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+// apply - Run one up file and record it, in a single transaction
+func (m *Migrator) apply(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Up); err != nil {
+			return fmt.Errorf("migration %03d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+			migration.Version, migration.Name, migration.Checksum,
+		); err != nil {
+			return fmt.Errorf("failed to record migration %03d: %w", migration.Version, err)
+		}
+		return nil
+	})
+}
 
-	log.Println("✅ Database migrations completed successfully")
-	return nil
-}
\ No newline at end of file
+// revert - Run one down file and forget the version, in a single transaction
+func (m *Migrator) revert(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Down); err != nil {
+			return fmt.Errorf("reverting %03d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version); err != nil {
+			return fmt.Errorf("failed to forget migration %03d: %w", migration.Version, err)
+		}
+		return nil
+	})
+}
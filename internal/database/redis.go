@@ -0,0 +1,34 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// ConnectRedis - Open a client for REDIS_URL, or REDIS_HOST and REDIS_PORT when it is unset
+func ConnectRedis(ctx context.Context, cfg config.RedisConfig) (*redis.Client, error) {
+	options := &redis.Options{Addr: net.JoinHostPort(cfg.Host, cfg.Port)}
+	if cfg.URL != "" {
+		parsed, err := redis.ParseURL(cfg.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse REDIS_URL: %w", err)
+		}
+		options = parsed
+	}
+
+	client := redis.NewClient(options)
+
+	// Test connection
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	logger.Infof(ctx, "✓ Redis connected successfully")
+	return client, nil
+}
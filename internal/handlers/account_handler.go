@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type AccountHandler struct {
+	accountService *services.AccountService
+}
+
+func NewAccountHandler(accountService *services.AccountService) *AccountHandler {
+	return &AccountHandler{accountService: accountService}
+}
+
+// RequestExport - POST /api/me/export
+func (h *AccountHandler) RequestExport(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	export, err := h.accountService.RequestExport(c.Request.Context(), userId.(string))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Accepted(c, "Data export started", export)
+}
+
+// ListExports - GET /api/me/exports
+func (h *AccountHandler) ListExports(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	exports, err := h.accountService.ListExports(c.Request.Context(), userId.(string))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, exports)
+}
+
+// GetExport - GET /api/me/exports/:id
+func (h *AccountHandler) GetExport(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	export, err := h.accountService.GetExport(c.Request.Context(), userId.(string), c.Param("id"))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, export)
+}
+
+// DownloadExport - GET /api/me/exports/:id/download
+func (h *AccountHandler) DownloadExport(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	filePath, err := h.accountService.ExportFile(c.Request.Context(), userId.(string), c.Param("id"))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	c.FileAttachment(filePath, "quillhub-export-"+c.Param("id")+".zip")
+}
+
+// DeleteAccount - DELETE /api/me
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	// Body is optional; mode defaults to anonymize
+	var req model.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BindError(c, err)
+		return
+	}
+
+	deletion, err := h.accountService.RequestDeletion(c.Request.Context(), userId.(string), req.Mode)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	if deletion.Status == "completed" {
+		response.Success(c, http.StatusOK, "Account deleted", deletion)
+		return
+	}
+
+	response.Accepted(c, "Account deletion scheduled; cancel it before the scheduled time to keep your account", deletion)
+}
+
+// GetDeletion - GET /api/me/deletion
+func (h *AccountHandler) GetDeletion(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	deletion, err := h.accountService.GetDeletion(c.Request.Context(), userId.(string))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, deletion)
+}
+
+// CancelDeletion - DELETE /api/me/deletion
+func (h *AccountHandler) CancelDeletion(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	if err := h.accountService.CancelDeletion(c.Request.Context(), userId.(string)); err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Message(c, "Account deletion cancelled")
+}
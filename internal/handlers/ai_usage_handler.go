@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type AIUsageHandler struct {
+	usageService *services.AIUsageService
+}
+
+func NewAIUsageHandler(usageService *services.AIUsageService) *AIUsageHandler {
+	return &AIUsageHandler{usageService: usageService}
+}
+
+// GetUsage - GET /api/admin/ai/usage?days=30
+func (h *AIUsageHandler) GetUsage(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil {
+		response.BadRequest(c, "days must be a number")
+		return
+	}
+
+	report, err := h.usageService.Report(c.Request.Context(), days)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, report)
+}
+
+// GetBudget - GET /api/admin/ai/budget
+func (h *AIUsageHandler) GetBudget(c *gin.Context) {
+	status, err := h.usageService.BudgetStatus(c.Request.Context())
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, status)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type AssistantHandler struct {
+	assistantService *services.AssistantService
+}
+
+func NewAssistantHandler(assistantService *services.AssistantService) *AssistantHandler {
+	return &AssistantHandler{assistantService: assistantService}
+}
+
+// SuggestTitles - POST /api/ai/assist/titles
+func (h *AssistantHandler) SuggestTitles(c *gin.Context) {
+	var req model.SuggestTitlesRequest
+	if !bindAssistantRequest(c, &req) {
+		return
+	}
+
+	userID, role := assistantUser(c)
+	result, err := h.assistantService.SuggestTitles(c.Request.Context(), userID, role, &req)
+	if err != nil {
+		respondAssistantError(c, err)
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// Summarize - POST /api/ai/assist/summary
+func (h *AssistantHandler) Summarize(c *gin.Context) {
+	var req model.SummarizeRequest
+	if !bindAssistantRequest(c, &req) {
+		return
+	}
+
+	userID, role := assistantUser(c)
+	result, err := h.assistantService.Summarize(c.Request.Context(), userID, role, &req)
+	if err != nil {
+		respondAssistantError(c, err)
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// SuggestTags - POST /api/ai/assist/tags
+func (h *AssistantHandler) SuggestTags(c *gin.Context) {
+	var req model.SuggestTagsRequest
+	if !bindAssistantRequest(c, &req) {
+		return
+	}
+
+	userID, role := assistantUser(c)
+	result, err := h.assistantService.SuggestTags(c.Request.Context(), userID, role, &req)
+	if err != nil {
+		respondAssistantError(c, err)
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// Rewrite - POST /api/ai/assist/rewrite
+func (h *AssistantHandler) Rewrite(c *gin.Context) {
+	var req model.RewriteRequest
+	if !bindAssistantRequest(c, &req) {
+		return
+	}
+
+	userID, role := assistantUser(c)
+	result, err := h.assistantService.Rewrite(c.Request.Context(), userID, role, &req)
+	if err != nil {
+		respondAssistantError(c, err)
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// GetQuota - GET /api/ai/assist/quota
+func (h *AssistantHandler) GetQuota(c *gin.Context) {
+	userID, role := assistantUser(c)
+	quota, err := h.assistantService.GetQuota(c.Request.Context(), userID, role)
+	if err != nil {
+		respondAssistantError(c, err)
+		return
+	}
+
+	response.OK(c, quota)
+}
+
+func bindAssistantRequest(c *gin.Context, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		response.BindError(c, err)
+		return false
+	}
+	return true
+}
+
+func assistantUser(c *gin.Context) (string, string) {
+	userID := c.GetString("userId")
+	role := c.GetString("userRole")
+	return userID, role
+}
+
+// respondAssistantError - Provider trouble gets a message the writer can act on; a failed
+// call is not counted against the quota
+func respondAssistantError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNoLLMProvider):
+		response.FailMessage(c, err, "AI assistant is not configured")
+	case errors.Is(err, services.ErrAIBudgetExceeded):
+		response.FailMessage(c, err, "AI assistant is paused until next month, the AI budget has been used up")
+	case errors.Is(err, services.ErrAssistantUnavailable), errors.Is(err, services.ErrAssistantBadResponse):
+		response.FailMessage(c, err, "AI assistant failed, please try again (this request was not counted)")
+	default:
+		response.Fail(c, err)
+	}
+}
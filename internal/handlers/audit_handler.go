@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/britinogn/quillhub/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditService *services.AuditService
+}
+
+func NewAuditHandler(auditService *services.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// GetAuditLogs - GET /api/admin/audit-logs?actor_id=&action=&from=&to=&page=&limit=
+func (h *AuditHandler) GetAuditLogs(c *gin.Context) {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	logs, err := h.auditService.GetLogs(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, logs)
+}
+
+// ExportAuditLogs - GET /api/admin/audit-logs/export (CSV, same filters)
+func (h *AuditHandler) ExportAuditLogs(c *gin.Context) {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("audit-logs-%s.csv", time.Now().UTC().Format("20060102-150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := h.auditService.ExportCSV(c.Request.Context(), filter, c.Writer); err != nil {
+		if c.Writer.Written() {
+			// Rows already went out; all that is left is to record the cause
+			logger.Errorf(c.Request.Context(), "[AUDIT-HANDLER] Error exporting audit logs: %v", err)
+			return
+		}
+		c.Header("Content-Type", "")
+		c.Header("Content-Disposition", "")
+		response.Fail(c, err)
+		return
+	}
+}
+
+// parseAuditFilter - Read filters from the query string; times are RFC 3339 or YYYY-MM-DD
+func parseAuditFilter(c *gin.Context) (model.AuditLogFilter, error) {
+	filter := model.AuditLogFilter{
+		ActorID: c.Query("actor_id"),
+		Action:  c.Query("action"),
+	}
+
+	if from := c.Query("from"); from != "" {
+		t, err := parseTimeParam(from)
+		if err != nil {
+			return filter, utils.Invalid("from", fmt.Sprintf("invalid 'from' time: %s", from))
+		}
+		filter.From = &t
+	}
+
+	if to := c.Query("to"); to != "" {
+		t, err := parseTimeParam(to)
+		if err != nil {
+			return filter, utils.Invalid("to", fmt.Sprintf("invalid 'to' time: %s", to))
+		}
+		filter.To = &t
+	}
+
+	return filter, nil
+}
+
+func parseTimeParam(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
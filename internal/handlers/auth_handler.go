@@ -6,6 +6,8 @@ import (
 
 	"github.com/britinogn/quillhub/internal/model"
 	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/britinogn/quillhub/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,7 +22,7 @@ func NewAuthHandler(authService *services.AuthService) *AuthHandler{
 func (h *AuthHandler) Register(c *gin.Context) {
     var req model.CreateUserRequest  // ← Use CreateUserRequest, not model.User
     if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(400, gin.H{"error": "invalid request"})
+        response.BindError(c, err)
         return
     }
 
@@ -31,43 +33,23 @@ func (h *AuthHandler) Register(c *gin.Context) {
         Username: req.Username,
         Email:    req.Email,
         Password: req.Password,
-        // Role:     "user",
-    }
-
-    // Set role: use provided role or default to "user"
-    if req.Role != nil && *req.Role != "" {
-        user.Role = *req.Role
-    } else {
-        user.Role = "user"
     }
 
+    // Role is decided by the service ("user", or the role granted by an invite)
     ctx := c.Request.Context()
-    err := h.authService.Register(ctx, user)
+    err := h.authService.Register(ctx, user, req.InviteCode)
     if err != nil {
-        if errors.Is(err, services.ErrEmailAlreadyRegistered) {
-            c.JSON(409, gin.H{"error": "email already registered"})
-            return
-        }
-        if errors.Is(err, services.ErrUsernameTaken) {
-            c.JSON(409, gin.H{"error": "username already taken"})
-            return
-        }
-        c.JSON(500, gin.H{
-            "error": "failed to create user",
-        })
+        response.Fail(c, err)
         return
     }
 
-    c.JSON(http.StatusCreated, gin.H{
-        "message": "user registered successfully",
-        "data": model.UserResponse{
-            ID:        user.ID.String(),
-            Name:      user.Name,
-            Username:  user.Username,
-            Email:     user.Email,
-            Role:      user.Role,
-            CreatedAt: user.CreatedAt,
-        },
+    response.Created(c, "user registered successfully", model.UserResponse{
+        ID:        user.ID.String(),
+        Name:      user.Name,
+        Username:  user.Username,
+        Email:     user.Email,
+        Role:      user.Role,
+        CreatedAt: user.CreatedAt,
     })
 }
 
@@ -75,33 +57,27 @@ func (h *AuthHandler) Register(c *gin.Context) {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req model.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(400, gin.H{"error": "invalid req"})
+		response.BindError(c, err)
 		return
 	}
 
-	user, token ,err := h.authService.Login(c.Request.Context(), req.Identifier, req.Password)
+	meta := model.SessionMeta{UserAgent: c.Request.UserAgent(), IPAddress: c.ClientIP()}
+	user, token ,err := h.authService.Login(c.Request.Context(), req.Identifier, req.Password, meta)
 	if err != nil {
-		if errors.Is(err, services.ErrInvalidCredentials) {
-			c.JSON(401, gin.H{"error": "invalid credentials"})
-			return
-		}
-		c.JSON(500, gin.H{"error": "something went wrong"})
+		response.Fail(c, err)
 		return
 	}
 
 	// Just return user (without password)
-	c.JSON(http.StatusOK, gin.H{
-        "message": "login successful",
-        "data": model.LoginResponse{
-            Token: token,
-            User: model.UserResponse{
-				ID : user.ID.String(),
-                Name:user.Name,
-                Username: user.Username,
-                Email: user.Email,
-                Role: user.Role,
-                CreatedAt: user.CreatedAt,
-            },
+	response.Success(c, http.StatusOK, "login successful", model.LoginResponse{
+        Token: token,
+        User: model.UserResponse{
+            ID : user.ID.String(),
+            Name:user.Name,
+            Username: user.Username,
+            Email: user.Email,
+            Role: user.Role,
+            CreatedAt: user.CreatedAt,
         },
     })
 }
@@ -110,13 +86,13 @@ func (h *AuthHandler) RegisterAdmin(c *gin.Context) {
     // Get the requesting user's role from JWT token
     requestingUserRole, exists := c.Get("userRole")
     if !exists {
-        c.JSON(401, gin.H{"error": "unauthorized"})
+        response.Unauthorized(c, "unauthorized")
         return
     }
 
     var req model.CreateUserRequest
     if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(400, gin.H{"error": "invalid input",})
+        response.BindError(c, err)
         return
     }
 
@@ -130,19 +106,56 @@ func (h *AuthHandler) RegisterAdmin(c *gin.Context) {
     ctx := c.Request.Context()
     err := h.authService.RegisterAdmin(ctx, user, requestingUserRole.(string))
     if err != nil {
-        c.JSON(500, gin.H{"error": err.Error()})
+        response.Fail(c, err)
         return
     }
 
-    c.JSON(http.StatusCreated, gin.H{
-        "message": "admin user created successfully",
-        "data": model.UserResponse{
-            ID:        user.ID.String(),
-            Name:      user.Name,
-            Username:  user.Username,
-            Email:     user.Email,
-            Role:      user.Role,
-            CreatedAt: user.CreatedAt,
-        },
+    response.Created(c, "admin user created successfully", model.UserResponse{
+        ID:        user.ID.String(),
+        Name:      user.Name,
+        Username:  user.Username,
+        Email:     user.Email,
+        Role:      user.Role,
+        CreatedAt: user.CreatedAt,
     })
-}
\ No newline at end of file
+}
+
+// GetRegistrationInfo - GET /api/auth/registration
+func (h *AuthHandler) GetRegistrationInfo(c *gin.Context) {
+    response.OK(c, h.authService.RegistrationInfo())
+}
+
+// ChangeUserRole - PATCH /api/admin/users/:id/role
+func (h *AuthHandler) ChangeUserRole(c *gin.Context) {
+    userId, exists := c.Get("userId")
+    if !exists {
+        response.Unauthorized(c, "User not authenticated")
+        return
+    }
+
+    var req model.UpdateRoleRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        response.BindError(c, err)
+        return
+    }
+
+    user, err := h.authService.ChangeUserRole(c.Request.Context(), c.Param("id"), req.Role, userId.(string))
+    if err != nil {
+        if errors.Is(err, services.ErrInvalidRole) {
+            response.Fail(c, utils.Invalid("role", "role must be one of: user, moderator, admin"))
+            return
+        }
+        response.Fail(c, err)
+        return
+    }
+
+    response.Success(c, http.StatusOK, "role updated successfully", model.UserResponse{
+        ID:         user.ID.String(),
+        Name:       user.Name,
+        Username:   user.Username,
+        Email:      user.Email,
+        Role:       user.Role,
+        ProfileURL: user.ProfileURL,
+        CreatedAt:  user.CreatedAt,
+    })
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type AutoPosterHandler struct {
+	autoPoster *services.AutoPosterService
+}
+
+func NewAutoPosterHandler(autoPoster *services.AutoPosterService) *AutoPosterHandler {
+	return &AutoPosterHandler{autoPoster: autoPoster}
+}
+
+// GetStatus - GET /api/admin/auto-poster
+func (h *AutoPosterHandler) GetStatus(c *gin.Context) {
+	response.OK(c, h.autoPoster.Status())
+}
+
+// Start - POST /api/admin/auto-poster/start
+func (h *AutoPosterHandler) Start(c *gin.Context) {
+	if err := h.autoPoster.StartScheduler(c.Request.Context()); err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Auto-poster started", h.autoPoster.Status())
+}
+
+// Stop - POST /api/admin/auto-poster/stop
+func (h *AutoPosterHandler) Stop(c *gin.Context) {
+	if err := h.autoPoster.StopScheduler(c.Request.Context()); err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Auto-poster stopped", h.autoPoster.Status())
+}
+
+// PostNow - POST /api/admin/auto-poster/run?persona_id= (default persona when empty)
+func (h *AutoPosterHandler) PostNow(c *gin.Context) {
+	if err := h.autoPoster.PostNow(c.Request.Context(), c.Query("persona_id")); err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Accepted(c, "Post generation started", nil)
+}
+
+// UpdateSchedule - PUT /api/admin/auto-poster/schedule
+func (h *AutoPosterHandler) UpdateSchedule(c *gin.Context) {
+	var req model.UpdateAutoPosterScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	status, err := h.autoPoster.UpdateSchedule(c.Request.Context(), &req)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Schedule updated", status)
+}
@@ -1,11 +1,10 @@
 package handlers
 
 import (
-	"errors"
-	"net/http"
 
 	"github.com/britinogn/quillhub/internal/model"
 	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
 	"github.com/gin-gonic/gin"
 )
 
@@ -22,21 +21,21 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 	// Get post ID from URL parameter
 	postID := c.Param("postId")
 	if postID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Post ID is required"})
+		response.BadRequest(c, "Post ID is required")
 		return
 	}
 
 	// Parse request body
 	var req model.CreateCommentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		response.BindError(c, err)
 		return
 	}
 
 	// Get authenticated user ID
 	userId, exists := c.Get("userId")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		response.Unauthorized(c, "User not authenticated")
 		return
 	}
 
@@ -45,19 +44,18 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 	comment, err := h.commentService.CreateComment(ctx, &req, postID, userId.(string))
 	if err != nil {
 		// Handle specific errors
-		if errors.Is(err, services.ErrPostNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
-			return
-		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		response.Fail(c, err)
+		return
+	}
+
+	// Held comments are saved but stay hidden until a moderator approves them
+	if comment.ModerationStatus == services.ModerationStatusPending {
+		response.Accepted(c, "Comment submitted and is awaiting moderation", comment)
 		return
 	}
 
 	// Return success
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "Comment created successfully",
-		"comment": comment,
-	})
+	response.Created(c, "Comment created successfully", comment)
 }
 
 // GetCommentsByPostID - HTTP handler for GET /posts/:postId/comments
@@ -65,7 +63,7 @@ func (h *CommentHandler) GetCommentsByPostID(c *gin.Context) {
 	// Get post ID from URL parameter
 	postID := c.Param("id")
 	if postID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Post ID is required"})
+		response.BadRequest(c, "Post ID is required")
 		return
 	}
 
@@ -73,19 +71,12 @@ func (h *CommentHandler) GetCommentsByPostID(c *gin.Context) {
 	comments, err := h.commentService.GetCommentsByPostID(c.Request.Context(), postID)
 	if err != nil {
 		// Handle specific errors
-		if errors.Is(err, services.ErrPostNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		response.Fail(c, err)
 		return
 	}
 
 	// Return comments
-	c.JSON(http.StatusOK, gin.H{
-		"comments": comments,
-		"count":    len(comments),
-	})
+	response.OK(c, comments)
 }
 
 // DeleteComment - HTTP handler for DELETE /comments/:commentId
@@ -93,14 +84,14 @@ func (h *CommentHandler) DeleteComment(c *gin.Context) {
 	// Get comment ID from URL parameter
 	commentID := c.Param("commentId")
 	if commentID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Comment ID is required"})
+		response.BadRequest(c, "Comment ID is required")
 		return
 	}
 
 	// Get authenticated user ID
 	userId, exists := c.Get("userId")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		response.Unauthorized(c, "User not authenticated")
 		return
 	}
 
@@ -108,20 +99,10 @@ func (h *CommentHandler) DeleteComment(c *gin.Context) {
 	err := h.commentService.DeleteComment(c.Request.Context(), commentID, userId.(string))
 	if err != nil {
 		// Handle specific errors
-		if errors.Is(err, services.ErrCommentNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
-			return
-		}
-		if errors.Is(err, services.ErrUnauthorizedComment) {
-			c.JSON(http.StatusForbidden, gin.H{"error": "You can only delete your own comments"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		response.Fail(c, err)
 		return
 	}
 
 	// Return success
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Comment deleted successfully",
-	})
+	response.Message(c, "Comment deleted successfully")
 }
\ No newline at end of file
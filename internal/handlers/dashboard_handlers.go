@@ -2,10 +2,11 @@
 package handlers
 
 import (
-	"log"
 	"net/http"
 
 	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/britinogn/quillhub/pkg/response"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,20 +20,16 @@ func NewDashboardHandler(dashboardService *services.DashboardService) *Dashboard
 
 // GetAdminDashboard - GET /api/admin/dashboard
 func (h *DashboardHandler) GetAdminDashboard(c *gin.Context) {
-	log.Printf("[DASHBOARD-HANDLER] Admin dashboard requested")
+	logger.Debugf(c.Request.Context(), "[DASHBOARD-HANDLER] Admin dashboard requested")
 
 	ctx := c.Request.Context()
 	dashboard, err := h.dashboardService.GetAdminDashboard(ctx)
 	if err != nil {
-		log.Printf("[DASHBOARD-HANDLER] Error fetching admin dashboard: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch dashboard data"})
+		response.Fail(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Admin dashboard fetched successfully",
-		"data":    dashboard,
-	})
+	response.Success(c, http.StatusOK, "Admin dashboard fetched successfully", dashboard)
 }
 
 // GetUserDashboard - GET /api/dashboard
@@ -40,22 +37,18 @@ func (h *DashboardHandler) GetUserDashboard(c *gin.Context) {
 	// Get authenticated user ID from middleware
 	userId, exists := c.Get("userId")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		response.Unauthorized(c, "User not authenticated")
 		return
 	}
 
-	log.Printf("[DASHBOARD-HANDLER] User dashboard requested for: %s", userId.(string))
+	logger.Debugf(c.Request.Context(), "[DASHBOARD-HANDLER] User dashboard requested for: %s", userId.(string))
 
 	ctx := c.Request.Context()
 	dashboard, err := h.dashboardService.GetUserDashboard(ctx, userId.(string))
 	if err != nil {
-		log.Printf("[DASHBOARD-HANDLER] Error fetching user dashboard: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch dashboard data"})
+		response.Fail(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "User dashboard fetched successfully",
-		"data":    dashboard,
-	})
+	response.Success(c, http.StatusOK, "User dashboard fetched successfully", dashboard)
 }
\ No newline at end of file
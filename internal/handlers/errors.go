@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+)
+
+// serviceErrors - The status and machine-readable code each service error is answered
+// with. Handlers pass service errors to response.Fail and only special-case the few that
+// need a message of their own; anything not listed here is a 500.
+var serviceErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	// Auth and registration
+	{services.ErrInvalidCredentials, http.StatusUnauthorized, "invalid_credentials"},
+	{services.ErrInvalidToken, http.StatusUnauthorized, "invalid_token"},
+	{services.ErrSessionRevoked, http.StatusUnauthorized, "session_revoked"},
+	{services.ErrEmailAlreadyRegistered, http.StatusConflict, "email_taken"},
+	{services.ErrUsernameTaken, http.StatusConflict, "username_taken"},
+	{services.ErrInvalidInput, http.StatusBadRequest, "invalid_input"},
+	{services.ErrWeakPassword, http.StatusBadRequest, "weak_password"},
+	{services.ErrAdminRequired, http.StatusForbidden, "admin_required"},
+	{services.ErrCannotChangeOwnRole, http.StatusForbidden, "cannot_change_own_role"},
+	{services.ErrInvalidRole, http.StatusBadRequest, "invalid_role"},
+	{services.ErrUserNotFound, http.StatusNotFound, "user_not_found"},
+	{services.ErrInviteRequired, http.StatusForbidden, "invite_required"},
+	{services.ErrEmailDomainNotAllowed, http.StatusForbidden, "email_domain_not_allowed"},
+	{services.ErrInvalidInvite, http.StatusBadRequest, "invalid_invite"},
+	{services.ErrInviteNotFound, http.StatusNotFound, "invite_not_found"},
+	{services.ErrSessionNotFound, http.StatusNotFound, "session_not_found"},
+
+	// Social login
+	{services.ErrUnknownProvider, http.StatusNotFound, "unknown_provider"},
+	{services.ErrInvalidOAuthState, http.StatusBadRequest, "invalid_oauth_state"},
+	{services.ErrIdentityAlreadyLinked, http.StatusConflict, "identity_already_linked"},
+	{services.ErrIdentityNotFound, http.StatusNotFound, "identity_not_found"},
+	{services.ErrLastIdentity, http.StatusConflict, "last_identity"},
+	{services.ErrEmailNotVerified, http.StatusConflict, "email_not_verified"},
+
+	// Posts, comments and moderation
+	{services.ErrPostNotFound, http.StatusNotFound, "post_not_found"},
+	{services.ErrUnauthorizedPost, http.StatusForbidden, "post_forbidden"},
+	{services.ErrCommentNotFound, http.StatusNotFound, "comment_not_found"},
+	{services.ErrUnauthorizedComment, http.StatusForbidden, "comment_forbidden"},
+	{services.ErrImageNotFound, http.StatusNotFound, "image_not_found"},
+	{services.ErrInvalidImageText, http.StatusBadRequest, "invalid_image_text"},
+	{services.ErrPostNotPending, http.StatusConflict, "post_not_pending"},
+	{services.ErrRejectReasonRequired, http.StatusBadRequest, "reject_reason_required"},
+	{services.ErrRegenerationInProgress, http.StatusConflict, "regeneration_in_progress"},
+	{services.ErrInvalidReviewStatus, http.StatusBadRequest, "invalid_review_status"},
+	{services.ErrInvalidReviewEdit, http.StatusBadRequest, "invalid_review_edit"},
+	{services.ErrCommentNotPending, http.StatusConflict, "comment_not_pending"},
+	{services.ErrInvalidModerationState, http.StatusBadRequest, "invalid_moderation_status"},
+
+	// Translations and search
+	{services.ErrInvalidLanguage, http.StatusBadRequest, "invalid_language"},
+	{services.ErrTranslationNotFound, http.StatusNotFound, "translation_not_found"},
+	{services.ErrTranslationInProgress, http.StatusConflict, "translation_in_progress"},
+	{services.ErrTranslationExists, http.StatusConflict, "translation_exists"},
+	{services.ErrSameLanguage, http.StatusBadRequest, "same_language"},
+	{services.ErrInvalidTranslation, http.StatusBadRequest, "invalid_translation"},
+	{services.ErrTranslationTooLong, http.StatusBadRequest, "translation_too_long"},
+	{services.ErrInvalidSearch, http.StatusBadRequest, "invalid_search"},
+	{services.ErrSemanticSearchUnavailable, http.StatusServiceUnavailable, "semantic_search_unavailable"},
+
+	// Account data
+	{services.ErrExportInProgress, http.StatusConflict, "export_in_progress"},
+	{services.ErrExportNotFound, http.StatusNotFound, "export_not_found"},
+	{services.ErrExportNotReady, http.StatusConflict, "export_not_ready"},
+	{services.ErrInvalidDeletionMode, http.StatusBadRequest, "invalid_deletion_mode"},
+	{services.ErrNoPendingDeletion, http.StatusNotFound, "no_pending_deletion"},
+	{services.ErrAccountNotDeletable, http.StatusForbidden, "account_not_deletable"},
+
+	// AI: personas, topics, auto-poster, assistant, usage
+	{services.ErrPersonaNotFound, http.StatusNotFound, "persona_not_found"},
+	{services.ErrPersonaExists, http.StatusConflict, "persona_exists"},
+	{services.ErrPersonaUsernameTaken, http.StatusConflict, "username_taken"},
+	{services.ErrInvalidPersona, http.StatusBadRequest, "invalid_persona"},
+	{services.ErrInvalidPromptTemplate, http.StatusBadRequest, "invalid_prompt_template"},
+	{services.ErrDefaultPersonaDelete, http.StatusConflict, "default_persona"},
+	{services.ErrTopicNotFound, http.StatusNotFound, "topic_not_found"},
+	{services.ErrTopicExists, http.StatusConflict, "topic_exists"},
+	{services.ErrCategoryNotFound, http.StatusNotFound, "category_not_found"},
+	{services.ErrCategoryExists, http.StatusConflict, "category_exists"},
+	{services.ErrInvalidTopicInput, http.StatusBadRequest, "invalid_topic"},
+	{services.ErrNoTopicAvailable, http.StatusConflict, "no_topic_available"},
+	{services.ErrAutoPosterRunning, http.StatusConflict, "auto_poster_running"},
+	{services.ErrAutoPosterNotRunning, http.StatusConflict, "auto_poster_not_running"},
+	{services.ErrAutoPosterBusy, http.StatusConflict, "auto_poster_busy"},
+	{services.ErrInvalidPosterSchedule, http.StatusBadRequest, "invalid_schedule"},
+	{services.ErrGenerationJobNotFound, http.StatusNotFound, "generation_job_not_found"},
+	{services.ErrInvalidJobFilter, http.StatusBadRequest, "invalid_job_filter"},
+	{services.ErrAssistantQuotaExceeded, http.StatusTooManyRequests, "assistant_quota_exceeded"},
+	{services.ErrAssistantInputTooLong, http.StatusBadRequest, "assistant_input_too_long"},
+	{services.ErrAssistantInvalidInput, http.StatusBadRequest, "invalid_assistant_request"},
+	{services.ErrAssistantUnavailable, http.StatusBadGateway, "ai_assistant_failed"},
+	{services.ErrAssistantBadResponse, http.StatusBadGateway, "ai_assistant_failed"},
+	{services.ErrAIBudgetExceeded, http.StatusServiceUnavailable, "ai_budget_exceeded"},
+	{services.ErrNoLLMProvider, http.StatusServiceUnavailable, "ai_not_configured"},
+	{services.ErrInvalidUsageRange, http.StatusBadRequest, "invalid_usage_range"},
+	{services.ErrInvalidAuditFilter, http.StatusBadRequest, "invalid_audit_filter"},
+}
+
+func init() {
+	for _, e := range serviceErrors {
+		response.Register(e.err, e.status, e.code)
+	}
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type GenerationJobHandler struct {
+	jobService *services.GenerationJobService
+}
+
+func NewGenerationJobHandler(jobService *services.GenerationJobService) *GenerationJobHandler {
+	return &GenerationJobHandler{jobService: jobService}
+}
+
+// ListJobs - GET /api/admin/ai/jobs?status=&page=&limit=
+func (h *GenerationJobHandler) ListJobs(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	jobs, err := h.jobService.ListJobs(c.Request.Context(), c.Query("status"), page, limit)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, jobs)
+}
+
+// GetJob - GET /api/admin/ai/jobs/:id (includes prompt, raw output and every attempt)
+func (h *GenerationJobHandler) GetJob(c *gin.Context) {
+	job, err := h.jobService.GetJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, job)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type InviteHandler struct {
+	inviteService *services.InviteService
+}
+
+func NewInviteHandler(inviteService *services.InviteService) *InviteHandler {
+	return &InviteHandler{inviteService: inviteService}
+}
+
+// CreateInvite - POST /api/admin/invites
+func (h *InviteHandler) CreateInvite(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req model.CreateInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	invite, err := h.inviteService.CreateInvite(c.Request.Context(), &req, userId.(string))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, "Invite created successfully", invite)
+}
+
+// ListInvites - GET /api/admin/invites
+func (h *InviteHandler) ListInvites(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	invites, err := h.inviteService.ListInvites(c.Request.Context(), page, limit)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, invites)
+}
+
+// RevokeInvite - DELETE /api/admin/invites/:id
+func (h *InviteHandler) RevokeInvite(c *gin.Context) {
+	err := h.inviteService.RevokeInvite(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Message(c, "Invite revoked successfully")
+}
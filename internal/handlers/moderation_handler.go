@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type ModerationHandler struct {
+	moderationService *services.ModerationService
+}
+
+func NewModerationHandler(moderationService *services.ModerationService) *ModerationHandler {
+	return &ModerationHandler{moderationService: moderationService}
+}
+
+// ListQueue - GET /api/moderation/comments?status=pending&page=&limit=
+func (h *ModerationHandler) ListQueue(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	queue, err := h.moderationService.ListQueue(c.Request.Context(), c.Query("status"), page, limit)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, queue)
+}
+
+// ApproveComment - POST /api/moderation/comments/:id/approve
+func (h *ModerationHandler) ApproveComment(c *gin.Context) {
+	comment, err := h.moderationService.ApproveComment(c.Request.Context(), c.Param("id"), c.GetString("userId"))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Comment approved and published", comment)
+}
+
+// RejectComment - POST /api/moderation/comments/:id/reject (reason is optional)
+func (h *ModerationHandler) RejectComment(c *gin.Context) {
+	var req model.ModerateCommentRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BindError(c, err)
+			return
+		}
+	}
+
+	comment, err := h.moderationService.RejectComment(c.Request.Context(), c.Param("id"), c.GetString("userId"), req.Reason)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Comment rejected", comment)
+}
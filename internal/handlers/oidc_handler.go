@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type OIDCHandler struct {
+	oidcService *services.OIDCService
+}
+
+func NewOIDCHandler(oidcService *services.OIDCService) *OIDCHandler {
+	return &OIDCHandler{oidcService: oidcService}
+}
+
+// ListProviders - GET /api/auth/oidc/providers
+func (h *OIDCHandler) ListProviders(c *gin.Context) {
+	providers := h.oidcService.Providers()
+	response.OK(c, providers)
+}
+
+// Login - GET /api/auth/oidc/:provider/login (redirects to the provider)
+func (h *OIDCHandler) Login(c *gin.Context) {
+	authURL, err := h.oidcService.BeginLogin(c.Request.Context(), c.Param("provider"), "")
+	if err != nil {
+		respondProviderUnavailable(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback - GET /api/auth/oidc/:provider/callback
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	if providerErr := c.Query("error"); providerErr != "" {
+		message := "Identity provider returned an error: " + providerErr
+		if description := c.Query("error_description"); description != "" {
+			message += " (" + description + ")"
+		}
+		response.Fail(c, response.NewError(http.StatusBadRequest, "identity_provider_error", message))
+		return
+	}
+
+	user, token, err := h.oidcService.CompleteLogin(
+		c.Request.Context(),
+		c.Param("provider"),
+		c.Query("state"),
+		c.Query("code"),
+		model.SessionMeta{UserAgent: c.Request.UserAgent(), IPAddress: c.ClientIP()},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnknownProvider):
+			response.FailMessage(c, err, "Unknown identity provider")
+		case errors.Is(err, services.ErrInvalidOAuthState):
+			response.FailMessage(c, err, "Invalid or expired login request")
+		case errors.Is(err, services.ErrIdentityAlreadyLinked):
+			response.FailMessage(c, err, "This account is already linked to another user")
+		case errors.Is(err, services.ErrInviteRequired), errors.Is(err, services.ErrEmailDomainNotAllowed):
+			response.Fail(c, err)
+		case errors.Is(err, services.ErrEmailNotVerified):
+			response.FailMessage(c, err, "The provider has not verified this email address; verify it there, or sign in and link the provider instead")
+		default:
+			// Token exchange and ID token checks fail here; the cause is logged, not shown
+			response.Fail(c, &response.Error{Status: http.StatusUnauthorized, Code: "external_sign_in_failed", Message: "External sign-in failed", Err: err})
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "login successful", model.LoginResponse{
+		Token: token,
+		User: model.UserResponse{
+			ID:         user.ID.String(),
+			Name:       user.Name,
+			Username:   user.Username,
+			Email:      user.Email,
+			Role:       user.Role,
+			ProfileURL: user.ProfileURL,
+			CreatedAt:  user.CreatedAt,
+		},
+	})
+}
+
+// LinkIdentity - POST /api/me/identities/:provider (returns the provider URL to visit)
+func (h *OIDCHandler) LinkIdentity(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	authURL, err := h.oidcService.BeginLogin(c.Request.Context(), c.Param("provider"), userId.(string))
+	if err != nil {
+		respondProviderUnavailable(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Visit the authorization URL to link your account", gin.H{"authorization_url": authURL})
+}
+
+// ListIdentities - GET /api/me/identities
+func (h *OIDCHandler) ListIdentities(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	identities, err := h.oidcService.ListIdentities(c.Request.Context(), userId.(string))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, identities)
+}
+
+// UnlinkIdentity - DELETE /api/me/identities/:id
+func (h *OIDCHandler) UnlinkIdentity(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	err := h.oidcService.UnlinkIdentity(c.Request.Context(), userId.(string), c.Param("id"))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Message(c, "Account unlinked successfully")
+}
+
+// respondProviderUnavailable - The provider's discovery or auth endpoint could not be reached
+func respondProviderUnavailable(c *gin.Context, err error) {
+	response.Fail(c, &response.Error{Status: http.StatusBadGateway, Code: "identity_provider_unavailable", Message: "Identity provider unavailable", Err: err})
+}
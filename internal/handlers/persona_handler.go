@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type PersonaHandler struct {
+	personaService *services.PersonaService
+}
+
+func NewPersonaHandler(personaService *services.PersonaService) *PersonaHandler {
+	return &PersonaHandler{personaService: personaService}
+}
+
+// ListPersonas - GET /api/admin/ai/personas
+func (h *PersonaHandler) ListPersonas(c *gin.Context) {
+	personas, err := h.personaService.ListPersonas(c.Request.Context())
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, personas)
+}
+
+// GetPersona - GET /api/admin/ai/personas/:id
+func (h *PersonaHandler) GetPersona(c *gin.Context) {
+	persona, err := h.personaService.GetPersona(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, persona)
+}
+
+// CreatePersona - POST /api/admin/ai/personas
+func (h *PersonaHandler) CreatePersona(c *gin.Context) {
+	var req model.CreatePersonaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	persona, err := h.personaService.CreatePersona(c.Request.Context(), &req)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, "Persona created successfully", persona)
+}
+
+// UpdatePersona - PATCH /api/admin/ai/personas/:id
+func (h *PersonaHandler) UpdatePersona(c *gin.Context) {
+	var req model.UpdatePersonaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	persona, err := h.personaService.UpdatePersona(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Persona updated successfully", persona)
+}
+
+// DeletePersona - DELETE /api/admin/ai/personas/:id
+func (h *PersonaHandler) DeletePersona(c *gin.Context) {
+	if err := h.personaService.DeletePersona(c.Request.Context(), c.Param("id")); err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Message(c, "Persona deleted successfully")
+}
+
+// PreviewPrompt - POST /api/admin/ai/personas/:id/preview
+func (h *PersonaHandler) PreviewPrompt(c *gin.Context) {
+	var req model.PreviewPersonaPromptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	prompt, err := h.personaService.PreviewPrompt(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, gin.H{"prompt": prompt})
+}
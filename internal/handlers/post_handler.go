@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"errors"
-	"log"
 	"mime/multipart"
 	"net/http"
 	"strconv"
@@ -10,6 +8,8 @@ import (
 
 	"github.com/britinogn/quillhub/internal/model"
 	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/britinogn/quillhub/pkg/response"
 	"github.com/gin-gonic/gin"
 )
 
@@ -32,7 +32,7 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 	if strings.Contains(contentType, "application/json") {
 		// JSON request
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON",})
+			response.BindError(c, err)
 			return
 		}
 	} else if strings.Contains(contentType, "multipart/form-data") {
@@ -41,6 +41,7 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 		content := c.PostForm("content")
 		tagsString := c.PostForm("tags")
 		category := c.PostForm("category")
+		language := c.PostForm("language")
 				
 		var tags []string
 		if tagsString != "" {
@@ -54,16 +55,17 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 			Content: content,
 			Tags:    tags,
 			Category: category,
+			Language: language,
 		}
 	} else {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported content type"})
+		response.BadRequest(c, "Unsupported content type")
 		return
 	}
 
 	// Get authenticated user ID
 	userId, exists := c.Get("userId")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		response.Unauthorized(c, "User not authenticated")
 		return
 	}
 
@@ -78,27 +80,27 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 	ctx := c.Request.Context()
 	post, err := h.postService.CreatePost(ctx, &req, userId.(string), files)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		response.Fail(c, err)
 		return
 	}
 
 
 	// Return response
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "Post created successfully",
-		"data": model.PostResponse{
-			ID:        post.ID.String(),
-			AuthorID:  post.AuthorID.String(),
-			Title:     post.Title,
-			Content:   post.Content,
-			ImageURL:  post.ImageURL,
-			Tags:      post.Tags,
-			Category: 	post.Category,
-			IsPublished: post.IsPublished,
-			ViewCount: post.ViewCount,
-			CreatedAt: post.CreatedAt,
-			UpdatedAt: post.UpdatedAt,
-		},
+	response.Created(c, "Post created successfully", model.PostResponse{
+		ID:        post.ID.String(),
+		AuthorID:  post.AuthorID.String(),
+		Title:     post.Title,
+		Content:   post.Content,
+		ImageURL:  post.ImageURL,
+		Images:    post.Images,
+		Tags:      post.Tags,
+		Category: 	post.Category,
+		IsPublished: post.IsPublished,
+		ViewCount: post.ViewCount,
+		AIGenerated: post.AIGenerated,
+		Language:  post.Language,
+		CreatedAt: post.CreatedAt,
+		UpdatedAt: post.UpdatedAt,
 	})
 }
 
@@ -114,48 +116,50 @@ func (h *PostHandler) GetAllPosts(c *gin.Context) {
 		limit = 10
 	}
 
-	// Call service
-	response, err := h.postService.GetPosts(c.Request.Context(), page, limit)
+	// Call service; ?lang= keeps posts readable in that language
+	posts, err := h.postService.GetPosts(c.Request.Context(), page, limit, c.Query("lang"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		response.Fail(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	response.OK(c, posts)
 }
 
 func(h *PostHandler) GetPostById(c *gin.Context){
 	postID := c.Param("id")
 
 	if postID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Post ID is required",
-		})
+		response.BadRequest(c, "Post ID is required")
 		return
 	}
 
+	// ?lang= wins over the Accept-Language header
+	languagePreference := c.Query("lang")
+	if languagePreference == "" {
+		languagePreference = c.GetHeader("Accept-Language")
+	}
+
 	// Call service to get post
 	ctx := c.Request.Context()
-	post, err := h.postService.GetPostByID(ctx, postID)
+	post, err := h.postService.GetPostByID(ctx, postID, languagePreference)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		response.Fail(c, err)
 		return
 	}
 
 	// Check if post exists
 	if post == nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Post not found",
-		})
+		response.NotFound(c, "Post not found")
 		return
 	}
 
+	// The body depends on the requested language
+	c.Header("Content-Language", post.Language)
+	c.Header("Vary", "Accept-Language")
+
 	// Return post
-	c.JSON(http.StatusOK, gin.H{
-		"post": post,
-	})
+	response.OK(c, post)
 }
 
 // GetPostsByAuthorID - HTTP handler for GET /posts/author/:authorId
@@ -164,37 +168,34 @@ func (h *PostHandler) GetPostsByAuthorID(c *gin.Context) {
 	authorID := c.Param("authorId")
 
 	if authorID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Author ID is required"})
+		response.BadRequest(c, "Author ID is required")
 		return
 	}
 
 	// Call service to get posts
 	ctx := c.Request.Context()
-	posts, err := h.postService.GetPostsByAuthorID(ctx, authorID)
+	posts, err := h.postService.GetPostsByAuthorID(ctx, authorID, c.Query("lang"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		response.Fail(c, err)
 		return
 	}
 
 	// Return posts (empty array if no posts found)
-	c.JSON(http.StatusOK, gin.H{
-		"posts": posts,
-		"count": len(posts),
-	})
+	response.OK(c, posts)
 }
 
 func (h *PostHandler) Update(c *gin.Context) {
 	// Get post ID from URL
 	postID := c.Param("id")
 	if postID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Post ID is required"})
+		response.BadRequest(c, "Post ID is required")
 		return
 	}
 
 	// Get authenticated user ID
 	userId, exists := c.Get("userId")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		response.Unauthorized(c, "User not authenticated")
 		return
 	}
 
@@ -207,7 +208,7 @@ func (h *PostHandler) Update(c *gin.Context) {
 	if strings.Contains(contentType, "application/json") {
 		// JSON request
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON",})
+			response.BindError(c, err)
 			return
 		}
 	} else if strings.Contains(contentType, "multipart/form-data") {
@@ -228,62 +229,56 @@ func (h *PostHandler) Update(c *gin.Context) {
 			}
 			req.Tags = tags
 		}
+		if language := c.PostForm("language"); language != "" {
+			req.Language = &language
+		}
 		if isPublished := c.PostForm("is_published"); isPublished != "" {
 			published := isPublished == "true"
 			req.IsPublished = &published
 		}
 	} else {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported content type"})
+		response.BadRequest(c, "Unsupported content type")
 		return
 	}
 
-	log.Printf("[POST-HANDLER] Updating post %s by user: %s", postID, userId.(string))
+	logger.Infof(c.Request.Context(), "[POST-HANDLER] Updating post %s by user: %s", postID, userId.(string))
 
 	// Get uploaded files (only for form-data)
 	form, _ := c.MultipartForm()
 	var files []*multipart.FileHeader
 	if form != nil && form.File["images"] != nil {
 		files = form.File["images"]
-		log.Printf("[POST-HANDLER] Received %d new image files", len(files))
+		logger.Infof(c.Request.Context(), "[POST-HANDLER] Received %d new image files", len(files))
 	}
 
 	// Call service
 	ctx := c.Request.Context()
 	post, err := h.postService.UpdatePost(ctx, &req, postID, userId.(string), files)
 	if err != nil {
-		log.Printf("[POST-HANDLER] Update error: %v", err)
-		
-		if errors.Is(err, services.ErrPostNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
-			return
-		}
-		if errors.Is(err, services.ErrUnauthorizedPost) {
-			c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to update this post"})
-			return
-		}
+		logger.Errorf(ctx, "[POST-HANDLER] Update error: %v", err)
 		
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		response.Fail(c, err)
 		return
 	}
 
-	log.Printf("[POST-HANDLER] Post updated successfully: %s", postID)
+	logger.Infof(ctx, "[POST-HANDLER] Post updated successfully: %s", postID)
 
 	// Return response
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Post updated successfully",
-		"data": model.PostResponse{
-			ID:        post.ID.String(),
-			AuthorID:  post.AuthorID.String(),
-			Title:     post.Title,
-			Content:   post.Content,
-			ImageURL:  post.ImageURL,
-			Category:  post.Category,
-			Tags:      post.Tags,
-			IsPublished: post.IsPublished,
-			ViewCount: post.ViewCount,
-			CreatedAt: post.CreatedAt,
-			UpdatedAt: post.UpdatedAt,
-		},
+	response.Success(c, http.StatusOK, "Post updated successfully", model.PostResponse{
+		ID:        post.ID.String(),
+		AuthorID:  post.AuthorID.String(),
+		Title:     post.Title,
+		Content:   post.Content,
+		ImageURL:  post.ImageURL,
+		Images:    post.Images,
+		Category:  post.Category,
+		Tags:      post.Tags,
+		IsPublished: post.IsPublished,
+		ViewCount: post.ViewCount,
+		AIGenerated: post.AIGenerated,
+		Language:  post.Language,
+		CreatedAt: post.CreatedAt,
+		UpdatedAt: post.UpdatedAt,
 	})
 }
 
@@ -292,42 +287,31 @@ func (h *PostHandler) Delete(c *gin.Context) {
 	// Get post ID from URL
 	postID := c.Param("id")
 	if postID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Post ID is required"})
+		response.BadRequest(c, "Post ID is required")
 		return
 	}
 
 	// Get authenticated user ID
 	userId, exists := c.Get("userId")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		response.Unauthorized(c, "User not authenticated")
 		return
 	}
 
-	log.Printf("[POST-HANDLER] Deleting post %s by user: %s", postID, userId.(string))
+	logger.Infof(c.Request.Context(), "[POST-HANDLER] Deleting post %s by user: %s", postID, userId.(string))
 
 	// Call service
 	ctx := c.Request.Context()
 	err := h.postService.DeletePost(ctx, postID, userId.(string))
 	if err != nil {
-		log.Printf("[POST-HANDLER] Delete error: %v", err)
+		logger.Errorf(ctx, "[POST-HANDLER] Delete error: %v", err)
 		
-		if errors.Is(err, services.ErrPostNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
-			return
-		}
-		if errors.Is(err, services.ErrUnauthorizedPost) {
-			c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to delete this post"})
-			return
-		}
-		
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete post"})
+		response.Fail(c, err)
 		return
 	}
 
-	log.Printf("[POST-HANDLER] Post deleted successfully: %s", postID)
+	logger.Infof(ctx, "[POST-HANDLER] Post deleted successfully: %s", postID)
 
 	// Return response
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Post deleted successfully",
-	})
+	response.Message(c, "Post deleted successfully")
 }
\ No newline at end of file
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type PostImageHandler struct {
+	imageService *services.PostImageService
+}
+
+func NewPostImageHandler(imageService *services.PostImageService) *PostImageHandler {
+	return &PostImageHandler{imageService: imageService}
+}
+
+// UpdateImage - PATCH /api/posts/:id/images/:imageId (author only)
+func (h *PostImageHandler) UpdateImage(c *gin.Context) {
+	var req model.UpdatePostImageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	image, err := h.imageService.UpdateImage(c.Request.Context(), c.Param("id"), c.Param("imageId"), c.GetString("userId"), &req)
+	if err != nil {
+		respondPostImageError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Image updated successfully", image)
+}
+
+// RedescribeImage - POST /api/posts/:postId/images/:imageId/describe (author only, runs in the background)
+func (h *PostImageHandler) RedescribeImage(c *gin.Context) {
+	image, err := h.imageService.Redescribe(c.Request.Context(), c.Param("postId"), c.Param("imageId"), c.GetString("userId"))
+	if err != nil {
+		respondPostImageError(c, err)
+		return
+	}
+
+	response.Accepted(c, "The image will be described again shortly", image)
+}
+
+// respondPostImageError - As response.Fail, but says which feature is not configured
+func respondPostImageError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrNoLLMProvider) {
+		response.FailMessage(c, err, "Image descriptions are not configured")
+		return
+	}
+	response.Fail(c, err)
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type PostReviewHandler struct {
+	reviewService *services.PostReviewService
+}
+
+func NewPostReviewHandler(reviewService *services.PostReviewService) *PostReviewHandler {
+	return &PostReviewHandler{reviewService: reviewService}
+}
+
+// ListQueue - GET /api/admin/reviews?status=pending&page=&limit=
+func (h *PostReviewHandler) ListQueue(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	queue, err := h.reviewService.ListQueue(c.Request.Context(), c.Query("status"), page, limit)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, queue)
+}
+
+// GetPost - GET /api/admin/reviews/:id
+func (h *PostReviewHandler) GetPost(c *gin.Context) {
+	post, err := h.reviewService.GetPost(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, post)
+}
+
+// EditPost - PATCH /api/admin/reviews/:id
+func (h *PostReviewHandler) EditPost(c *gin.Context) {
+	var req model.ReviewPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	post, err := h.reviewService.EditPost(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Post updated successfully", post)
+}
+
+// ApprovePost - POST /api/admin/reviews/:id/approve
+func (h *PostReviewHandler) ApprovePost(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	post, err := h.reviewService.ApprovePost(c.Request.Context(), c.Param("id"), userId.(string))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Post approved and published", post)
+}
+
+// RejectPost - POST /api/admin/reviews/:id/reject
+func (h *PostReviewHandler) RejectPost(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req model.RejectPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	post, err := h.reviewService.RejectPost(c.Request.Context(), c.Param("id"), userId.(string), req.Reason)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Post rejected", post)
+}
+
+// RegeneratePost - POST /api/admin/reviews/:id/regenerate (runs in the background)
+func (h *PostReviewHandler) RegeneratePost(c *gin.Context) {
+	if err := h.reviewService.RegeneratePost(c.Request.Context(), c.Param("id")); err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Accepted(c, "Regeneration started; the post returns to pending when it finishes", nil)
+}
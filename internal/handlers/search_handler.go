@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	embeddingService *services.EmbeddingService
+}
+
+func NewSearchHandler(embeddingService *services.EmbeddingService) *SearchHandler {
+	return &SearchHandler{embeddingService: embeddingService}
+}
+
+// Search - GET /api/posts/search?q=&mode=keyword|semantic&page=&limit=
+func (h *SearchHandler) Search(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	results, err := h.embeddingService.Search(c.Request.Context(), c.Query("q"), c.Query("mode"), page, limit)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, results)
+}
+
+// Related - GET /api/posts/:id/related?limit=
+func (h *SearchHandler) Related(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if err != nil || limit < 1 || limit > 20 {
+		limit = 5
+	}
+
+	hits, mode, err := h.embeddingService.Related(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, gin.H{"posts": hits, "mode": mode})
+}
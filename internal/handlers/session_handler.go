@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type SessionHandler struct {
+	sessionService *services.SessionService
+}
+
+func NewSessionHandler(sessionService *services.SessionService) *SessionHandler {
+	return &SessionHandler{sessionService: sessionService}
+}
+
+// ListSessions - GET /api/me/sessions
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	sessions, err := h.sessionService.ListSessions(c.Request.Context(), userId.(string), c.GetString("sessionId"))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, sessions)
+}
+
+// RevokeSession - DELETE /api/me/sessions/:id
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	err := h.sessionService.RevokeSession(c.Request.Context(), userId.(string), c.Param("id"))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Message(c, "Session revoked successfully")
+}
+
+// RevokeAllSessions - DELETE /api/me/sessions (sign out everywhere)
+func (h *SessionHandler) RevokeAllSessions(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	revoked, err := h.sessionService.RevokeAllSessions(c.Request.Context(), userId.(string))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Signed out of all sessions", gin.H{"revoked": revoked})
+}
+
+// Logout - POST /api/auth/logout (revokes the current session)
+func (h *SessionHandler) Logout(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	err := h.sessionService.RevokeSession(c.Request.Context(), userId.(string), c.GetString("sessionId"))
+	if err != nil && !errors.Is(err, services.ErrSessionNotFound) {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Message(c, "logout successful")
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type TopicHandler struct {
+	topicService *services.TopicService
+}
+
+func NewTopicHandler(topicService *services.TopicService) *TopicHandler {
+	return &TopicHandler{topicService: topicService}
+}
+
+// ListTopics - GET /api/admin/ai/topics
+func (h *TopicHandler) ListTopics(c *gin.Context) {
+	topics, err := h.topicService.ListTopics(c.Request.Context())
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, topics)
+}
+
+// CreateTopic - POST /api/admin/ai/topics
+func (h *TopicHandler) CreateTopic(c *gin.Context) {
+	var req model.CreateAITopicRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	topic, err := h.topicService.CreateTopic(c.Request.Context(), &req)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, "Topic created successfully", topic)
+}
+
+// UpdateTopic - PATCH /api/admin/ai/topics/:id
+func (h *TopicHandler) UpdateTopic(c *gin.Context) {
+	var req model.UpdateAITopicRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	topic, err := h.topicService.UpdateTopic(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Topic updated successfully", topic)
+}
+
+// DeleteTopic - DELETE /api/admin/ai/topics/:id
+func (h *TopicHandler) DeleteTopic(c *gin.Context) {
+	if err := h.topicService.DeleteTopic(c.Request.Context(), c.Param("id")); err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Message(c, "Topic deleted successfully")
+}
+
+// ListCategories - GET /api/admin/ai/categories
+func (h *TopicHandler) ListCategories(c *gin.Context) {
+	categories, err := h.topicService.ListCategories(c.Request.Context())
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.OK(c, categories)
+}
+
+// CreateCategory - POST /api/admin/ai/categories
+func (h *TopicHandler) CreateCategory(c *gin.Context) {
+	var req model.CreateAICategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	category, err := h.topicService.CreateCategory(c.Request.Context(), &req)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, "Category created successfully", category)
+}
+
+// UpdateCategory - PATCH /api/admin/ai/categories/:id
+func (h *TopicHandler) UpdateCategory(c *gin.Context) {
+	var req model.UpdateAICategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	category, err := h.topicService.UpdateCategory(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Category updated successfully", category)
+}
+
+// DeleteCategory - DELETE /api/admin/ai/categories/:id
+func (h *TopicHandler) DeleteCategory(c *gin.Context) {
+	if err := h.topicService.DeleteCategory(c.Request.Context(), c.Param("id")); err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Message(c, "Category deleted successfully")
+}
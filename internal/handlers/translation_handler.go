@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type TranslationHandler struct {
+	translationService *services.TranslationService
+}
+
+func NewTranslationHandler(translationService *services.TranslationService) *TranslationHandler {
+	return &TranslationHandler{translationService: translationService}
+}
+
+// ListTranslations - GET /api/posts/:id/translations
+func (h *TranslationHandler) ListTranslations(c *gin.Context) {
+	translations, err := h.translationService.ListTranslations(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondTranslationError(c, err)
+		return
+	}
+
+	response.OK(c, translations)
+}
+
+// GetTranslation - GET /api/posts/:id/translations/:lang
+func (h *TranslationHandler) GetTranslation(c *gin.Context) {
+	translation, err := h.translationService.GetTranslation(c.Request.Context(), c.Param("id"), c.Param("lang"))
+	if err != nil {
+		respondTranslationError(c, err)
+		return
+	}
+
+	response.OK(c, translation)
+}
+
+// RequestTranslation - POST /api/posts/:postId/translations (the AI translates in the background)
+func (h *TranslationHandler) RequestTranslation(c *gin.Context) {
+	var req model.RequestTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	translation, err := h.translationService.RequestTranslation(
+		c.Request.Context(),
+		c.Param("postId"),
+		c.GetString("userId"),
+		c.GetString("userRole"),
+		&req,
+	)
+	if err != nil {
+		respondTranslationError(c, err)
+		return
+	}
+
+	response.Accepted(c, "Translation started; its status turns ready when it finishes", translation)
+}
+
+// UpdateTranslation - PUT /api/posts/:id/translations/:lang (author only)
+func (h *TranslationHandler) UpdateTranslation(c *gin.Context) {
+	var req model.UpdateTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	translation, err := h.translationService.UpdateTranslation(
+		c.Request.Context(),
+		c.Param("id"),
+		c.Param("lang"),
+		c.GetString("userId"),
+		&req,
+	)
+	if err != nil {
+		respondTranslationError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Translation saved successfully", translation)
+}
+
+// DeleteTranslation - DELETE /api/posts/:id/translations/:lang
+func (h *TranslationHandler) DeleteTranslation(c *gin.Context) {
+	err := h.translationService.DeleteTranslation(
+		c.Request.Context(),
+		c.Param("id"),
+		c.Param("lang"),
+		c.GetString("userId"),
+		c.GetString("userRole"),
+	)
+	if err != nil {
+		respondTranslationError(c, err)
+		return
+	}
+
+	response.Message(c, "Translation deleted successfully")
+}
+
+// respondTranslationError - As response.Fail, but says which feature is paused
+func respondTranslationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNoLLMProvider):
+		response.FailMessage(c, err, "AI translation is not configured")
+	case errors.Is(err, services.ErrAIBudgetExceeded):
+		response.FailMessage(c, err, "AI translation is paused until next month, the AI budget has been used up")
+	default:
+		response.Fail(c, err)
+	}
+}
@@ -1,47 +1,74 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/response"
 	"github.com/britinogn/quillhub/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware() gin.HandlerFunc{
+// SessionValidator - Verifies tokens and checks that the login session behind one is still active
+type SessionValidator interface {
+	VerifyToken(token string) (*utils.Claims, error)
+	ValidateSession(ctx context.Context, sessionID, userID string) error
+}
+
+func AuthMiddleware(sessions SessionValidator) gin.HandlerFunc{
 	return func (c *gin.Context)  {
 		// Get token from Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "Authorization header required",
-			})
+			response.Unauthorized(c, "Authorization header required")
 			return 
 		}
 
 		//Check if its a bearer token
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid authorization format. Use: Bearer <token>",
-			})
+			response.Unauthorized(c, "Invalid authorization format. Use: Bearer <token>")
 			return 
 		}
 
 		token := parts[1]
 		
 		//verify token - this returns *Claims, not string
-		claims, err := utils.VerifyToken(token)
+		claims, err := sessions.VerifyToken(token)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid or expired token",
-			})
+			response.Unauthorized(c, "Invalid or expired token")
 			return 
 		}	
+
+		// Reject tokens whose session was revoked (sign out, sign out everywhere). Any other
+		// failure means the check could not run, which must not sign everyone out.
+		if err := sessions.ValidateSession(c.Request.Context(), claims.SessionID, claims.UserID); err != nil {
+			if errors.Is(err, services.ErrSessionRevoked) || errors.Is(err, services.ErrSessionNotFound) {
+				response.Unauthorized(c, "Session has been revoked, please log in again")
+				return
+			}
+			response.Fail(c, &response.Error{
+				Status:  http.StatusServiceUnavailable,
+				Code:    response.CodeUnavailable,
+				Message: "Could not check your session, please try again",
+				Err:     err,
+			})
+			return
+		}
 	
 		// Store the USER ID STRING from claims, not the whole claims object
 		c.Set("userId", claims.UserID)  // ← Extract UserID from claims
 		c.Set("userRole", claims.Role)
+		c.Set("sessionId", claims.SessionID)
+
+		// Make the actor available to services (audit log)
+		c.Request = c.Request.WithContext(utils.WithActor(c.Request.Context(), utils.Actor{
+			UserID: claims.UserID,
+			Role:   claims.Role,
+		}))
 		
 		// Optionally store other useful info
 		// c.Set("userEmail", claims.Email)
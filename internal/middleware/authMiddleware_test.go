@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/britinogn/quillhub/internal/services"
+	"github.com/britinogn/quillhub/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type stubSessions struct {
+	err error
+}
+
+func (s stubSessions) VerifyToken(token string) (*utils.Claims, error) {
+	if token != "valid" {
+		return nil, errors.New("bad token")
+	}
+	return &utils.Claims{UserID: "user-1", Role: "user", SessionID: "session-1"}, nil
+}
+
+func (s stubSessions) ValidateSession(context.Context, string, string) error {
+	return s.err
+}
+
+func TestAuthMiddlewareSessionErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		header string
+		err    error
+		want   int
+	}{
+		{"active session", "Bearer valid", nil, http.StatusOK},
+		{"no header", "", nil, http.StatusUnauthorized},
+		{"bad token", "Bearer forged", nil, http.StatusUnauthorized},
+		{"revoked session", "Bearer valid", services.ErrSessionRevoked, http.StatusUnauthorized},
+		{"unknown session", "Bearer valid", fmt.Errorf("lookup: %w", services.ErrSessionNotFound), http.StatusUnauthorized},
+		{"database down", "Bearer valid", errors.New("failed to connect to database"), http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", AuthMiddleware(stubSessions{err: tt.err}), func(c *gin.Context) {
+				c.String(http.StatusOK, c.GetString("userId"))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
@@ -1,8 +1,8 @@
 package middleware
 
 import (
-	"net/http"
 
+	"github.com/britinogn/quillhub/pkg/response"
 	"github.com/gin-gonic/gin"
 )
 
@@ -11,26 +11,35 @@ func AdminOnly() gin.HandlerFunc {
 		// Get role from context (you must set it earlier)
 		userRole, exists := c.Get("userRole")
 		if !exists {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error": "User role not found",
-			})
+			response.Forbidden(c, "User role not found")
 			return
 		}
 
 		role, ok := userRole.(string)
 		if !ok || role != "admin" {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error": "Admin access required",
-			})
+			response.Forbidden(c, "Admin access required")
 			return
 		}
 
 		// if !ok || (role != "admin" && role != "moderator") {
-		// 	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		// 	response.Forbidden(c, "Admin access required")
 		// 	return
 		// }
 
 		// Only admins reach here
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// ModeratorOnly - Admins and moderators
+func ModeratorOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("userRole")
+		if role != "admin" && role != "moderator" {
+			response.Forbidden(c, "Moderator access required")
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/britinogn/quillhub/pkg/ratelimit"
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// Rate limit response headers (draft-ietf-httpapi-ratelimit-headers)
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RateLimitPolicyHeader    = "RateLimit-Policy"
+	RetryAfterHeader         = "Retry-After"
+)
+
+// RateLimitHeaders - Response headers browsers need CORS permission to read
+var RateLimitHeaders = []string{
+	RateLimitLimitHeader, RateLimitRemainingHeader, RateLimitResetHeader, RateLimitPolicyHeader, RetryAfterHeader,
+}
+
+// RateLimitKey - Who a request is counted against
+type RateLimitKey func(c *gin.Context) string
+
+// KeyByIP - The client's address
+func KeyByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// KeyByUser - The signed-in user, or the address before AuthMiddleware has run
+func KeyByUser(c *gin.Context) string {
+	if userID := c.GetString("userId"); userID != "" {
+		return "user:" + userID
+	}
+	return KeyByIP(c)
+}
+
+// KeyByToken - The bearer token, hashed so it is never stored, or the address without one.
+// The token is not verified here, so only use it where AuthMiddleware rejects made-up ones.
+func KeyByToken(c *gin.Context) string {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return KeyByIP(c)
+	}
+	sum := sha256.Sum256([]byte(token))
+	return "token:" + hex.EncodeToString(sum[:16])
+}
+
+var rateLimitKeys = map[string]RateLimitKey{
+	"ip":    KeyByIP,
+	"user":  KeyByUser,
+	"token": KeyByToken,
+}
+
+// RateLimit counts each request against policy under the caller's key and answers 429
+// once the limit is used up. If the store fails the request goes through: an outage of
+// Redis should not take the API down with it.
+func RateLimit(store ratelimit.Store, policy ratelimit.Policy, key RateLimitKey) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		caller := key(c)
+
+		result, err := store.Allow(ctx, policy, caller)
+		if err != nil {
+			logger.Warnf(ctx, "[RATE-LIMIT] %s check failed, letting the request through: %v", policy.Name, err)
+			c.Next()
+			return
+		}
+
+		setRateLimitHeaders(c, policy, result)
+
+		if !result.Allowed {
+			c.Header(RetryAfterHeader, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			response.Fail(c, &response.Error{
+				Status:  http.StatusTooManyRequests,
+				Code:    response.CodeTooManyRequests,
+				Message: "Too many requests, please try again later",
+				Err:     fmt.Errorf("%s rate limit reached for %s", policy.Name, caller),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// setRateLimitHeaders - A request can pass several limiters; the headers describe the one
+// closest to its limit
+func setRateLimitHeaders(c *gin.Context, policy ratelimit.Policy, result ratelimit.Result) {
+	if remaining, ok := c.Get("rateLimitRemaining"); ok && remaining.(int) < result.Remaining && result.Allowed {
+		return
+	}
+	c.Set("rateLimitRemaining", result.Remaining)
+
+	c.Header(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+	c.Header(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+	c.Header(RateLimitResetHeader, strconv.Itoa(ceilSeconds(result.Reset)))
+	c.Header(RateLimitPolicyHeader, policy.String())
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// RateLimits - The limiters the routes apply, one per configured policy
+type RateLimits struct {
+	Auth    gin.HandlerFunc // signup, login and social sign-in
+	Comment gin.HandlerFunc // new comments
+	Write   gin.HandlerFunc // authenticated writes; reads pass through
+	Read    gin.HandlerFunc // reads; writes pass through
+}
+
+// NewRateLimits - Limiters for the configured policies; a nil store turns them all off
+func NewRateLimits(store ratelimit.Store, cfg config.RateLimitConfig) RateLimits {
+	if store == nil {
+		pass := func(c *gin.Context) { c.Next() }
+		return RateLimits{Auth: pass, Comment: pass, Write: pass, Read: pass}
+	}
+
+	limiter := func(name string, p config.RateLimitPolicy) gin.HandlerFunc {
+		policy := ratelimit.Policy{
+			Name:      name,
+			Limit:     p.Requests,
+			Window:    p.Window,
+			Algorithm: ratelimit.Algorithm(p.Algorithm),
+		}
+		return RateLimit(store, policy, rateLimitKeys[p.Key])
+	}
+
+	read := limiter("read", cfg.Read)
+	write := limiter("write", cfg.Write)
+	return RateLimits{
+		Auth:    limiter("auth", cfg.Auth),
+		Comment: limiter("comment", cfg.Comment),
+		Write: func(c *gin.Context) {
+			if isRead(c.Request.Method) {
+				c.Next()
+				return
+			}
+			write(c)
+		},
+		Read: func(c *gin.Context) {
+			if !isRead(c.Request.Method) {
+				c.Next()
+				return
+			}
+			read(c)
+		},
+	}
+}
+
+func isRead(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"github.com/britinogn/quillhub/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// RequestInfo assigns a request ID and stores client details in the request context.
+// An incoming X-Request-ID is kept so a request can be traced across services; the
+// ID ends up in every log line and audit entry written while handling the request.
+func RequestInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Set("requestId", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		ctx := utils.WithRequestInfo(c.Request.Context(), utils.RequestInfo{
+			RequestID: requestID,
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// validRequestID - Client-supplied IDs are written to logs, so only short IDs of
+// letters, digits and - _ . : are kept
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.' || r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
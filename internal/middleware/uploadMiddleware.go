@@ -1,10 +1,13 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
 	"path/filepath"
 	"strings"
 
+	"github.com/britinogn/quillhub/pkg/response"
+	"github.com/britinogn/quillhub/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,38 +22,30 @@ func ValidateUpload(maxSizeMB int64, allowedExtensions []string) gin.HandlerFunc
 	return func(c *gin.Context) {
 		file, err := c.FormFile("file")
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-				"message": "No file uploaded",
-			})
+			response.Fail(c, utils.Invalid("file", "No file uploaded"))
 			return
 		}
 
 		// size check
 		maxSizeBytes := maxSizeMB * 1024 * 1024
 		if file.Size > maxSizeBytes {
-			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
-				"message":     "File too large",
-				"max_size_mb": maxSizeMB,
-			})
+			response.Fail(c, response.NewError(http.StatusRequestEntityTooLarge, "file_too_large",
+				fmt.Sprintf("File too large, the limit is %d MB", maxSizeMB)))
 			return
 		}
 
 		// extension check
 		ext := strings.ToLower(filepath.Ext(file.Filename))
 		if _, ok := allowed[ext]; !ok {
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-				"message":       "Invalid file type",
-				"allowed_types": allowedExtensions,
-			})
+			response.Fail(c, utils.Invalid("file",
+				"Invalid file type, allowed types are: "+strings.Join(allowedExtensions, ", ")))
 			return
 		}
 
 		// MIME type check (extra safety)
 		contentType := file.Header.Get("Content-Type")
 		if !strings.HasPrefix(contentType, "image/") {
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-				"message": "Invalid file content type",
-			})
+			response.Fail(c, utils.Invalid("file", "Invalid file content type"))
 			return
 		}
 
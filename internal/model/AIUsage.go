@@ -0,0 +1,71 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AIUsageEvent - One LLM call with its token counts and estimated cost
+type AIUsageEvent struct {
+	ID              pgtype.UUID `json:"id" db:"id"`
+	Feature         string      `json:"feature" db:"feature"`
+	UserID          pgtype.UUID `json:"user_id" db:"user_id"`
+	Provider        string      `json:"provider" db:"provider"`
+	Model           string      `json:"model" db:"model"`
+	InputTokens     int         `json:"input_tokens" db:"input_tokens"`
+	OutputTokens    int         `json:"output_tokens" db:"output_tokens"`
+	EstimatedTokens bool        `json:"estimated_tokens" db:"estimated_tokens"`
+	CostUSD         float64     `json:"cost_usd" db:"cost_usd"`
+	Success         bool        `json:"success" db:"success"`
+	LatencyMs       int64       `json:"latency_ms" db:"latency_ms"`
+	CreatedAt       time.Time   `json:"created_at" db:"created_at"`
+}
+
+// AIUsageTotals - Summed usage for one group (a day, a feature, a user or a model)
+type AIUsageTotals struct {
+	Key          string  `json:"key"`
+	Requests     int64   `json:"requests"`
+	Failures     int64   `json:"failures"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// AIBudget - Spend against one monthly budget
+type AIBudget struct {
+	Feature      string   `json:"feature"`   // "total" for the overall budget
+	LimitUSD     *float64 `json:"limit_usd"` // nil: no budget
+	SpentUSD     float64  `json:"spent_usd"`
+	RemainingUSD *float64 `json:"remaining_usd,omitempty"`
+	Exceeded     bool     `json:"exceeded"`
+}
+
+// AIBudgetStatus - This month's spend and budgets (months are UTC)
+type AIBudgetStatus struct {
+	Month    string     `json:"month"` // 2006-01
+	ResetsAt time.Time  `json:"resets_at"`
+	Total    AIBudget   `json:"total"`
+	Features []AIBudget `json:"features"`
+}
+
+// AIUsageSummary - Headline AI numbers for the admin dashboard
+type AIUsageSummary struct {
+	Budget            AIBudgetStatus `json:"budget"`
+	RequestsThisMonth int64          `json:"requests_this_month"`
+	TokensThisMonth   int64          `json:"tokens_this_month"`
+	CostTodayUSD      float64        `json:"cost_today_usd"`
+	AutoPosterPaused  bool           `json:"auto_poster_paused"`
+}
+
+// AIUsageReport - Usage breakdowns over a period for admins
+type AIUsageReport struct {
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
+	Totals    AIUsageTotals   `json:"totals"`
+	ByDay     []AIUsageTotals `json:"by_day"`
+	ByFeature []AIUsageTotals `json:"by_feature"`
+	ByModel   []AIUsageTotals `json:"by_model"`
+	TopUsers  []AIUsageTotals `json:"top_users"`
+	Budget    AIBudgetStatus  `json:"budget"`
+}
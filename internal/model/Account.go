@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DataExport - A background job producing a ZIP of a user's personal data
+type DataExport struct {
+	ID          pgtype.UUID `json:"id" db:"id"`
+	UserID      pgtype.UUID `json:"user_id" db:"user_id"`
+	Status      string      `json:"status" db:"status"`
+	FilePath    *string     `json:"-" db:"file_path"`
+	FileSize    *int64      `json:"file_size,omitempty" db:"file_size"`
+	Error       *string     `json:"error,omitempty" db:"error"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+	CompletedAt *time.Time  `json:"completed_at,omitempty" db:"completed_at"`
+	ExpiresAt   *time.Time  `json:"expires_at,omitempty" db:"expires_at"`
+}
+
+// DataExportResponse - What to return to client
+type DataExportResponse struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	FileSize    *int64     `json:"file_size,omitempty"`
+	Error       *string    `json:"error,omitempty"`
+	DownloadURL *string    `json:"download_url,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// UserProfile - Every stored profile field, as included in a data export
+type UserProfile struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Username   string     `json:"username"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	Gender     *string    `json:"gender,omitempty"`
+	ProfileURL *string    `json:"profile_url,omitempty"`
+	Bio        *string    `json:"bio,omitempty"`
+	IsVerified bool       `json:"is_verified"`
+	IsActive   bool       `json:"is_active"`
+	LastLogin  *time.Time `json:"last_login,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// UserLike - A post the user liked
+type UserLike struct {
+	PostID    string    `json:"post_id"`
+	PostTitle string    `json:"post_title"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DeleteAccountRequest - Body of DELETE /api/me
+type DeleteAccountRequest struct {
+	Mode string `json:"mode"` // "anonymize" (default) or "delete"
+}
+
+// AccountDeletion - A pending deletion request
+type AccountDeletion struct {
+	UserID       pgtype.UUID `db:"id"`
+	Mode         string      `db:"deletion_mode"`
+	RequestedAt  time.Time   `db:"deletion_requested_at"`
+	ScheduledFor time.Time   `db:"deletion_scheduled_for"`
+}
+
+// AccountDeletionResponse - What to return to client
+type AccountDeletionResponse struct {
+	Mode         string     `json:"mode"`
+	Status       string     `json:"status"` // "scheduled" or "completed"
+	RequestedAt  *time.Time `json:"requested_at,omitempty"`
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty"`
+}
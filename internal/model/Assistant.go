@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// SuggestTitlesRequest - Draft to suggest titles for
+type SuggestTitlesRequest struct {
+	Title   string `json:"title,omitempty"`
+	Content string `json:"content" binding:"required"`
+	Count   int    `json:"count,omitempty"` // default 5, max 10
+}
+
+// SuggestTitlesResponse - Candidate titles, best first
+type SuggestTitlesResponse struct {
+	Titles []string `json:"titles"`
+}
+
+// SummarizeRequest - Draft to summarize into an excerpt
+type SummarizeRequest struct {
+	Title    string `json:"title,omitempty"`
+	Content  string `json:"content" binding:"required"`
+	MaxWords int    `json:"max_words,omitempty"` // default 50, max 150
+}
+
+// SummarizeResponse - A short excerpt for cards and previews
+type SummarizeResponse struct {
+	Summary string `json:"summary"`
+}
+
+// SuggestTagsRequest - Draft to classify
+type SuggestTagsRequest struct {
+	Title   string `json:"title,omitempty"`
+	Content string `json:"content" binding:"required"`
+}
+
+// SuggestTagsResponse - Tags and category from the site's existing taxonomy
+type SuggestTagsResponse struct {
+	Tags     []string `json:"tags"`               // already used on the site
+	NewTags  []string `json:"new_tags,omitempty"` // proposed, not used anywhere yet
+	Category *string  `json:"category,omitempty"` // always an existing category
+}
+
+// RewriteRequest - A selection to improve; context is the surrounding text
+type RewriteRequest struct {
+	Selection string `json:"selection" binding:"required"`
+	Context   string `json:"context,omitempty"`
+	Goal      string `json:"goal,omitempty"` // grammar | clarity | concise (default clarity)
+}
+
+// RewriteResponse - The proposed replacement and what changed
+type RewriteResponse struct {
+	Rewrite string   `json:"rewrite"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+// AssistantQuota - Today's usage of the writing assistant
+type AssistantQuota struct {
+	Unlimited bool      `json:"unlimited"`
+	Limit     int       `json:"limit,omitempty"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining,omitempty"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
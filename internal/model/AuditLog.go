@@ -0,0 +1,48 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AuditLog - Database model for an append-only audit entry
+type AuditLog struct {
+	ID          pgtype.UUID     `json:"id" db:"id"`
+	ActorID     pgtype.UUID     `json:"actor_id" db:"actor_id"`
+	ActorRole   *string         `json:"actor_role,omitempty" db:"actor_role"`
+	Action      string          `json:"action" db:"action"`
+	TargetType  *string         `json:"target_type,omitempty" db:"target_type"`
+	TargetID    *string         `json:"target_id,omitempty" db:"target_id"`
+	IPAddress   *string         `json:"ip_address,omitempty" db:"ip_address"`
+	RequestID   *string         `json:"request_id,omitempty" db:"request_id"`
+	BeforeState json.RawMessage `json:"before,omitempty" db:"before_state"`
+	AfterState  json.RawMessage `json:"after,omitempty" db:"after_state"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AuditLogFilter - Query parameters for the admin audit log endpoint
+type AuditLogFilter struct {
+	ActorID string
+	Action  string
+	From    *time.Time
+	To      *time.Time
+	Limit   int
+	Offset  int
+}
+
+// AuditLogResponse - What to return to client
+type AuditLogResponse struct {
+	ID         string          `json:"id"`
+	ActorID    *string         `json:"actor_id,omitempty"`
+	ActorRole  *string         `json:"actor_role,omitempty"`
+	Action     string          `json:"action"`
+	TargetType *string         `json:"target_type,omitempty"`
+	TargetID   *string         `json:"target_id,omitempty"`
+	IPAddress  *string         `json:"ip_address,omitempty"`
+	RequestID  *string         `json:"request_id,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
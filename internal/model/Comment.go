@@ -12,6 +12,16 @@ type Comment struct {
 	PostID    pgtype.UUID `json:"post_id" db:"post_id"`
 	AuthorID  pgtype.UUID `json:"author_id" db:"author_id"`
 	Text      string      `json:"text" db:"text"`
+	ParentID  pgtype.UUID `json:"parent_id" db:"parent_id"`     // the comment a reply answers; null for top-level comments
+	IsAIReply bool        `json:"is_ai_reply" db:"is_ai_reply"` // written by a bot persona
+	ModerationStatus  string      `json:"moderation_status" db:"moderation_status"`
+	ToxicityScore     *float64    `json:"-" db:"toxicity_score"`
+	SpamScore         *float64    `json:"-" db:"spam_score"`
+	ModerationReasons []string    `json:"-" db:"moderation_reasons"`
+	ModerationSource  *string     `json:"-" db:"moderation_source"`
+	ModerationNote    *string     `json:"-" db:"moderation_note"`
+	ModeratedBy       pgtype.UUID `json:"-" db:"moderated_by"`
+	ModeratedAt       *time.Time  `json:"-" db:"moderated_at"`
 	CreatedAt time.Time   `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time   `json:"updated_at" db:"updated_at"`
 }
@@ -47,4 +57,37 @@ type CommentWithAuthor struct {
 	AuthorUsername string    `json:"author_username"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
-}
\ No newline at end of file
+}
+
+// ModerationResult - Verdict of the comment moderation pipeline
+type ModerationResult struct {
+	Status   string   `json:"status"` // approved | pending
+	Toxicity float64  `json:"toxicity"`
+	Spam     float64  `json:"spam"`
+	Reasons  []string `json:"reasons"`
+	Source   string   `json:"source"` // rules | llm | ai_reply
+}
+
+// ModerateCommentRequest - Moderator decision on a held comment
+type ModerateCommentRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// ModerationCommentResponse - A comment in the moderation queue
+type ModerationCommentResponse struct {
+	ID                string     `json:"id"`
+	PostID            string     `json:"post_id"`
+	AuthorID          string     `json:"author_id"`
+	Text              string     `json:"text"`
+	ParentID          *string    `json:"parent_id,omitempty"`
+	IsAIReply         bool       `json:"is_ai_reply"`
+	ModerationStatus  string     `json:"moderation_status"`
+	ToxicityScore     *float64   `json:"toxicity_score,omitempty"`
+	SpamScore         *float64   `json:"spam_score,omitempty"`
+	ModerationReasons []string   `json:"moderation_reasons"`
+	ModerationSource  *string    `json:"moderation_source,omitempty"`
+	ModerationNote    *string    `json:"moderation_note,omitempty"`
+	ModeratedBy       *string    `json:"moderated_by,omitempty"`
+	ModeratedAt       *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
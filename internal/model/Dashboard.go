@@ -15,6 +15,7 @@ type AdminDashboard struct {
 	TopContributors     []TopContributor     `json:"top_contributors"`
 	RecentComments      []RecentComment      `json:"recent_comments"`
 	PostsByCategory     map[string]int64     `json:"posts_by_category"`
+	AIUsage             *AIUsageSummary      `json:"ai_usage,omitempty"`
 }
 
 // UserDashboard - Personal stats for individual users
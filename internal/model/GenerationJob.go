@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// GenerationJob - One AI post generation, including retries
+type GenerationJob struct {
+	ID          pgtype.UUID `json:"id" db:"id"`
+	Trigger     string      `json:"trigger" db:"trigger"`
+	Status      string      `json:"status" db:"status"`
+	TopicID     pgtype.UUID `json:"topic_id" db:"topic_id"`
+	PersonaID   pgtype.UUID `json:"persona_id" db:"persona_id"`
+	Topic       string      `json:"topic" db:"topic"`
+	Category    *string     `json:"category,omitempty" db:"category"`
+	Provider    *string     `json:"provider,omitempty" db:"provider"`
+	Model       *string     `json:"model,omitempty" db:"model"`
+	Prompt      string      `json:"prompt" db:"prompt"`
+	Attempts    int         `json:"attempts" db:"attempts"`
+	RawResponse *string     `json:"raw_response,omitempty" db:"raw_response"`
+	Error       *string     `json:"error,omitempty" db:"error"`
+	LatencyMs   *int64      `json:"latency_ms,omitempty" db:"latency_ms"`
+	PostID      pgtype.UUID `json:"post_id" db:"post_id"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+	FinishedAt  *time.Time  `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+// GenerationAttempt - A single call to the LLM within a job
+type GenerationAttempt struct {
+	ID           pgtype.UUID `json:"id" db:"id"`
+	JobID        pgtype.UUID `json:"job_id" db:"job_id"`
+	Attempt      int         `json:"attempt" db:"attempt"`
+	Purpose      string      `json:"purpose" db:"purpose"`
+	Model        *string     `json:"model,omitempty" db:"model"`
+	RawResponse  *string     `json:"raw_response,omitempty" db:"raw_response"`
+	Error        *string     `json:"error,omitempty" db:"error"`
+	ErrorKind    *string     `json:"error_kind,omitempty" db:"error_kind"`
+	LatencyMs    int64       `json:"latency_ms" db:"latency_ms"`
+	InputTokens  int         `json:"input_tokens" db:"input_tokens"`
+	OutputTokens int         `json:"output_tokens" db:"output_tokens"`
+	CreatedAt    time.Time   `json:"created_at" db:"created_at"`
+}
+
+// GenerationJobFilter - Admin history filters
+type GenerationJobFilter struct {
+	Status string
+	Limit  int
+	Offset int
+}
+
+// GenerationJobResponse - What to return to client
+type GenerationJobResponse struct {
+	ID          string                      `json:"id"`
+	Trigger     string                      `json:"trigger"`
+	Status      string                      `json:"status"`
+	TopicID     *string                     `json:"topic_id,omitempty"`
+	PersonaID   *string                     `json:"persona_id,omitempty"`
+	Topic       string                      `json:"topic"`
+	Category    *string                     `json:"category,omitempty"`
+	Provider    *string                     `json:"provider,omitempty"`
+	Model       *string                     `json:"model,omitempty"`
+	Prompt      string                      `json:"prompt,omitempty"`
+	Attempts    int                         `json:"attempts"`
+	RawResponse *string                     `json:"raw_response,omitempty"`
+	Error       *string                     `json:"error,omitempty"`
+	LatencyMs   *int64                      `json:"latency_ms,omitempty"`
+	PostID      *string                     `json:"post_id,omitempty"`
+	CreatedAt   time.Time                   `json:"created_at"`
+	FinishedAt  *time.Time                  `json:"finished_at,omitempty"`
+	AttemptLog  []GenerationAttemptResponse `json:"attempt_log,omitempty"`
+}
+
+// GenerationAttemptResponse - What to return to client
+type GenerationAttemptResponse struct {
+	Attempt      int       `json:"attempt"`
+	Purpose      string    `json:"purpose"` // generate | repair
+	Model        *string   `json:"model,omitempty"`
+	RawResponse  *string   `json:"raw_response,omitempty"`
+	Error        *string   `json:"error,omitempty"`
+	ErrorKind    *string   `json:"error_kind,omitempty"`
+	LatencyMs    int64     `json:"latency_ms"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	CreatedAt    time.Time `json:"created_at"`
+}
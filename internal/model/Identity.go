@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UserIdentity - External OIDC identity linked to a local user
+type UserIdentity struct {
+	ID          pgtype.UUID `json:"id" db:"id"`
+	UserID      pgtype.UUID `json:"user_id" db:"user_id"`
+	Provider    string      `json:"provider" db:"provider"`
+	Subject     string      `json:"subject" db:"subject"`
+	Email       *string     `json:"email,omitempty" db:"email"`
+	LastLoginAt *time.Time  `json:"last_login_at,omitempty" db:"last_login_at"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+}
+
+// OAuthState - Pending authorization request (state, nonce and PKCE verifier)
+type OAuthState struct {
+	State        string      `db:"state"`
+	Provider     string      `db:"provider"`
+	Nonce        string      `db:"nonce"`
+	CodeVerifier string      `db:"code_verifier"`
+	LinkUserID   pgtype.UUID `db:"link_user_id"` // set when an authenticated user is linking an account
+	ExpiresAt    time.Time   `db:"expires_at"`
+	CreatedAt    time.Time   `db:"created_at"`
+}
+
+// ExternalProfile - Verified claims returned by an OIDC provider
+type ExternalProfile struct {
+	Provider          string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+	Picture           string
+}
+
+// OIDCProviderInfo - Public description of a configured provider
+type OIDCProviderInfo struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	LoginURL    string `json:"login_url"`
+}
+
+// IdentityResponse - What to return to client for a linked identity
+type IdentityResponse struct {
+	ID          string     `json:"id"`
+	Provider    string     `json:"provider"`
+	Email       *string    `json:"email,omitempty"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// InviteCode - Database model for a single-use registration invite
+type InviteCode struct {
+	ID        pgtype.UUID `json:"id" db:"id"`
+	Code      string      `json:"code" db:"code"`
+	Email     *string     `json:"email,omitempty" db:"email"`
+	Role      string      `json:"role" db:"role"`
+	CreatedBy pgtype.UUID `json:"created_by" db:"created_by"`
+	UsedBy    pgtype.UUID `json:"used_by" db:"used_by"`
+	UsedAt    *time.Time  `json:"used_at,omitempty" db:"used_at"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt time.Time   `json:"created_at" db:"created_at"`
+}
+
+// CreateInviteRequest - For admins creating invite codes
+type CreateInviteRequest struct {
+	Email          *string `json:"email" binding:"omitempty,email"`
+	Role           string  `json:"role"`
+	ExpiresInHours int     `json:"expires_in_hours" binding:"omitempty,min=1,max=8760"`
+}
+
+// InviteResponse - What to return to client
+type InviteResponse struct {
+	ID        string     `json:"id"`
+	Code      string     `json:"code"`
+	Email     *string    `json:"email,omitempty"`
+	Role      string     `json:"role"`
+	CreatedBy *string    `json:"created_by,omitempty"`
+	UsedBy    *string    `json:"used_by,omitempty"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// RegistrationInfo - Public description of the registration policy
+type RegistrationInfo struct {
+	Mode           string   `json:"mode"`
+	InviteRequired bool     `json:"invite_required"`
+	AllowedDomains []string `json:"allowed_domains,omitempty"`
+}
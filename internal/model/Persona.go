@@ -0,0 +1,105 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// BotPersona - An AI author with its own user account, prompt, model settings and schedule
+type BotPersona struct {
+	ID               pgtype.UUID `json:"id" db:"id"`
+	Name             string      `json:"name" db:"name"`
+	Description      *string     `json:"description,omitempty" db:"description"`
+	UserID           pgtype.UUID `json:"user_id" db:"user_id"`
+	Username         string      `json:"username" db:"username"`
+	PromptTemplate   *string     `json:"prompt_template,omitempty" db:"prompt_template"`
+	Tone             *string     `json:"tone,omitempty" db:"tone"`
+	Model            *string     `json:"model,omitempty" db:"model"`
+	Temperature      *float64    `json:"temperature,omitempty" db:"temperature"`
+	MaxTokens        *int        `json:"max_tokens,omitempty" db:"max_tokens"`
+	MinWords         *int        `json:"min_words,omitempty" db:"min_words"`
+	MaxWords         *int        `json:"max_words,omitempty" db:"max_words"`
+	ScheduleInterval *string     `json:"schedule_interval,omitempty" db:"schedule_interval"`
+	ScheduleCron     *string     `json:"schedule_cron,omitempty" db:"schedule_cron"`
+	Enabled          bool        `json:"enabled" db:"enabled"`
+	IsDefault        bool        `json:"is_default" db:"is_default"`
+	CreatedAt        time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// CreatePersonaRequest - For admins adding a persona; a bot user is created with the username
+type CreatePersonaRequest struct {
+	Name             string   `json:"name" binding:"required,max=100"`
+	Username         string   `json:"username" binding:"required,min=3,max=50"`
+	Description      *string  `json:"description"`
+	PromptTemplate   *string  `json:"prompt_template"`
+	Tone             *string  `json:"tone" binding:"omitempty,max=255"`
+	Model            *string  `json:"model" binding:"omitempty,max=100"`
+	Temperature      *float64 `json:"temperature" binding:"omitempty,min=0,max=2"`
+	MaxTokens        *int     `json:"max_tokens" binding:"omitempty,min=1,max=100000"`
+	MinWords         *int     `json:"min_words" binding:"omitempty,min=1,max=10000"`
+	MaxWords         *int     `json:"max_words" binding:"omitempty,min=1,max=10000"`
+	ScheduleInterval *string  `json:"schedule_interval"`
+	ScheduleCron     *string  `json:"schedule_cron"`
+	Enabled          *bool    `json:"enabled"`
+}
+
+// UpdatePersonaRequest - Partial update; an empty string clears an optional text field
+type UpdatePersonaRequest struct {
+	Name             *string  `json:"name" binding:"omitempty,max=100"`
+	Description      *string  `json:"description"`
+	PromptTemplate   *string  `json:"prompt_template"`
+	Tone             *string  `json:"tone" binding:"omitempty,max=255"`
+	Model            *string  `json:"model" binding:"omitempty,max=100"`
+	Temperature      *float64 `json:"temperature" binding:"omitempty,min=0,max=2"`
+	MaxTokens        *int     `json:"max_tokens" binding:"omitempty,min=0,max=100000"` // 0 clears
+	MinWords         *int     `json:"min_words" binding:"omitempty,min=0,max=10000"`   // 0 clears
+	MaxWords         *int     `json:"max_words" binding:"omitempty,min=0,max=10000"`   // 0 clears
+	ScheduleInterval *string  `json:"schedule_interval"`
+	ScheduleCron     *string  `json:"schedule_cron"`
+	Enabled          *bool    `json:"enabled"`
+}
+
+// PreviewPersonaPromptRequest - Render a persona's prompt for a sample topic
+type PreviewPersonaPromptRequest struct {
+	Topic    string `json:"topic" binding:"required,max=255"`
+	Category string `json:"category" binding:"max=100"`
+}
+
+// PersonaResponse - What to return to client
+type PersonaResponse struct {
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	Description      *string   `json:"description,omitempty"`
+	UserID           string    `json:"user_id"`
+	Username         string    `json:"username"`
+	PromptTemplate   *string   `json:"prompt_template,omitempty"` // omitted when using the built-in template
+	Tone             *string   `json:"tone,omitempty"`
+	Model            *string   `json:"model,omitempty"`
+	Temperature      *float64  `json:"temperature,omitempty"`
+	MaxTokens        *int      `json:"max_tokens,omitempty"`
+	MinWords         *int      `json:"min_words,omitempty"`
+	MaxWords         *int      `json:"max_words,omitempty"`
+	ScheduleInterval *string   `json:"schedule_interval,omitempty"`
+	ScheduleCron     *string   `json:"schedule_cron,omitempty"`
+	Enabled          bool      `json:"enabled"`
+	IsDefault        bool      `json:"is_default"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// PersonaRunStatus - Auto-poster state for one persona
+type PersonaRunStatus struct {
+	PersonaID     string     `json:"persona_id"`
+	Name          string     `json:"name"`
+	Schedule      string     `json:"schedule"` // "main", "every 2h0m0s" or "cron 0 9 * * *"
+	Generating    bool       `json:"generating"`
+	NextRunAt     *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt     *time.Time `json:"last_run_at,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	LastError     *string    `json:"last_error,omitempty"`
+	LastPostID    *string    `json:"last_post_id,omitempty"`
+	TotalRuns     int64      `json:"total_runs"`
+	TotalFailures int64      `json:"total_failures"`
+}
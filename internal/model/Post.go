@@ -19,6 +19,16 @@ type Post struct {
 	Category 	*string 		`json:"category" db:"category"`
 	IsPublished bool         	`json:"is_published" db:"is_published"`
 	ViewCount 	int64   		`json:"view_count" db:"view_count"`
+	AIGenerated bool         	`json:"ai_generated" db:"ai_generated"`
+	ReviewStatus *string     	`json:"review_status,omitempty" db:"review_status"` // pending | approved | rejected; nil when never reviewed
+	ReviewReason *string     	`json:"review_reason,omitempty" db:"review_reason"`
+	ReviewedBy  pgtype.UUID  	`json:"-" db:"reviewed_by"`
+	ReviewedAt  *time.Time   	`json:"reviewed_at,omitempty" db:"reviewed_at"`
+	GenerationJobID pgtype.UUID `json:"-" db:"generation_job_id"`
+	Language    string       	`json:"language" db:"language"` // of the title and content as returned
+	OriginalLanguage *string 	`json:"original_language,omitempty" db:"-"` // set when a translation is returned
+	AvailableLanguages []string `json:"available_languages,omitempty" db:"-"`
+	Images      []PostImageResponse `json:"images,omitempty" db:"-"` // image_url with alt text and captions
 	CreatedAt 	time.Time    	`json:"created_at" db:"created_at"`
 	UpdatedAt  	time.Time    	`json:"updated_at" db:"updated_at"`
 }
@@ -30,6 +40,7 @@ type CreatePostRequest struct {
 	//ImageURL *[]string  `json:"image_url,omitempty"`
 	Category    string  `json:"category,omitempty"`
 	Tags     []string `json:"tags,omitempty"`
+	Language string   `json:"language,omitempty"` // BCP 47 code; defaults to "en"
 	AuthorID   string `json:"author_id"`
 }
 
@@ -41,6 +52,7 @@ type UpdatePostRequest struct {
 	Category  *string 	`json:"category,omitempty"`
 	Tags     []string `json:"tags,omitempty"`
 	IsPublished *bool    `json:"is_published,omitempty"`
+	Language *string  `json:"language,omitempty"`
 	ViewCount int64 	`json:"view_count,omitempty"`
 }
 
@@ -51,10 +63,43 @@ type PostResponse struct {
 	Content   string    `json:"content"`
 	AuthorID  string    `json:"author_id"`
 	ImageURL  []string   `json:"image_url,omitempty"`
+	Images    []PostImageResponse `json:"images,omitempty"`
 	Tags      []string  `json:"tags"`
 	Category  *string 	`json:"category,omitempty"`
 	IsPublished bool 	`json:"is_published,omitempty"`
 	ViewCount int64 	`json:"view_count,omitempty"`
+	AIGenerated bool 	`json:"ai_generated"`
+	Language  string    `json:"language"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// ReviewPostRequest - Admin edits to a post waiting for review
+type ReviewPostRequest struct {
+	Title    *string  `json:"title,omitempty"`
+	Content  *string  `json:"content,omitempty"`
+	Category *string  `json:"category,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// RejectPostRequest - Why an AI post was rejected
+type RejectPostRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ReviewPostResponse - A queued AI post as shown to reviewers
+type ReviewPostResponse struct {
+	ID              string     `json:"id"`
+	Title           string     `json:"title"`
+	Content         string     `json:"content"`
+	Tags            []string   `json:"tags"`
+	Category        *string    `json:"category,omitempty"`
+	ReviewStatus    string     `json:"review_status"`
+	ReviewReason    *string    `json:"review_reason,omitempty"`
+	ReviewedBy      *string    `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	GenerationJobID *string    `json:"generation_job_id,omitempty"`
+	Regenerating    bool       `json:"regenerating"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
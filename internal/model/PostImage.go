@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PostImage - Alt text and caption for one image of a post
+type PostImage struct {
+	ID            pgtype.UUID `json:"id" db:"id"`
+	PostID        pgtype.UUID `json:"post_id" db:"post_id"`
+	URL           string      `json:"url" db:"url"`
+	AltText       *string     `json:"alt_text,omitempty" db:"alt_text"` // AI
+	Caption       *string     `json:"caption,omitempty" db:"caption"`
+	AuthorAltText *string     `json:"author_alt_text,omitempty" db:"author_alt_text"` // author override
+	AuthorCaption *string     `json:"author_caption,omitempty" db:"author_caption"`
+	Status        string      `json:"status" db:"status"` // pending | ready | failed
+	Error         *string     `json:"error,omitempty" db:"error"`
+	Attempts      int         `json:"attempts" db:"attempts"`
+	Model         *string     `json:"model,omitempty" db:"model"`
+	DescribedAt   *time.Time  `json:"described_at,omitempty" db:"described_at"`
+	CreatedAt     time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// ImageDescriptionSource - An image waiting for AI text, with the post context the prompt uses
+type ImageDescriptionSource struct {
+	ImageID   pgtype.UUID
+	PostID    pgtype.UUID
+	URL       string
+	PostTitle string
+	Language  string
+	Attempts  int
+}
+
+// UpdatePostImageRequest - Author overrides; an empty string goes back to the AI text
+type UpdatePostImageRequest struct {
+	AltText *string `json:"alt_text,omitempty"`
+	Caption *string `json:"caption,omitempty"`
+}
+
+// PostImageResponse - An image with the text readers should get
+type PostImageResponse struct {
+	ID            string  `json:"id,omitempty"`
+	URL           string  `json:"url"`
+	AltText       *string `json:"alt_text"`
+	Caption       *string `json:"caption"`
+	AltTextSource string  `json:"alt_text_source,omitempty"` // ai | author
+	CaptionSource string  `json:"caption_source,omitempty"`
+	Status        string  `json:"status"` // of the AI description
+}
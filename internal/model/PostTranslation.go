@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PostTranslation - A post's title, content and tags in another language
+type PostTranslation struct {
+	ID              pgtype.UUID `json:"id" db:"id"`
+	PostID          pgtype.UUID `json:"post_id" db:"post_id"`
+	Language        string      `json:"language" db:"language"`
+	Title           string      `json:"title" db:"title"`
+	Content         string      `json:"content" db:"content"`
+	Tags            []string    `json:"tags" db:"tags"`
+	Source          string      `json:"source" db:"source"` // ai | manual
+	Status          string      `json:"status" db:"status"` // pending | ready | failed
+	Error           *string     `json:"error,omitempty" db:"error"`
+	Model           *string     `json:"model,omitempty" db:"model"`
+	TranslatedBy    pgtype.UUID `json:"-" db:"translated_by"`
+	SourceUpdatedAt *time.Time  `json:"-" db:"source_updated_at"`
+	CreatedAt       time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// RequestTranslationRequest - Ask the AI to translate a post
+type RequestTranslationRequest struct {
+	Language string `json:"language" binding:"required"`
+	// Overwrite replaces a translation the author wrote by hand
+	Overwrite bool `json:"overwrite"`
+}
+
+// UpdateTranslationRequest - Author edits; title and content are required for a new translation
+type UpdateTranslationRequest struct {
+	Title   *string  `json:"title,omitempty"`
+	Content *string  `json:"content,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// PostTranslationResponse - A translation as returned to clients
+type PostTranslationResponse struct {
+	ID           string    `json:"id"`
+	PostID       string    `json:"post_id"`
+	Language     string    `json:"language"`
+	Title        string    `json:"title,omitempty"`
+	Content      string    `json:"content,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	Source       string    `json:"source"`
+	Status       string    `json:"status"`
+	Error        *string   `json:"error,omitempty"`
+	Model        *string   `json:"model,omitempty"`
+	TranslatedBy *string   `json:"translated_by,omitempty"`
+	Available    bool      `json:"available"` // has text readers can see
+	Outdated     bool      `json:"outdated"`  // the post changed after it was translated
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
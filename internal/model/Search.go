@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PostEmbedding - Vector representation of a post for similarity search
+type PostEmbedding struct {
+	PostID      pgtype.UUID `json:"post_id" db:"post_id"`
+	Model       string      `json:"model" db:"model"`
+	Dims        int         `json:"dims" db:"dims"`
+	Embedding   []float32   `json:"-" db:"embedding"`
+	ContentHash string      `json:"content_hash" db:"content_hash"`
+	EmbeddedAt  time.Time   `json:"embedded_at" db:"embedded_at"`
+}
+
+// EmbeddingSource - A post whose embedding is missing or out of date
+type EmbeddingSource struct {
+	PostID      pgtype.UUID
+	Title       string
+	Content     string
+	Tags        []string
+	Category    *string
+	ContentHash string
+}
+
+// PostSearchHit - A post with its relevance score
+type PostSearchHit struct {
+	Post  *Post   `json:"post"`
+	Score float64 `json:"score"`
+}
+
+// PostSearchResponse - Search results, best first
+type PostSearchResponse struct {
+	Query   string          `json:"query"`
+	Mode    string          `json:"mode"` // keyword | semantic
+	Page    int             `json:"page"`
+	Limit   int             `json:"limit"`
+	Results []PostSearchHit `json:"results"`
+}
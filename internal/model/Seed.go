@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// SeedData - Demo content for local development, written by the seed command
+type SeedData struct {
+	Users []SeedUser
+	Posts []SeedPost
+}
+
+type SeedUser struct {
+	Name     string
+	Username string
+	Email    string
+	Bio      string
+}
+
+// SeedPost - Authors, likers and commenters are indexes into SeedData.Users
+type SeedPost struct {
+	Author    int
+	Title     string
+	Content   string
+	Category  string
+	Tags      []string
+	ViewCount int64
+	Age       time.Duration // how long ago it was published
+	LikedBy   []int
+	Comments  []SeedComment
+}
+
+type SeedComment struct {
+	Author int
+	Text   string
+	After  time.Duration // written this long after the post
+}
+
+// SeedResult - What the seed command created
+type SeedResult struct {
+	Users    int      `json:"users"`
+	Posts    int      `json:"posts"`
+	Comments int      `json:"comments"`
+	Likes    int      `json:"likes"`
+	Emails   []string `json:"emails"` // of the demo accounts, for signing in
+}
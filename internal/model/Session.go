@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Session - A login session backing an issued JWT
+type Session struct {
+	ID         pgtype.UUID `json:"id" db:"id"`
+	UserID     pgtype.UUID `json:"user_id" db:"user_id"`
+	UserAgent  *string     `json:"user_agent,omitempty" db:"user_agent"`
+	IPAddress  *string     `json:"ip_address,omitempty" db:"ip_address"`
+	CreatedAt  time.Time   `json:"created_at" db:"created_at"`
+	LastSeenAt time.Time   `json:"last_seen_at" db:"last_seen_at"`
+	ExpiresAt  time.Time   `json:"expires_at" db:"expires_at"`
+	RevokedAt  *time.Time  `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// SessionMeta - Client details captured when a session is created
+type SessionMeta struct {
+	UserAgent string
+	IPAddress string
+}
+
+// SessionResponse - What to return to client
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  *string   `json:"user_agent,omitempty"`
+	IPAddress  *string   `json:"ip_address,omitempty"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
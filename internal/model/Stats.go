@@ -0,0 +1,11 @@
+package model
+
+// StatsRecount - What recount-stats changed, and the totals afterwards
+type StatsRecount struct {
+	TopicsUpdated        int64 `json:"topics_updated"`         // last_used_at / last_post_id rebuilt from generation jobs
+	AssistantDaysUpdated int64 `json:"assistant_days_updated"` // daily token totals rebuilt from the AI usage log
+	TotalUsers           int64 `json:"total_users"`
+	TotalPosts           int64 `json:"total_posts"`
+	TotalComments        int64 `json:"total_comments"`
+	TotalLikes           int64 `json:"total_likes"`
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AICategory - A category the auto-poster can post under
+type AICategory struct {
+	ID        pgtype.UUID `json:"id" db:"id"`
+	Name      string      `json:"name" db:"name"`
+	Weight    int         `json:"weight" db:"weight"`
+	Enabled   bool        `json:"enabled" db:"enabled"`
+	CreatedAt time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// AITopic - A topic the auto-poster can write about
+type AITopic struct {
+	ID            pgtype.UUID `json:"id" db:"id"`
+	Title         string      `json:"title" db:"title"`
+	CategoryID    pgtype.UUID `json:"category_id" db:"category_id"`
+	CategoryName  *string     `json:"category_name,omitempty" db:"category_name"`
+	Weight        int         `json:"weight" db:"weight"`
+	Enabled       bool        `json:"enabled" db:"enabled"`
+	CooldownHours int         `json:"cooldown_hours" db:"cooldown_hours"`
+	LastUsedAt    *time.Time  `json:"last_used_at,omitempty" db:"last_used_at"`
+	LastPostID    pgtype.UUID `json:"last_post_id" db:"last_post_id"`
+	CreatedAt     time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// CreateAICategoryRequest - For admins adding a category
+type CreateAICategoryRequest struct {
+	Name    string `json:"name" binding:"required,max=100"`
+	Weight  *int   `json:"weight" binding:"omitempty,min=1,max=1000"`
+	Enabled *bool  `json:"enabled"`
+}
+
+// UpdateAICategoryRequest - Partial update
+type UpdateAICategoryRequest struct {
+	Name    *string `json:"name" binding:"omitempty,max=100"`
+	Weight  *int    `json:"weight" binding:"omitempty,min=1,max=1000"`
+	Enabled *bool   `json:"enabled"`
+}
+
+// CreateAITopicRequest - For admins adding a topic
+type CreateAITopicRequest struct {
+	Title         string  `json:"title" binding:"required,max=255"`
+	CategoryID    *string `json:"category_id"`
+	Weight        *int    `json:"weight" binding:"omitempty,min=1,max=1000"`
+	Enabled       *bool   `json:"enabled"`
+	CooldownHours *int    `json:"cooldown_hours" binding:"omitempty,min=0,max=8760"`
+}
+
+// UpdateAITopicRequest - Partial update; an empty category_id clears the category
+type UpdateAITopicRequest struct {
+	Title         *string `json:"title" binding:"omitempty,max=255"`
+	CategoryID    *string `json:"category_id"`
+	Weight        *int    `json:"weight" binding:"omitempty,min=1,max=1000"`
+	Enabled       *bool   `json:"enabled"`
+	CooldownHours *int    `json:"cooldown_hours" binding:"omitempty,min=0,max=8760"`
+}
+
+// AICategoryResponse - What to return to client
+type AICategoryResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Weight    int       `json:"weight"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AITopicResponse - What to return to client
+type AITopicResponse struct {
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	CategoryID    *string    `json:"category_id,omitempty"`
+	CategoryName  *string    `json:"category_name,omitempty"`
+	Weight        int        `json:"weight"`
+	Enabled       bool       `json:"enabled"`
+	CooldownHours int        `json:"cooldown_hours"`
+	LastUsedAt    *time.Time `json:"last_used_at,omitempty"`
+	LastPostID    *string    `json:"last_post_id,omitempty"`
+	AvailableAt   *time.Time `json:"available_at,omitempty"` // when the cooldown ends
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TopicSelection - What the auto-poster should write next
+type TopicSelection struct {
+	TopicID  string
+	Topic    string
+	Category string
+}
@@ -12,6 +12,7 @@ type User struct {
     Username    string         `json:"username" db:"username"`
     Email       string         `json:"email" db:"email"`
     Password    string         `json:"-" db:"password"`
+    PasswordSet bool           `json:"-" db:"password_set"` // false for accounts created through an external identity
     Role        string         `json:"role" db:"role"`
     Gender      *string        `json:"gender,omitempty" db:"gender"`
     ProfileURL  *string        `json:"profile_url,omitempty" db:"profile_url"`
@@ -29,8 +30,12 @@ type CreateUserRequest struct {
     Username string `json:"username" binding:"required,min=3"` 
     Email    string `json:"email" binding:"required,email"`
     Password string `json:"password" binding:"required,min=8"`
-    Role     *string `json:"role"`  // ← Optional role field
+    InviteCode string `json:"invite_code"` // required when registration is invite-only
+}
 
+// UpdateRoleRequest - for admins changing a user's role
+type UpdateRoleRequest struct {
+    Role string `json:"role" binding:"required"`
 }
 
 // UserResponse - what to return to client (without sensitive data)
@@ -1,16 +1,46 @@
 // internal/model/ai_post.go
 package model
 
+import "time"
+
 type AIPostRequest struct {
 	Topic      string   `json:"topic"`
 	Category   string   `json:"category"`
 	Tags       []string `json:"tags"`
 	MinLength  int      `json:"min_length"`  // Minimum word count
 	MaxLength  int      `json:"max_length"`  // Maximum word count
+	Tone       string   `json:"tone"`        // empty lets the model pick a tone for the topic
 }
 
 type AIGeneratedPost struct {
 	Title   string   `json:"title"`
 	Content string   `json:"content"`
 	Tags    []string `json:"tags"`
-}
\ No newline at end of file
+}
+
+// AutoPosterStatus - Scheduler state reported to admins (schedule fields describe the main schedule)
+type AutoPosterStatus struct {
+	Running       bool       `json:"running"`
+	Generating    bool       `json:"generating"`
+	ScheduleType  string     `json:"schedule_type"` // "interval" or "cron"
+	Interval      string     `json:"interval,omitempty"`
+	Cron          string     `json:"cron,omitempty"`
+	RequireReview bool       `json:"require_review"` // new posts wait in the review queue
+	BudgetPaused  bool       `json:"budget_paused"`  // skipping runs until the AI budget allows them again
+	PausedReason  *string    `json:"paused_reason,omitempty"`
+	NextRunAt     *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt     *time.Time `json:"last_run_at,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	LastError     *string    `json:"last_error,omitempty"`
+	LastPostID    *string    `json:"last_post_id,omitempty"`
+	TotalRuns     int64      `json:"total_runs"`
+	TotalFailures int64      `json:"total_failures"`
+
+	Personas []PersonaRunStatus `json:"personas"` // run state per persona; the fields above sum them up
+}
+
+// UpdateAutoPosterScheduleRequest - Set exactly one of interval (Go duration, e.g. "30m") or cron
+type UpdateAutoPosterScheduleRequest struct {
+	Interval string `json:"interval"`
+	Cron     string `json:"cron"`
+}
@@ -0,0 +1,548 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AccountRepository - Personal data exports and account deletion
+type AccountRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAccountRepository(db *pgxpool.Pool) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+const dataExportColumns = `id, user_id, status, file_path, file_size, error, created_at, completed_at, expires_at`
+
+func scanDataExport(row pgx.Row) (*model.DataExport, error) {
+	var export model.DataExport
+	err := row.Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&export.FilePath,
+		&export.FileSize,
+		&export.Error,
+		&export.CreatedAt,
+		&export.CompletedAt,
+		&export.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// CreateExport - Queue a new export job
+func (r *AccountRepository) CreateExport(ctx context.Context, userID string) (*model.DataExport, error) {
+	query := `INSERT INTO data_exports (user_id) VALUES ($1) RETURNING ` + dataExportColumns
+
+	export, err := scanDataExport(r.db.QueryRow(ctx, query, userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create data export: %w", err)
+	}
+
+	return export, nil
+}
+
+// FindExport - Get one of the user's exports
+func (r *AccountRepository) FindExport(ctx context.Context, exportID, userID string) (*model.DataExport, error) {
+	query := `SELECT ` + dataExportColumns + ` FROM data_exports WHERE id = $1 AND user_id = $2`
+
+	export, err := scanDataExport(r.db.QueryRow(ctx, query, exportID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find data export: %w", err)
+	}
+
+	return export, nil
+}
+
+// ListExports - The user's exports, newest first
+func (r *AccountRepository) ListExports(ctx context.Context, userID string) ([]*model.DataExport, error) {
+	query := `SELECT ` + dataExportColumns + ` FROM data_exports WHERE user_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data exports: %w", err)
+	}
+	defer rows.Close()
+
+	var exports []*model.DataExport
+	for rows.Next() {
+		export, err := scanDataExport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan data export: %w", err)
+		}
+		exports = append(exports, export)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating data exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+// HasActiveExport - Whether the user already has an export pending or in progress
+func (r *AccountRepository) HasActiveExport(ctx context.Context, userID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM data_exports WHERE user_id = $1 AND status IN ('pending', 'processing'))`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check data exports: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ClaimPendingExport - Move the oldest pending export to processing; nil when the queue is empty
+func (r *AccountRepository) ClaimPendingExport(ctx context.Context) (*model.DataExport, error) {
+	query := `
+		UPDATE data_exports
+		SET status = 'processing'
+		WHERE id = (
+			SELECT id FROM data_exports
+			WHERE status = 'pending'
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + dataExportColumns
+
+	export, err := scanDataExport(r.db.QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim data export: %w", err)
+	}
+
+	return export, nil
+}
+
+// RequeueStaleExports - Put exports left in processing by a previous run back in the queue
+func (r *AccountRepository) RequeueStaleExports(ctx context.Context) (int64, error) {
+	query := `UPDATE data_exports SET status = 'pending' WHERE status = 'processing'`
+
+	result, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue data exports: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// CompleteExport - Record the finished archive
+func (r *AccountRepository) CompleteExport(ctx context.Context, exportID, filePath string, fileSize int64, expiresAt time.Time) error {
+	query := `
+		UPDATE data_exports
+		SET status = 'completed', file_path = $2, file_size = $3, error = NULL,
+			completed_at = CURRENT_TIMESTAMP, expires_at = $4
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, exportID, filePath, fileSize, expiresAt); err != nil {
+		return fmt.Errorf("failed to complete data export: %w", err)
+	}
+
+	return nil
+}
+
+// FailExport - Record why an export could not be built
+func (r *AccountRepository) FailExport(ctx context.Context, exportID, reason string) error {
+	query := `
+		UPDATE data_exports
+		SET status = 'failed', error = $2, completed_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, exportID, reason); err != nil {
+		return fmt.Errorf("failed to mark data export failed: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredExports - Remove expired export rows and return their archive paths
+func (r *AccountRepository) DeleteExpiredExports(ctx context.Context) ([]string, error) {
+	query := `
+		DELETE FROM data_exports
+		WHERE expires_at IS NOT NULL AND expires_at < CURRENT_TIMESTAMP
+		RETURNING file_path
+	`
+
+	return r.collectFilePaths(ctx, query)
+}
+
+// DeleteExportsByUserID - Remove all of a user's exports and return their archive paths
+func (r *AccountRepository) DeleteExportsByUserID(ctx context.Context, userID string) ([]string, error) {
+	query := `DELETE FROM data_exports WHERE user_id = $1 RETURNING file_path`
+
+	return r.collectFilePaths(ctx, query, userID)
+}
+
+func (r *AccountRepository) collectFilePaths(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete data exports: %w", err)
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path *string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("failed to scan data export path: %w", err)
+		}
+		if path != nil {
+			paths = append(paths, *path)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating data exports: %w", err)
+	}
+
+	return paths, nil
+}
+
+// GetProfile - Every stored profile field of a user
+func (r *AccountRepository) GetProfile(ctx context.Context, userID string) (*model.UserProfile, error) {
+	query := `
+		SELECT id::text, name, username, email, role, gender, profile_url, bio,
+			COALESCE(is_verified, false), COALESCE(is_active, true), last_login, created_at, updated_at
+		FROM users
+		WHERE id = $1
+	`
+
+	var profile model.UserProfile
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&profile.ID,
+		&profile.Name,
+		&profile.Username,
+		&profile.Email,
+		&profile.Role,
+		&profile.Gender,
+		&profile.ProfileURL,
+		&profile.Bio,
+		&profile.IsVerified,
+		&profile.IsActive,
+		&profile.LastLogin,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// GetCommentsByAuthor - Every comment written by the user
+func (r *AccountRepository) GetCommentsByAuthor(ctx context.Context, userID string) ([]*model.Comment, error) {
+	query := `
+		SELECT id, post_id, author_id, text, created_at, updated_at
+		FROM comments
+		WHERE author_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*model.Comment
+	for rows.Next() {
+		var comment model.Comment
+		err := rows.Scan(
+			&comment.ID,
+			&comment.PostID,
+			&comment.AuthorID,
+			&comment.Text,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, &comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// GetLikesByUser - Every post the user liked
+func (r *AccountRepository) GetLikesByUser(ctx context.Context, userID string) ([]model.UserLike, error) {
+	query := `
+		SELECT l.post_id::text, p.title, l.created_at
+		FROM likes l
+		JOIN posts p ON p.id = l.post_id
+		WHERE l.user_id = $1
+		ORDER BY l.created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch likes: %w", err)
+	}
+	defer rows.Close()
+
+	var likes []model.UserLike
+	for rows.Next() {
+		var like model.UserLike
+		if err := rows.Scan(&like.PostID, &like.PostTitle, &like.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan like: %w", err)
+		}
+		likes = append(likes, like)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating likes: %w", err)
+	}
+
+	return likes, nil
+}
+
+// ScheduleDeletion - Mark the account for deletion once the cool-off period ends
+func (r *AccountRepository) ScheduleDeletion(ctx context.Context, userID, mode string, scheduledFor time.Time) (*model.AccountDeletion, error) {
+	query := `
+		UPDATE users
+		SET deletion_mode = $2, deletion_requested_at = CURRENT_TIMESTAMP, deletion_scheduled_for = $3
+		WHERE id = $1 AND anonymized_at IS NULL
+		RETURNING id, deletion_mode, deletion_requested_at, deletion_scheduled_for
+	`
+
+	var deletion model.AccountDeletion
+	err := r.db.QueryRow(ctx, query, userID, mode, scheduledFor).Scan(
+		&deletion.UserID,
+		&deletion.Mode,
+		&deletion.RequestedAt,
+		&deletion.ScheduledFor,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to schedule account deletion: %w", err)
+	}
+
+	return &deletion, nil
+}
+
+// FindDeletion - The user's pending deletion request, if any
+func (r *AccountRepository) FindDeletion(ctx context.Context, userID string) (*model.AccountDeletion, error) {
+	query := `
+		SELECT id, deletion_mode, deletion_requested_at, deletion_scheduled_for
+		FROM users
+		WHERE id = $1 AND deletion_scheduled_for IS NOT NULL AND anonymized_at IS NULL
+	`
+
+	var deletion model.AccountDeletion
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&deletion.UserID,
+		&deletion.Mode,
+		&deletion.RequestedAt,
+		&deletion.ScheduledFor,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find account deletion: %w", err)
+	}
+
+	return &deletion, nil
+}
+
+// CancelDeletion - Clear a pending deletion request; false when there was none
+func (r *AccountRepository) CancelDeletion(ctx context.Context, userID string) (bool, error) {
+	query := `
+		UPDATE users
+		SET deletion_mode = NULL, deletion_requested_at = NULL, deletion_scheduled_for = NULL
+		WHERE id = $1 AND deletion_scheduled_for IS NOT NULL AND anonymized_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// FindDueDeletions - Deletion requests whose cool-off period has ended
+func (r *AccountRepository) FindDueDeletions(ctx context.Context, limit int) ([]*model.AccountDeletion, error) {
+	query := `
+		SELECT id, deletion_mode, deletion_requested_at, deletion_scheduled_for
+		FROM users
+		WHERE deletion_scheduled_for IS NOT NULL
+			AND deletion_scheduled_for <= CURRENT_TIMESTAMP
+			AND anonymized_at IS NULL
+		ORDER BY deletion_scheduled_for
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch due deletions: %w", err)
+	}
+	defer rows.Close()
+
+	var deletions []*model.AccountDeletion
+	for rows.Next() {
+		var deletion model.AccountDeletion
+		err := rows.Scan(
+			&deletion.UserID,
+			&deletion.Mode,
+			&deletion.RequestedAt,
+			&deletion.ScheduledFor,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan deletion: %w", err)
+		}
+		deletions = append(deletions, &deletion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deletions: %w", err)
+	}
+
+	return deletions, nil
+}
+
+// The account comments are handed to when their author is deleted but other people replied
+// to them. It cannot sign in: it is inactive and its password is not a bcrypt hash. Should a
+// real user already hold the username, the placeholder gets a suffixed one instead.
+const (
+	deletedUserName     = "Deleted User"
+	deletedUserUsername = "deleted-user"
+	deletedUserEmail    = "deleted-user@deleted.invalid"
+)
+
+// HardDelete - Remove the user row; posts, comments, likes, sessions and identities cascade.
+// Comments others replied to would take the replies with them, so those are first blanked
+// and handed to the shared Deleted User account, like Anonymize keeps them in place.
+func (r *AccountRepository) HardDelete(ctx context.Context, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin deletion: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var placeholderID string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO users (name, username, email, password, is_active)
+		SELECT $1,
+			CASE WHEN EXISTS (SELECT 1 FROM users WHERE username = $2 AND email <> $3)
+				THEN $2 || '-' || left(md5(random()::text), 8)
+				ELSE $2 END,
+			$3, '!', false
+		ON CONFLICT (email) DO UPDATE SET email = EXCLUDED.email
+		RETURNING id::text
+	`, deletedUserName, deletedUserUsername, deletedUserEmail).Scan(&placeholderID)
+	if err != nil {
+		return fmt.Errorf("failed to find the deleted user account: %w", err)
+	}
+
+	// Every ancestor of a comment written by someone else keeps its place in the thread
+	result, err := tx.Exec(ctx, `
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_id AS id FROM comments
+			WHERE author_id <> $1 AND parent_id IS NOT NULL
+			UNION
+			SELECT c.parent_id FROM comments c
+			JOIN ancestors a ON c.id = a.id
+			WHERE c.parent_id IS NOT NULL
+		)
+		UPDATE comments
+		SET author_id = $2, text = '[deleted]'
+		WHERE author_id = $1 AND id IN (SELECT id FROM ancestors)
+	`, userID, placeholderID)
+	if err != nil {
+		return fmt.Errorf("failed to keep replied-to comments: %w", err)
+	}
+	kept := result.RowsAffected()
+
+	result, err = tx.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("user not found")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit deletion: %w", err)
+	}
+
+	logger.Infof(ctx, "[ACCOUNT-REPO] User %s hard-deleted (%d replied-to comment(s) kept)", userID, kept)
+	return nil
+}
+
+// Anonymize - Strip personal data but keep posts and comments so threads stay intact
+func (r *AccountRepository) Anonymize(ctx context.Context, userID, username, email, passwordHash string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin anonymization: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE users
+		SET name = 'Deleted User', username = $2, email = $3, password = $4,
+			gender = NULL, profile_url = NULL, bio = NULL, last_login = NULL,
+			is_active = false, is_verified = false,
+			deletion_scheduled_for = NULL, anonymized_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, userID, username, email, passwordHash)
+	if err != nil {
+		return fmt.Errorf("failed to anonymize user: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("user not found")
+	}
+
+	cleanup := []string{
+		`DELETE FROM likes WHERE user_id = $1`,
+		`DELETE FROM user_identities WHERE user_id = $1`,
+		`UPDATE user_sessions SET revoked_at = CURRENT_TIMESTAMP, user_agent = NULL, ip_address = NULL WHERE user_id = $1`,
+	}
+	for _, statement := range cleanup {
+		if _, err := tx.Exec(ctx, statement, userID); err != nil {
+			return fmt.Errorf("failed to anonymize user data: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit anonymization: %w", err)
+	}
+
+	logger.Infof(ctx, "[ACCOUNT-REPO] User %s anonymized", userID)
+	return nil
+}
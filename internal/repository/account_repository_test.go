@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// insertComment - A comment on postID, a reply when parentID is set; returns its ID
+func insertComment(t *testing.T, db *pgxpool.Pool, postID, authorID, parentID, text string) string {
+	t.Helper()
+
+	var id string
+	err := db.QueryRow(context.Background(), `
+		INSERT INTO comments (post_id, author_id, parent_id, text)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4)
+		RETURNING id::text
+	`, postID, authorID, parentID, text).Scan(&id)
+	if err != nil {
+		t.Fatalf("insert comment: %v", err)
+	}
+	return id
+}
+
+type storedComment struct {
+	author string
+	parent *string
+	text   string
+}
+
+// findComment - nil when the comment is gone
+func findComment(t *testing.T, db *pgxpool.Pool, commentID string) *storedComment {
+	t.Helper()
+
+	var c storedComment
+	err := db.QueryRow(context.Background(), `
+		SELECT author_id::text, parent_id::text, text FROM comments WHERE id = $1
+	`, commentID).Scan(&c.author, &c.parent, &c.text)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("find comment: %v", err)
+	}
+	return &c
+}
+
+func TestAccountRepositoryHardDeleteKeepsThreads(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo := NewAccountRepository(db)
+
+	leaving := insertUser(t, db, "leaving")
+	staying := insertUser(t, db, "staying")
+	post := insertPost(t, db, staying, "Thread", "Body")
+	ownPost := insertPost(t, db, leaving, "Own post", "Body")
+
+	answered := insertComment(t, db, post, leaving, "", "A question")
+	reply := insertComment(t, db, post, staying, answered, "An answer")
+	unanswered := insertComment(t, db, post, leaving, "", "Nobody replied")
+
+	// leaving's own reply sits between two of staying's comments
+	question := insertComment(t, db, post, staying, "", "Another question")
+	middle := insertComment(t, db, post, leaving, question, "A follow-up")
+	deep := insertComment(t, db, post, staying, middle, "A deeper answer")
+
+	onOwnPost := insertComment(t, db, ownPost, staying, "", "Nice post")
+
+	if err := repo.HardDelete(ctx, leaving); err != nil {
+		t.Fatalf("HardDelete: %v", err)
+	}
+
+	var placeholder string
+	if err := db.QueryRow(ctx, `SELECT id::text FROM users WHERE email = $1`, deletedUserEmail).Scan(&placeholder); err != nil {
+		t.Fatalf("deleted user account: %v", err)
+	}
+
+	for _, id := range []string{answered, middle} {
+		c := findComment(t, db, id)
+		if c == nil {
+			t.Errorf("replied-to comment %s was deleted", id)
+			continue
+		}
+		if c.author != placeholder || c.text != "[deleted]" {
+			t.Errorf("replied-to comment %s = %+v, want blanked and owned by the deleted user account", id, c)
+		}
+	}
+	for _, id := range []string{reply, question, deep} {
+		if findComment(t, db, id) == nil {
+			t.Errorf("someone else's comment %s was deleted", id)
+		}
+	}
+	if c := findComment(t, db, reply); c != nil && (c.parent == nil || *c.parent != answered) {
+		t.Errorf("reply lost its parent: %+v", c)
+	}
+	if findComment(t, db, unanswered) != nil {
+		t.Error("a comment nobody replied to survived the deletion")
+	}
+	if findComment(t, db, onOwnPost) != nil {
+		t.Error("a comment on the deleted user's post survived the post")
+	}
+
+	// A second deletion reuses the same placeholder account
+	other := insertUser(t, db, "other")
+	kept := insertComment(t, db, post, other, "", "Also leaving")
+	insertComment(t, db, post, staying, kept, "Bye")
+	if err := repo.HardDelete(ctx, other); err != nil {
+		t.Fatalf("second HardDelete: %v", err)
+	}
+	if c := findComment(t, db, kept); c == nil || c.author != placeholder {
+		t.Errorf("second deletion did not reuse the deleted user account: %+v", c)
+	}
+
+	if err := repo.HardDelete(ctx, leaving); err == nil {
+		t.Error("deleting a missing user succeeded")
+	}
+}
+
+func TestAccountRepositoryHardDeleteWithTakenUsername(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	squatter := insertUser(t, db, deletedUserUsername)
+	leaving := insertUser(t, db, "leaving")
+	post := insertPost(t, db, squatter, "Thread", "Body")
+	kept := insertComment(t, db, post, leaving, "", "A question")
+	insertComment(t, db, post, squatter, kept, "An answer")
+
+	if err := NewAccountRepository(db).HardDelete(ctx, leaving); err != nil {
+		t.Fatalf("HardDelete: %v", err)
+	}
+	if c := findComment(t, db, kept); c == nil || c.author == squatter {
+		t.Errorf("replied-to comment went to the user who owns the username: %+v", c)
+	}
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AIUsageRepository - Per-call AI usage and the aggregates built from it
+type AIUsageRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAIUsageRepository(db *pgxpool.Pool) *AIUsageRepository {
+	return &AIUsageRepository{db: db}
+}
+
+// Record - Store one LLM call
+func (r *AIUsageRepository) Record(ctx context.Context, event *model.AIUsageEvent) error {
+	query := `
+		INSERT INTO ai_usage_events
+			(feature, user_id, provider, model, input_tokens, output_tokens, estimated_tokens,
+			 cost_usd, success, latency_ms, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		event.Feature,
+		event.UserID,
+		event.Provider,
+		event.Model,
+		event.InputTokens,
+		event.OutputTokens,
+		event.EstimatedTokens,
+		event.CostUSD,
+		event.Success,
+		event.LatencyMs,
+		event.CreatedAt,
+	).Scan(&event.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to record AI usage: %w", err)
+	}
+
+	return nil
+}
+
+// SpendByFeature - Cost per feature since a point in time (UTC)
+func (r *AIUsageRepository) SpendByFeature(ctx context.Context, since time.Time) (map[string]float64, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT feature, COALESCE(SUM(cost_usd), 0)::float8
+		FROM ai_usage_events
+		WHERE created_at >= $1
+		GROUP BY feature
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum AI spend: %w", err)
+	}
+	defer rows.Close()
+
+	spend := make(map[string]float64)
+	for rows.Next() {
+		var (
+			feature string
+			cost    float64
+		)
+		if err := rows.Scan(&feature, &cost); err != nil {
+			return nil, fmt.Errorf("failed to scan AI spend: %w", err)
+		}
+		spend[feature] = cost
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating AI spend: %w", err)
+	}
+
+	return spend, nil
+}
+
+// usageGroups - SQL expression to group by, for each breakdown
+var usageGroups = map[string]string{
+	"day":     `to_char(created_at, 'YYYY-MM-DD')`,
+	"feature": `feature`,
+	"model":   `model`,
+	"user":    `COALESCE(user_id::text, 'system')`,
+}
+
+// Totals - Usage in [from, to) grouped by day, feature, model or user ("" for one overall row)
+func (r *AIUsageRepository) Totals(ctx context.Context, groupBy string, from, to time.Time, limit int) ([]model.AIUsageTotals, error) {
+	key := `'total'`
+	order := `1`
+	if groupBy != "" {
+		expr, ok := usageGroups[groupBy]
+		if !ok {
+			return nil, fmt.Errorf("unknown usage grouping %q", groupBy)
+		}
+		key = expr
+		if groupBy != "day" {
+			order = `6 DESC, 2 DESC`
+		}
+	}
+	if limit < 1 {
+		limit = 1000
+	}
+
+	query := `
+		SELECT ` + key + ` AS key,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE NOT success),
+			COALESCE(SUM(input_tokens), 0),
+			COALESCE(SUM(output_tokens), 0),
+			COALESCE(SUM(cost_usd), 0)::float8
+		FROM ai_usage_events
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY 1
+		ORDER BY ` + order + `
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate AI usage: %w", err)
+	}
+	defer rows.Close()
+
+	totals := []model.AIUsageTotals{}
+	for rows.Next() {
+		var t model.AIUsageTotals
+		if err := rows.Scan(&t.Key, &t.Requests, &t.Failures, &t.InputTokens, &t.OutputTokens, &t.CostUSD); err != nil {
+			return nil, fmt.Errorf("failed to scan AI usage: %w", err)
+		}
+		totals = append(totals, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating AI usage: %w", err)
+	}
+
+	return totals, nil
+}
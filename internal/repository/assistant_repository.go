@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AssistantRepository - Writing assistant quotas and the taxonomy it suggests from
+type AssistantRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAssistantRepository(db *pgxpool.Pool) *AssistantRepository {
+	return &AssistantRepository{db: db}
+}
+
+// ReserveRequest - Count one request against the user's day; false when the limit is reached (limit <= 0 is unlimited)
+func (r *AssistantRepository) ReserveRequest(ctx context.Context, userID string, day time.Time, limit int) (bool, error) {
+	query := `
+		INSERT INTO ai_assistant_usage (user_id, day, requests)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (user_id, day) DO UPDATE
+		SET requests = ai_assistant_usage.requests + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE $3 <= 0 OR ai_assistant_usage.requests < $3
+		RETURNING requests
+	`
+
+	var requests int
+	err := r.db.QueryRow(ctx, query, userID, day, limit).Scan(&requests)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to reserve assistant request: %w", err)
+	}
+
+	return true, nil
+}
+
+// ReleaseRequest - Give a reserved request back after a failed call
+func (r *AssistantRepository) ReleaseRequest(ctx context.Context, userID string, day time.Time) error {
+	query := `
+		UPDATE ai_assistant_usage
+		SET requests = GREATEST(requests - 1, 0), updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND day = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, day); err != nil {
+		return fmt.Errorf("failed to release assistant request: %w", err)
+	}
+
+	return nil
+}
+
+// AddTokens - Record token usage reported by the provider
+func (r *AssistantRepository) AddTokens(ctx context.Context, userID string, day time.Time, inputTokens, outputTokens int) error {
+	query := `
+		UPDATE ai_assistant_usage
+		SET input_tokens = input_tokens + $3, output_tokens = output_tokens + $4, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND day = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, day, inputTokens, outputTokens); err != nil {
+		return fmt.Errorf("failed to record assistant tokens: %w", err)
+	}
+
+	return nil
+}
+
+// GetRequests - Requests the user made on a day
+func (r *AssistantRepository) GetRequests(ctx context.Context, userID string, day time.Time) (int, error) {
+	var requests int
+	err := r.db.QueryRow(ctx, `SELECT requests FROM ai_assistant_usage WHERE user_id = $1 AND day = $2`, userID, day).Scan(&requests)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get assistant usage: %w", err)
+	}
+
+	return requests, nil
+}
+
+// ListPopularTags - Most used tags on public posts
+func (r *AssistantRepository) ListPopularTags(ctx context.Context, limit int) ([]string, error) {
+	query := `
+		SELECT tag
+		FROM (
+			SELECT LOWER(unnest(tags)) AS tag
+			FROM posts
+			WHERE review_status IS NULL OR review_status = 'approved'
+		) t
+		WHERE tag <> ''
+		GROUP BY tag
+		ORDER BY COUNT(*) DESC, tag
+		LIMIT $1
+	`
+
+	return r.listStrings(ctx, query, limit)
+}
+
+// ListCategories - Enabled AI categories plus every category already used by a post
+func (r *AssistantRepository) ListCategories(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT name FROM ai_categories WHERE enabled
+		UNION
+		SELECT DISTINCT category FROM posts WHERE category IS NOT NULL AND category <> ''
+		ORDER BY 1
+	`
+
+	return r.listStrings(ctx, query)
+}
+
+func (r *AssistantRepository) listStrings(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch taxonomy: %w", err)
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to scan taxonomy: %w", err)
+		}
+		values = append(values, value)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating taxonomy: %w", err)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Create - Append an audit entry (the table rejects updates and deletes)
+func (r *AuditRepository) Create(ctx context.Context, entry *model.AuditLog) error {
+	query := `
+		INSERT INTO audit_logs (actor_id, actor_role, action, target_type, target_id,
+			ip_address, request_id, before_state, after_state)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		entry.ActorID,
+		entry.ActorRole,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		entry.IPAddress,
+		entry.RequestID,
+		nullableJSON(entry.BeforeState),
+		nullableJSON(entry.AfterState),
+	).Scan(&entry.ID, &entry.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	return nil
+}
+
+// List - Query audit entries, newest first
+func (r *AuditRepository) List(ctx context.Context, filter model.AuditLogFilter) ([]*model.AuditLog, error) {
+	where, args := auditWhere(filter)
+
+	query := `
+		SELECT id, actor_id, actor_role, action, target_type, target_id,
+			ip_address, request_id, before_state, after_state, created_at
+		FROM audit_logs` + where + `
+		ORDER BY created_at DESC`
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit logs: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*model.AuditLog
+	for rows.Next() {
+		var entry model.AuditLog
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ActorID,
+			&entry.ActorRole,
+			&entry.Action,
+			&entry.TargetType,
+			&entry.TargetID,
+			&entry.IPAddress,
+			&entry.RequestID,
+			&entry.BeforeState,
+			&entry.AfterState,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit log: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating audit logs: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Count - Number of audit entries matching the filter
+func (r *AuditRepository) Count(ctx context.Context, filter model.AuditLogFilter) (int64, error) {
+	where, args := auditWhere(filter)
+
+	var count int64
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM audit_logs"+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	return count, nil
+}
+
+// auditWhere - Build the WHERE clause shared by List and Count
+func auditWhere(filter model.AuditLogFilter) (string, []any) {
+	var conditions []string
+	var args []any
+
+	if filter.ActorID != "" {
+		args = append(args, filter.ActorID)
+		conditions = append(conditions, fmt.Sprintf("actor_id = $%d", len(args)))
+	}
+	if filter.Action != "" {
+		args = append(args, filter.Action)
+		conditions = append(conditions, fmt.Sprintf("action = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// nullableJSON - Store empty snapshots as SQL NULL
+func nullableJSON(data []byte) any {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
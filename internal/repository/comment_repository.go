@@ -4,9 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"time"
 
 	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -19,15 +20,45 @@ func NewCommentRepository(db *pgxpool.Pool) *CommentRepository {
 	return &CommentRepository{db: db}
 }
 
+const commentColumns = `id, text, post_id, author_id, parent_id, is_ai_reply, moderation_status, toxicity_score, spam_score,
+	moderation_reasons, moderation_source, moderation_note, moderated_by, moderated_at, created_at, updated_at`
+
+func scanComment(row pgx.Row) (*model.Comment, error) {
+	var comment model.Comment
+	err := row.Scan(
+		&comment.ID,
+		&comment.Text,
+		&comment.PostID,
+		&comment.AuthorID,
+		&comment.ParentID,
+		&comment.IsAIReply,
+		&comment.ModerationStatus,
+		&comment.ToxicityScore,
+		&comment.SpamScore,
+		&comment.ModerationReasons,
+		&comment.ModerationSource,
+		&comment.ModerationNote,
+		&comment.ModeratedBy,
+		&comment.ModeratedAt,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
 // Create - Create a new comment
 func (r *CommentRepository) Create(ctx context.Context, comment *model.Comment) error {
 	query := `
-		INSERT INTO comments (text, post_id, author_id)
-		VALUES ($1, $2, $3)
+		INSERT INTO comments (text, post_id, author_id, moderation_status, toxicity_score,
+			spam_score, moderation_reasons, moderation_source, parent_id, is_ai_reply)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id, created_at, updated_at
 	`
 
-	log.Printf("[COMMENT-REPO] Creating comment for post: %s", comment.PostID.String())
+	logger.Infof(ctx, "[COMMENT-REPO] Creating comment for post: %s", comment.PostID.String())
 
 	err := r.db.QueryRow(
 		ctx,
@@ -35,6 +66,13 @@ func (r *CommentRepository) Create(ctx context.Context, comment *model.Comment)
 		comment.Text,
 		comment.PostID,
 		comment.AuthorID,
+		comment.ModerationStatus,
+		comment.ToxicityScore,
+		comment.SpamScore,
+		comment.ModerationReasons,
+		comment.ModerationSource,
+		comment.ParentID,
+		comment.IsAIReply,
 	).Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
 
 	if err != nil {
@@ -47,9 +85,9 @@ func (r *CommentRepository) Create(ctx context.Context, comment *model.Comment)
 // GetAllComments - Get all comments (optionally by post_id)
 func (r *CommentRepository) GetAllComments(ctx context.Context, postID string) ([]*model.Comment, error) {
 	query := `
-		SELECT id, text, post_id, author_id, created_at, updated_at
+		SELECT ` + commentColumns + `
 		FROM comments
-		WHERE post_id = $1
+		WHERE post_id = $1 AND moderation_status = 'approved'
 		ORDER BY created_at DESC
 	`
 
@@ -62,21 +100,12 @@ func (r *CommentRepository) GetAllComments(ctx context.Context, postID string) (
 	var comments []*model.Comment
 
 	for rows.Next() {
-		var comment model.Comment
-
-		err := rows.Scan(
-			&comment.ID,
-			&comment.Text,
-			&comment.PostID,
-			&comment.AuthorID,
-			&comment.CreatedAt,
-			&comment.UpdatedAt,
-		)
+		comment, err := scanComment(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan comment: %w", err)
 		}
 
-		comments = append(comments, &comment)
+		comments = append(comments, comment)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -90,21 +119,12 @@ func (r *CommentRepository) GetAllComments(ctx context.Context, postID string) (
 // FindByID - Get a single comment by ID
 func (r *CommentRepository) FindByID(ctx context.Context, commentID string) (*model.Comment, error) {
 	query := `
-		SELECT id, text, post_id, author_id, created_at, updated_at
+		SELECT ` + commentColumns + `
 		FROM comments 
 		WHERE id = $1
 	`
 
-	var comment model.Comment
-	err := r.db.QueryRow(ctx, query, commentID).Scan(
-		&comment.ID,
-		&comment.Text,
-		&comment.PostID,
-		&comment.AuthorID,
-		&comment.CreatedAt,
-		&comment.UpdatedAt,
-	)
-
+	comment, err := scanComment(r.db.QueryRow(ctx, query, commentID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -112,15 +132,15 @@ func (r *CommentRepository) FindByID(ctx context.Context, commentID string) (*mo
 		return nil, fmt.Errorf("failed to find comment: %w", err)
 	}
 
-	return &comment, nil
+	return comment, nil
 }
 
 // GetCommentsByPostID - Get all comments for a specific post
 func (r *CommentRepository) GetCommentsByPostID(ctx context.Context, postID string) ([]*model.Comment, error) {
 	query := `
-		SELECT id, text, post_id, author_id, created_at, updated_at
+		SELECT ` + commentColumns + `
 		FROM comments 
-		WHERE post_id = $1
+		WHERE post_id = $1 AND moderation_status = 'approved'
 		ORDER BY created_at ASC
 	`
 
@@ -132,20 +152,12 @@ func (r *CommentRepository) GetCommentsByPostID(ctx context.Context, postID stri
 
 	var comments []*model.Comment
 	for rows.Next() {
-		var comment model.Comment
-		err := rows.Scan(
-			&comment.ID,
-			&comment.Text,
-			&comment.PostID,
-			&comment.AuthorID,
-			&comment.CreatedAt,
-			&comment.UpdatedAt,
-		)
+		comment, err := scanComment(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan comment: %w", err)
 		}
 
-		comments = append(comments, &comment)
+		comments = append(comments, comment)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -194,13 +206,13 @@ func (r *CommentRepository) Delete(ctx context.Context, commentID string) error
 		return errors.New("comment not found")
 	}
 
-	log.Printf("[COMMENT-REPO] Comment deleted successfully: %s", commentID)
+	logger.Infof(ctx, "[COMMENT-REPO] Comment deleted successfully: %s", commentID)
 	return nil
 }
 
 // CountCommentsByPostID - Count total comments for a post
 func (r *CommentRepository) CountCommentsByPostID(ctx context.Context, postID string) (int64, error) {
-	query := `SELECT COUNT(*) FROM comments WHERE post_id = $1`
+	query := `SELECT COUNT(*) FROM comments WHERE post_id = $1 AND moderation_status = 'approved'`
 
 	var count int64
 	err := r.db.QueryRow(ctx, query, postID).Scan(&count)
@@ -219,7 +231,7 @@ func (r *CommentRepository) GetCommentsByPostIDWithAuthor(ctx context.Context, p
 			u.name as author_name, u.username as author_username
 		FROM comments c
 		INNER JOIN users u ON c.author_id = u.id
-		WHERE c.post_id = $1
+		WHERE c.post_id = $1 AND c.moderation_status = 'approved'
 		ORDER BY c.created_at ASC
 	`
 
@@ -250,4 +262,97 @@ func (r *CommentRepository) GetCommentsByPostIDWithAuthor(ctx context.Context, p
 	}
 
 	return comments, nil
-}
\ No newline at end of file
+}
+
+// ListForModeration - Comments in a moderation state, oldest first
+func (r *CommentRepository) ListForModeration(ctx context.Context, status string, limit, offset int) ([]*model.Comment, error) {
+	query := `
+		SELECT ` + commentColumns + `
+		FROM comments
+		WHERE moderation_status = $1
+		ORDER BY created_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch moderation queue: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []*model.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating moderation queue: %w", err)
+	}
+
+	return comments, nil
+}
+
+// CountForModeration - Number of comments in a moderation state
+func (r *CommentRepository) CountForModeration(ctx context.Context, status string) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM comments WHERE moderation_status = $1`, status).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count moderation queue: %w", err)
+	}
+	return count, nil
+}
+
+// UpdateModeration - Save a moderator's decision
+func (r *CommentRepository) UpdateModeration(ctx context.Context, comment *model.Comment) error {
+	query := `
+		UPDATE comments
+		SET moderation_status = $1, moderation_note = $2, moderated_by = $3, moderated_at = $4
+		WHERE id = $5
+	`
+
+	result, err := r.db.Exec(
+		ctx,
+		query,
+		comment.ModerationStatus,
+		comment.ModerationNote,
+		comment.ModeratedBy,
+		comment.ModeratedAt,
+		comment.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update comment moderation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("comment not found")
+	}
+
+	return nil
+}
+
+// CountAIRepliesSince - AI replies on a post, in any moderation state, created after a time
+func (r *CommentRepository) CountAIRepliesSince(ctx context.Context, postID string, since time.Time) (int64, error) {
+	query := `SELECT COUNT(*) FROM comments WHERE post_id = $1 AND is_ai_reply AND created_at >= $2`
+
+	var count int64
+	if err := r.db.QueryRow(ctx, query, postID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count AI replies: %w", err)
+	}
+
+	return count, nil
+}
+
+// HasAIReply - Whether a comment already has an AI reply
+func (r *CommentRepository) HasAIReply(ctx context.Context, commentID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM comments WHERE parent_id = $1 AND is_ai_reply)`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, commentID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check AI reply: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// GenerationJobRepository - History of AI post generations
+type GenerationJobRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewGenerationJobRepository(db *pgxpool.Pool) *GenerationJobRepository {
+	return &GenerationJobRepository{db: db}
+}
+
+const generationJobColumns = `id, trigger, status, topic_id, persona_id, topic, category, provider, model, prompt,
+	attempts, raw_response, error, latency_ms, post_id, created_at, finished_at`
+
+func scanGenerationJob(row pgx.Row) (*model.GenerationJob, error) {
+	var job model.GenerationJob
+	err := row.Scan(
+		&job.ID,
+		&job.Trigger,
+		&job.Status,
+		&job.TopicID,
+		&job.PersonaID,
+		&job.Topic,
+		&job.Category,
+		&job.Provider,
+		&job.Model,
+		&job.Prompt,
+		&job.Attempts,
+		&job.RawResponse,
+		&job.Error,
+		&job.LatencyMs,
+		&job.PostID,
+		&job.CreatedAt,
+		&job.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Create - Open a job in the running state
+func (r *GenerationJobRepository) Create(ctx context.Context, job *model.GenerationJob) error {
+	query := `
+		INSERT INTO ai_generation_jobs (trigger, topic_id, persona_id, topic, category, provider, model, prompt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, status, created_at
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		job.Trigger,
+		job.TopicID,
+		job.PersonaID,
+		job.Topic,
+		job.Category,
+		job.Provider,
+		job.Model,
+		job.Prompt,
+	).Scan(&job.ID, &job.Status, &job.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create generation job: %w", err)
+	}
+
+	return nil
+}
+
+// AddAttempt - Record one LLM call and bump the job's attempt counter
+func (r *GenerationJobRepository) AddAttempt(ctx context.Context, attempt *model.GenerationAttempt) error {
+	query := `
+		WITH inserted AS (
+			INSERT INTO ai_generation_attempts
+				(job_id, attempt, purpose, model, raw_response, error, error_kind, latency_ms, input_tokens, output_tokens)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING id, created_at
+		), bumped AS (
+			UPDATE ai_generation_jobs SET attempts = $2 WHERE id = $1
+		)
+		SELECT id, created_at FROM inserted
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		attempt.JobID,
+		attempt.Attempt,
+		attempt.Purpose,
+		attempt.Model,
+		attempt.RawResponse,
+		attempt.Error,
+		attempt.ErrorKind,
+		attempt.LatencyMs,
+		attempt.InputTokens,
+		attempt.OutputTokens,
+	).Scan(&attempt.ID, &attempt.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to record generation attempt: %w", err)
+	}
+
+	return nil
+}
+
+// Finish - Close a job with its final status
+func (r *GenerationJobRepository) Finish(ctx context.Context, job *model.GenerationJob) error {
+	query := `
+		UPDATE ai_generation_jobs
+		SET status = $2, model = $3, raw_response = $4, error = $5, latency_ms = $6,
+			post_id = $7, finished_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING finished_at
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		job.ID,
+		job.Status,
+		job.Model,
+		job.RawResponse,
+		job.Error,
+		job.LatencyMs,
+		job.PostID,
+	).Scan(&job.FinishedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to finish generation job: %w", err)
+	}
+
+	return nil
+}
+
+// FailStale - Mark jobs left running by a previous process as failed
+func (r *GenerationJobRepository) FailStale(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE ai_generation_jobs
+		SET status = 'failed', error = 'interrupted by server restart', finished_at = CURRENT_TIMESTAMP
+		WHERE status = 'running'
+	`
+
+	result, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to close stale generation jobs: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// FindByID - Get a job by ID
+func (r *GenerationJobRepository) FindByID(ctx context.Context, jobID string) (*model.GenerationJob, error) {
+	query := `SELECT ` + generationJobColumns + ` FROM ai_generation_jobs WHERE id = $1`
+
+	job, err := scanGenerationJob(r.db.QueryRow(ctx, query, jobID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find generation job: %w", err)
+	}
+
+	return job, nil
+}
+
+// List - Most recent jobs first
+func (r *GenerationJobRepository) List(ctx context.Context, filter model.GenerationJobFilter) ([]*model.GenerationJob, error) {
+	query := `
+		SELECT ` + generationJobColumns + `
+		FROM ai_generation_jobs
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.Status, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch generation jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*model.GenerationJob
+	for rows.Next() {
+		job, err := scanGenerationJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan generation job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating generation jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// Count - Number of jobs matching the filter
+func (r *GenerationJobRepository) Count(ctx context.Context, filter model.GenerationJobFilter) (int64, error) {
+	var total int64
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM ai_generation_jobs WHERE ($1 = '' OR status = $1)`, filter.Status).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count generation jobs: %w", err)
+	}
+	return total, nil
+}
+
+// ListAttempts - Every attempt of a job in order
+func (r *GenerationJobRepository) ListAttempts(ctx context.Context, jobID string) ([]*model.GenerationAttempt, error) {
+	query := `
+		SELECT id, job_id, attempt, purpose, model, raw_response, error, error_kind, latency_ms,
+			input_tokens, output_tokens, created_at
+		FROM ai_generation_attempts
+		WHERE job_id = $1
+		ORDER BY attempt
+	`
+
+	rows, err := r.db.Query(ctx, query, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch generation attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*model.GenerationAttempt
+	for rows.Next() {
+		var attempt model.GenerationAttempt
+		err := rows.Scan(
+			&attempt.ID,
+			&attempt.JobID,
+			&attempt.Attempt,
+			&attempt.Purpose,
+			&attempt.Model,
+			&attempt.RawResponse,
+			&attempt.Error,
+			&attempt.ErrorKind,
+			&attempt.LatencyMs,
+			&attempt.InputTokens,
+			&attempt.OutputTokens,
+			&attempt.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan generation attempt: %w", err)
+		}
+		attempts = append(attempts, &attempt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating generation attempts: %w", err)
+	}
+
+	return attempts, nil
+}
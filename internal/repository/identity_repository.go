@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IdentityRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewIdentityRepository(db *pgxpool.Pool) *IdentityRepository {
+	return &IdentityRepository{db: db}
+}
+
+// Create - Link a new external identity to a user
+func (r *IdentityRepository) Create(ctx context.Context, identity *model.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (user_id, provider, subject, email, last_login_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+		RETURNING id, last_login_at, created_at
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+	).Scan(&identity.ID, &identity.LastLoginAt, &identity.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	logger.Infof(ctx, "[IDENTITY-REPO] Linked %s identity to user: %s", identity.Provider, identity.UserID.String())
+	return nil
+}
+
+// FindByProviderSubject - Get an identity by provider and subject claim
+func (r *IdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*model.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, last_login_at, created_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+
+	var identity model.UserIdentity
+	err := r.db.QueryRow(ctx, query, provider, subject).Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.LastLoginAt,
+		&identity.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find identity: %w", err)
+	}
+
+	return &identity, nil
+}
+
+// FindByUserID - Get all identities linked to a user
+func (r *IdentityRepository) FindByUserID(ctx context.Context, userID string) ([]*model.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, last_login_at, created_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch identities: %w", err)
+	}
+	defer rows.Close()
+
+	var identities []*model.UserIdentity
+	for rows.Next() {
+		var identity model.UserIdentity
+		err := rows.Scan(
+			&identity.ID,
+			&identity.UserID,
+			&identity.Provider,
+			&identity.Subject,
+			&identity.Email,
+			&identity.LastLoginAt,
+			&identity.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan identity: %w", err)
+		}
+		identities = append(identities, &identity)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+// TouchLogin - Record a successful login through an identity
+func (r *IdentityRepository) TouchLogin(ctx context.Context, identityID string, email *string) error {
+	query := `
+		UPDATE user_identities
+		SET last_login_at = CURRENT_TIMESTAMP, email = COALESCE($2, email)
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, identityID, email); err != nil {
+		return fmt.Errorf("failed to update identity login: %w", err)
+	}
+
+	return nil
+}
+
+// Delete - Unlink an identity owned by the given user
+func (r *IdentityRepository) Delete(ctx context.Context, identityID, userID string) error {
+	query := `DELETE FROM user_identities WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(ctx, query, identityID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to unlink identity: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("identity not found")
+	}
+
+	return nil
+}
+
+// SaveState - Persist a pending authorization request
+func (r *IdentityRepository) SaveState(ctx context.Context, state *model.OAuthState) error {
+	query := `
+		INSERT INTO oauth_states (state, provider, nonce, code_verifier, link_user_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		state.State,
+		state.Provider,
+		state.Nonce,
+		state.CodeVerifier,
+		state.LinkUserID,
+		state.ExpiresAt,
+	).Scan(&state.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to save oauth state: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeState - Fetch and delete a pending authorization request (single use)
+func (r *IdentityRepository) ConsumeState(ctx context.Context, state string) (*model.OAuthState, error) {
+	query := `
+		DELETE FROM oauth_states
+		WHERE state = $1
+		RETURNING state, provider, nonce, code_verifier, link_user_id, expires_at, created_at
+	`
+
+	var s model.OAuthState
+	err := r.db.QueryRow(ctx, query, state).Scan(
+		&s.State,
+		&s.Provider,
+		&s.Nonce,
+		&s.CodeVerifier,
+		&s.LinkUserID,
+		&s.ExpiresAt,
+		&s.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to consume oauth state: %w", err)
+	}
+
+	return &s, nil
+}
+
+// DeleteExpiredStates - Remove abandoned authorization requests
+func (r *IdentityRepository) DeleteExpiredStates(ctx context.Context) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM oauth_states WHERE expires_at < CURRENT_TIMESTAMP`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired oauth states: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type InviteRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewInviteRepository(db *pgxpool.Pool) *InviteRepository {
+	return &InviteRepository{db: db}
+}
+
+const inviteColumns = `id, code, email, role, created_by, used_by, used_at, expires_at, created_at`
+
+func scanInvite(row pgx.Row) (*model.InviteCode, error) {
+	var invite model.InviteCode
+	err := row.Scan(
+		&invite.ID,
+		&invite.Code,
+		&invite.Email,
+		&invite.Role,
+		&invite.CreatedBy,
+		&invite.UsedBy,
+		&invite.UsedAt,
+		&invite.ExpiresAt,
+		&invite.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// Create - Store a new invite code
+func (r *InviteRepository) Create(ctx context.Context, invite *model.InviteCode) error {
+	query := `
+		INSERT INTO invite_codes (code, email, role, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		invite.Code,
+		invite.Email,
+		invite.Role,
+		invite.CreatedBy,
+		invite.ExpiresAt,
+	).Scan(&invite.ID, &invite.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	return nil
+}
+
+// List - Most recent invites first
+func (r *InviteRepository) List(ctx context.Context, limit, offset int) ([]*model.InviteCode, error) {
+	query := `SELECT ` + inviteColumns + ` FROM invite_codes ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch invites: %w", err)
+	}
+	defer rows.Close()
+
+	var invites []*model.InviteCode
+	for rows.Next() {
+		invite, err := scanInvite(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invite: %w", err)
+		}
+		invites = append(invites, invite)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating invites: %w", err)
+	}
+
+	return invites, nil
+}
+
+// FindByID - Get an invite by ID
+func (r *InviteRepository) FindByID(ctx context.Context, inviteID string) (*model.InviteCode, error) {
+	query := `SELECT ` + inviteColumns + ` FROM invite_codes WHERE id = $1`
+
+	invite, err := scanInvite(r.db.QueryRow(ctx, query, inviteID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find invite: %w", err)
+	}
+
+	return invite, nil
+}
+
+// Claim - Atomically mark an unused, unexpired invite as used; returns nil if it cannot be claimed
+func (r *InviteRepository) Claim(ctx context.Context, code string) (*model.InviteCode, error) {
+	query := `
+		UPDATE invite_codes
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE code = $1
+			AND used_at IS NULL
+			AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+		RETURNING ` + inviteColumns
+
+	invite, err := scanInvite(r.db.QueryRow(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim invite: %w", err)
+	}
+
+	return invite, nil
+}
+
+// Release - Undo a claim when registration fails after claiming
+func (r *InviteRepository) Release(ctx context.Context, inviteID string) error {
+	query := `UPDATE invite_codes SET used_at = NULL, used_by = NULL WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, inviteID); err != nil {
+		return fmt.Errorf("failed to release invite: %w", err)
+	}
+
+	return nil
+}
+
+// MarkUsedBy - Record which user redeemed a claimed invite
+func (r *InviteRepository) MarkUsedBy(ctx context.Context, inviteID, userID string) error {
+	query := `UPDATE invite_codes SET used_by = $2 WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, inviteID, userID); err != nil {
+		return fmt.Errorf("failed to mark invite used: %w", err)
+	}
+
+	return nil
+}
+
+// Delete - Revoke an invite that has not been used yet
+func (r *InviteRepository) Delete(ctx context.Context, inviteID string) error {
+	query := `DELETE FROM invite_codes WHERE id = $1 AND used_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, inviteID)
+	if err != nil {
+		return fmt.Errorf("failed to delete invite: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("invite not found")
+	}
+
+	return nil
+}
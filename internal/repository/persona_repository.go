@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PersonaRepository - Bot personas for AI posting
+type PersonaRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPersonaRepository(db *pgxpool.Pool) *PersonaRepository {
+	return &PersonaRepository{db: db}
+}
+
+const personaSelect = `
+	SELECT p.id, p.name, p.description, p.user_id, u.username, p.prompt_template, p.tone, p.model,
+		p.temperature, p.max_tokens, p.min_words, p.max_words, p.schedule_interval, p.schedule_cron,
+		p.enabled, p.is_default, p.created_at, p.updated_at
+	FROM ai_personas p
+	JOIN users u ON u.id = p.user_id
+`
+
+func scanPersona(row pgx.Row) (*model.BotPersona, error) {
+	var persona model.BotPersona
+	err := row.Scan(
+		&persona.ID,
+		&persona.Name,
+		&persona.Description,
+		&persona.UserID,
+		&persona.Username,
+		&persona.PromptTemplate,
+		&persona.Tone,
+		&persona.Model,
+		&persona.Temperature,
+		&persona.MaxTokens,
+		&persona.MinWords,
+		&persona.MaxWords,
+		&persona.ScheduleInterval,
+		&persona.ScheduleCron,
+		&persona.Enabled,
+		&persona.IsDefault,
+		&persona.CreatedAt,
+		&persona.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &persona, nil
+}
+
+func (r *PersonaRepository) findOne(ctx context.Context, where string, args ...any) (*model.BotPersona, error) {
+	persona, err := scanPersona(r.db.QueryRow(ctx, personaSelect+where, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find persona: %w", err)
+	}
+	return persona, nil
+}
+
+// FindByID - One persona; nil if missing
+func (r *PersonaRepository) FindByID(ctx context.Context, personaID string) (*model.BotPersona, error) {
+	return r.findOne(ctx, `WHERE p.id = $1`, personaID)
+}
+
+// FindByName - Case-insensitive lookup; nil if missing
+func (r *PersonaRepository) FindByName(ctx context.Context, name string) (*model.BotPersona, error) {
+	return r.findOne(ctx, `WHERE LOWER(p.name) = LOWER($1)`, name)
+}
+
+// FindByUserID - The persona that writes as a user; nil if the user is not a persona
+func (r *PersonaRepository) FindByUserID(ctx context.Context, userID string) (*model.BotPersona, error) {
+	return r.findOne(ctx, `WHERE p.user_id = $1`, userID)
+}
+
+// FindDefault - The default persona; nil before it is created
+func (r *PersonaRepository) FindDefault(ctx context.Context) (*model.BotPersona, error) {
+	return r.findOne(ctx, `WHERE p.is_default`)
+}
+
+// List - All personas, default first; enabledOnly skips disabled ones
+func (r *PersonaRepository) List(ctx context.Context, enabledOnly bool) ([]*model.BotPersona, error) {
+	query := personaSelect
+	if enabledOnly {
+		query += ` WHERE p.enabled`
+	}
+	query += ` ORDER BY p.is_default DESC, p.name`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personas: %w", err)
+	}
+	defer rows.Close()
+
+	personas := []*model.BotPersona{}
+	for rows.Next() {
+		persona, err := scanPersona(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan persona: %w", err)
+		}
+		personas = append(personas, persona)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating personas: %w", err)
+	}
+
+	return personas, nil
+}
+
+// Create - Insert a persona for an existing user
+func (r *PersonaRepository) Create(ctx context.Context, persona *model.BotPersona) error {
+	query := `
+		INSERT INTO ai_personas
+			(name, description, user_id, prompt_template, tone, model, temperature, max_tokens,
+			 min_words, max_words, schedule_interval, schedule_cron, enabled, is_default)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		persona.Name,
+		persona.Description,
+		persona.UserID,
+		persona.PromptTemplate,
+		persona.Tone,
+		persona.Model,
+		persona.Temperature,
+		persona.MaxTokens,
+		persona.MinWords,
+		persona.MaxWords,
+		persona.ScheduleInterval,
+		persona.ScheduleCron,
+		persona.Enabled,
+		persona.IsDefault,
+	).Scan(&persona.ID, &persona.CreatedAt, &persona.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create persona: %w", err)
+	}
+
+	return nil
+}
+
+// Update - Save the editable persona fields
+func (r *PersonaRepository) Update(ctx context.Context, persona *model.BotPersona) error {
+	query := `
+		UPDATE ai_personas
+		SET name = $2, description = $3, prompt_template = $4, tone = $5, model = $6,
+			temperature = $7, max_tokens = $8, min_words = $9, max_words = $10,
+			schedule_interval = $11, schedule_cron = $12, enabled = $13, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		persona.ID,
+		persona.Name,
+		persona.Description,
+		persona.PromptTemplate,
+		persona.Tone,
+		persona.Model,
+		persona.Temperature,
+		persona.MaxTokens,
+		persona.MinWords,
+		persona.MaxWords,
+		persona.ScheduleInterval,
+		persona.ScheduleCron,
+		persona.Enabled,
+	).Scan(&persona.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to update persona: %w", err)
+	}
+
+	return nil
+}
+
+// Delete - Remove a persona; its user and posts stay
+func (r *PersonaRepository) Delete(ctx context.Context, personaID string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM ai_personas WHERE id = $1`, personaID)
+	if err != nil {
+		return fmt.Errorf("failed to delete persona: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("persona not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostImageRepository - Alt text and captions for the images in posts.image_url
+type PostImageRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostImageRepository(db *pgxpool.Pool) *PostImageRepository {
+	return &PostImageRepository{db: db}
+}
+
+const postImageColumns = `id, post_id, url, alt_text, caption, author_alt_text, author_caption,
+	status, error, attempts, model, described_at, created_at, updated_at`
+
+func scanPostImage(row pgx.Row) (*model.PostImage, error) {
+	var image model.PostImage
+	err := row.Scan(
+		&image.ID,
+		&image.PostID,
+		&image.URL,
+		&image.AltText,
+		&image.Caption,
+		&image.AuthorAltText,
+		&image.AuthorCaption,
+		&image.Status,
+		&image.Error,
+		&image.Attempts,
+		&image.Model,
+		&image.DescribedAt,
+		&image.CreatedAt,
+		&image.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+// SyncPost - One row per image of a post; rows of removed images are dropped
+func (r *PostImageRepository) SyncPost(ctx context.Context, postID string, urls []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin image sync: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO post_images (post_id, url)
+		SELECT $1, url FROM unnest($2::text[]) AS url
+		ON CONFLICT (post_id, url) DO NOTHING
+	`, postID, urls); err != nil {
+		return fmt.Errorf("failed to add post images: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM post_images WHERE post_id = $1 AND NOT (url = ANY($2::text[]))
+	`, postID, urls); err != nil {
+		return fmt.Errorf("failed to remove post images: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit image sync: %w", err)
+	}
+	return nil
+}
+
+// SyncAll - Add rows for images saved before descriptions existed and drop rows of removed images
+func (r *PostImageRepository) SyncAll(ctx context.Context) (int64, error) {
+	result, err := r.db.Exec(ctx, `
+		INSERT INTO post_images (post_id, url)
+		SELECT p.id, u.url
+		FROM posts p, unnest(p.image_url) AS u(url)
+		ON CONFLICT (post_id, url) DO NOTHING
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add missing post images: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, `
+		DELETE FROM post_images i
+		USING posts p
+		WHERE i.post_id = p.id AND NOT (i.url = ANY(COALESCE(p.image_url, '{}')))
+	`); err != nil {
+		return 0, fmt.Errorf("failed to remove stale post images: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// ListPending - Images waiting for AI text, and failed ones due a retry, oldest first
+func (r *PostImageRepository) ListPending(ctx context.Context, maxAttempts int, retryAfter time.Duration, limit int) ([]*model.ImageDescriptionSource, error) {
+	query := `
+		SELECT i.id, i.post_id, i.url, p.title, p.language, i.attempts
+		FROM post_images i
+		JOIN posts p ON p.id = i.post_id
+		WHERE i.status = 'pending'
+			OR (i.status = 'failed' AND i.attempts < $1
+				AND i.updated_at < CURRENT_TIMESTAMP - make_interval(secs => $2))
+		ORDER BY i.created_at
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, maxAttempts, retryAfter.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending images: %w", err)
+	}
+	defer rows.Close()
+
+	sources := []*model.ImageDescriptionSource{}
+	for rows.Next() {
+		var source model.ImageDescriptionSource
+		if err := rows.Scan(&source.ImageID, &source.PostID, &source.URL, &source.PostTitle, &source.Language, &source.Attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan pending image: %w", err)
+		}
+		sources = append(sources, &source)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pending images: %w", err)
+	}
+
+	return sources, nil
+}
+
+// SaveDescription - Store the AI text; author overrides are left alone
+func (r *PostImageRepository) SaveDescription(ctx context.Context, imageID, altText, caption, modelName string) error {
+	query := `
+		UPDATE post_images
+		SET alt_text = $2, caption = NULLIF($3, ''), model = $4, status = 'ready', error = NULL,
+			described_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, imageID, altText, caption, modelName); err != nil {
+		return fmt.Errorf("failed to save image description: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed - Count a failed attempt; the image is retried later until it runs out of attempts
+func (r *PostImageRepository) MarkFailed(ctx context.Context, imageID, reason string) error {
+	query := `
+		UPDATE post_images
+		SET status = 'failed', error = $2, attempts = attempts + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, imageID, reason); err != nil {
+		return fmt.Errorf("failed to mark image description failed: %w", err)
+	}
+	return nil
+}
+
+// Requeue - Describe an image again from scratch
+func (r *PostImageRepository) Requeue(ctx context.Context, imageID string) error {
+	query := `
+		UPDATE post_images
+		SET status = 'pending', error = NULL, attempts = 0, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, imageID); err != nil {
+		return fmt.Errorf("failed to requeue image: %w", err)
+	}
+	return nil
+}
+
+// FindByID - One image row; nil if missing
+func (r *PostImageRepository) FindByID(ctx context.Context, imageID string) (*model.PostImage, error) {
+	query := `SELECT ` + postImageColumns + ` FROM post_images WHERE id = $1`
+
+	image, err := scanPostImage(r.db.QueryRow(ctx, query, imageID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find image: %w", err)
+	}
+	return image, nil
+}
+
+// ListByPosts - Image rows of several posts
+func (r *PostImageRepository) ListByPosts(ctx context.Context, postIDs []string) ([]*model.PostImage, error) {
+	query := `SELECT ` + postImageColumns + ` FROM post_images WHERE post_id = ANY($1::uuid[])`
+
+	rows, err := r.db.Query(ctx, query, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list post images: %w", err)
+	}
+	defer rows.Close()
+
+	images := []*model.PostImage{}
+	for rows.Next() {
+		image, err := scanPostImage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan post image: %w", err)
+		}
+		images = append(images, image)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating post images: %w", err)
+	}
+
+	return images, nil
+}
+
+// UpdateAuthorText - Save the author's alt text and caption overrides
+func (r *PostImageRepository) UpdateAuthorText(ctx context.Context, image *model.PostImage) error {
+	query := `
+		UPDATE post_images
+		SET author_alt_text = $2, author_caption = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, image.ID, image.AuthorAltText, image.AuthorCaption).Scan(&image.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("image not found")
+		}
+		return fmt.Errorf("failed to update image text: %w", err)
+	}
+	return nil
+}
@@ -20,12 +20,53 @@ func NewPostRepository(db *pgxpool.Pool) *PostRepository{
 	return &PostRepository{db: db}
 }
 
+const postColumns = `id, author_id, title, content, image_url, category, tags,
+	is_published, view_count, ai_generated, review_status, review_reason,
+	reviewed_by, reviewed_at, generation_job_id, language, created_at, updated_at`
+
+// postLanguageFilter - Posts written in the language at param, or with a readable translation into it; '' matches all
+func postLanguageFilter(param string) string {
+	return `(` + param + ` = '' OR language = ` + param + ` OR EXISTS (
+		SELECT 1 FROM post_translations t
+		WHERE t.post_id = posts.id AND t.language = ` + param + ` AND t.content <> ''
+	))`
+}
+
+func scanPost(row pgx.Row) (*model.Post, error) {
+	var post model.Post
+	err := row.Scan(
+		&post.ID,
+		&post.AuthorID,
+		&post.Title,
+		&post.Content,
+		&post.ImageURL,
+		&post.Category,
+		&post.Tags,
+		&post.IsPublished,
+		&post.ViewCount,
+		&post.AIGenerated,
+		&post.ReviewStatus,
+		&post.ReviewReason,
+		&post.ReviewedBy,
+		&post.ReviewedAt,
+		&post.GenerationJobID,
+		&post.Language,
+		&post.CreatedAt,
+		&post.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
 
 func (r *PostRepository) Create(ctx context.Context, post *model.Post) error {
 	query := `
-		INSERT INTO posts (title, content, image_url, tags, author_id, category)
-		VALUES ($1, $2 , $3, $4, $5, $6)
-		RETURNING id , created_at , updated_at
+		INSERT INTO posts (title, content, image_url, tags, author_id, category,
+			is_published, ai_generated, review_status, generation_job_id, language)
+		VALUES ($1, $2 , $3, $4, $5, $6, $7, $8, $9, $10, COALESCE(NULLIF($11, ''), 'en'))
+		RETURNING id , language, created_at , updated_at
 	`
 	// Execute query and scan the returned values
 	err := r.db.QueryRow(
@@ -37,7 +78,12 @@ func (r *PostRepository) Create(ctx context.Context, post *model.Post) error {
 		post.Tags,       
 		post.AuthorID,    
 		post.Category,    
-	).Scan(&post.ID, &post.CreatedAt, &post.UpdatedAt)
+		post.IsPublished,
+		post.AIGenerated,
+		post.ReviewStatus,
+		post.GenerationJobID,
+		post.Language,
+	).Scan(&post.ID, &post.Language, &post.CreatedAt, &post.UpdatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create post: %w", err)
@@ -47,17 +93,18 @@ func (r *PostRepository) Create(ctx context.Context, post *model.Post) error {
 }
 
 
-func (r *PostRepository) GetAllPost(ctx context.Context, limit, offset int) ([]*model.Post, error){
+func (r *PostRepository) GetAllPost(ctx context.Context, limit, offset int, language string) ([]*model.Post, error){
+	// Posts held back by review stay out of the public feed
 	query := `
-		SELECT id, title, content, author_id, image_url, tags, 
-			category, is_published, view_count,
-			created_at, updated_at		
+		SELECT ` + postColumns + `
 		FROM posts
+		WHERE (review_status IS NULL OR review_status = 'approved')
+			AND ` + postLanguageFilter("$3") + `
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
 	`
 
-	rows, err := r.db.Query(ctx, query, limit, offset)
+	rows, err := r.db.Query(ctx, query, limit, offset, language)
 	if err != nil {
 		return nil, err
 	}
@@ -65,27 +112,12 @@ func (r *PostRepository) GetAllPost(ctx context.Context, limit, offset int) ([]*
 
 	var posts []*model.Post
 	for rows.Next(){
-		var post model.Post
-		err := rows.Scan(
-			&post.ID,
-			&post.Title,
-			&post.Content,
-			&post.AuthorID,
-			&post.ImageURL,
-			&post.Tags,
-			&post.Category,
-			&post.IsPublished,
-			&post.ViewCount,
-			// &post.Likes,
-			// &post.Comments,
-			&post.CreatedAt,
-			&post.UpdatedAt,
-		)
+		post, err := scanPost(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan post %w", err)
 		}
 
-		posts = append(posts, &post)
+		posts = append(posts, post)
 	}
 
 	// Check for iteration errors
@@ -97,11 +129,14 @@ func (r *PostRepository) GetAllPost(ctx context.Context, limit, offset int) ([]*
 }
 
 
-func (r *PostRepository) CountPosts(ctx context.Context) (int64, error) {
-	query := "SELECT COUNT(*) FROM posts"
+func (r *PostRepository) CountPosts(ctx context.Context, language string) (int64, error) {
+	query := `
+		SELECT COUNT(*) FROM posts
+		WHERE (review_status IS NULL OR review_status = 'approved')
+			AND ` + postLanguageFilter("$1")
 	
 	var count int64
-	err := r.db.QueryRow(ctx, query).Scan(&count)
+	err := r.db.QueryRow(ctx, query, language).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count posts: %w", err)
 	}
@@ -113,27 +148,12 @@ func (r *PostRepository) CountPosts(ctx context.Context) (int64, error) {
 //FindByID - Get a post by ID
 func (r *PostRepository) FindByID(ctx context.Context, postID string) (*model.Post, error) {
 	query := `
-		SELECT id, author_id, title, content, image_url, category, tags, 
-		is_published, view_count, created_at, updated_at
+		SELECT ` + postColumns + `
 		FROM posts
 		WHERE id = $1
 	`
 
-	var post model.Post
-	err := r.db.QueryRow(ctx, query, postID).Scan(
-		&post.ID,
-		&post.AuthorID,
-		&post.Title,
-		&post.Content,
-		&post.ImageURL,
-		&post.Category,
-		&post.Tags,
-		&post.IsPublished,
-		&post.ViewCount,
-		&post.CreatedAt,
-		&post.UpdatedAt,
-	)
-
+	post, err := scanPost(r.db.QueryRow(ctx, query, postID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -141,15 +161,14 @@ func (r *PostRepository) FindByID(ctx context.Context, postID string) (*model.Po
 		return nil, fmt.Errorf("failed to find post: %w", err)
 	}
 
-	return &post, nil
+	return post, nil
 }
 
 
 //FindByID - Get a auth by authorID
 func (r *PostRepository) FindByAuthorID(ctx context.Context, authorID string) ([]*model.Post, error) {
 	query := `
-		SELECT id, author_id, title, content, image_url, category, tags, 
-		is_published, view_count, created_at, updated_at
+		SELECT ` + postColumns + `
 		FROM posts
 		WHERE author_id = $1
 		ORDER BY created_at DESC
@@ -162,24 +181,11 @@ func (r *PostRepository) FindByAuthorID(ctx context.Context, authorID string) ([
 	defer rows.Close()
 	var posts []*model.Post
 	for rows.Next(){
-		var post model.Post
-		err := rows.Scan(
-			&post.ID,
-			&post.AuthorID,
-			&post.Title,
-			&post.Content,
-			&post.ImageURL,
-			&post.Category,
-			&post.Tags,
-			&post.IsPublished,
-			&post.ViewCount,
-			&post.CreatedAt,
-			&post.UpdatedAt,
-		)
+		post, err := scanPost(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan post: %w", err)
 		}
-		posts = append(posts, &post)
+		posts = append(posts, post)
 	}
 	
 	return posts, nil
@@ -192,7 +198,8 @@ func (r *PostRepository) Update(ctx context.Context, post *model.Post) error {
 	query := `
 		UPDATE posts
 		SET title = $1, content = $2, image_url = $3, category = $4, 
-			tags = $5, is_published = $6, updated_at = CURRENT_TIMESTAMP
+			tags = $5, is_published = $6, language = COALESCE(NULLIF($8, ''), language),
+			updated_at = CURRENT_TIMESTAMP
 		WHERE id = $7
 		RETURNING updated_at
 	`
@@ -207,6 +214,7 @@ func (r *PostRepository) Update(ctx context.Context, post *model.Post) error {
 		post.Tags,
 		post.IsPublished,
 		post.ID,
+		post.Language,
 	).Scan(&post.UpdatedAt)
 
 	if err != nil {
@@ -247,4 +255,81 @@ func (r *PostRepository) IncrementViewCount(ctx context.Context, postID string)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+
+// ListForReview - AI posts in a review state, oldest first so the queue is worked in order
+func (r *PostRepository) ListForReview(ctx context.Context, status string, limit, offset int) ([]*model.Post, error) {
+	query := `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE review_status = $1
+		ORDER BY created_at
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review queue: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []*model.Post
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan post: %w", err)
+		}
+		posts = append(posts, post)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review queue: %w", err)
+	}
+
+	return posts, nil
+}
+
+
+// CountForReview - Number of posts in a review state
+func (r *PostRepository) CountForReview(ctx context.Context, status string) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM posts WHERE review_status = $1", status).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count review queue: %w", err)
+	}
+	return count, nil
+}
+
+
+// UpdateReview - Save the review decision, publish flag and generating job
+func (r *PostRepository) UpdateReview(ctx context.Context, post *model.Post) error {
+	query := `
+		UPDATE posts
+		SET review_status = $2, review_reason = $3, reviewed_by = $4, reviewed_at = $5,
+			is_published = $6, generation_job_id = $7, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		post.ID,
+		post.ReviewStatus,
+		post.ReviewReason,
+		post.ReviewedBy,
+		post.ReviewedAt,
+		post.IsPublished,
+		post.GenerationJobID,
+	).Scan(&post.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("post not found")
+		}
+		return fmt.Errorf("failed to update post review: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// testDatabaseEnv - A Postgres URL the repository tests may create and drop schemas in
+const testDatabaseEnv = "QUILLHUB_TEST_DATABASE_URL"
+
+// testDB - A pool on a fresh schema with every migration applied, dropped when the test
+// ends. Tests using it are skipped unless QUILLHUB_TEST_DATABASE_URL is set.
+func testDB(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	url := os.Getenv(testDatabaseEnv)
+	if url == "" {
+		t.Skipf("%s is not set", testDatabaseEnv)
+	}
+	ctx := context.Background()
+
+	admin, err := pgx.Connect(ctx, url)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	schema := fmt.Sprintf("quillhub_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("drop schema: %v", err)
+		}
+		admin.Close(ctx)
+	})
+
+	cfg, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		t.Fatalf("parse %s: %v", testDatabaseEnv, err)
+	}
+	// Extensions already installed in public stay visible
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema + ",public"
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	if err := database.RunMigrations(ctx, pool); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return pool
+}
+
+// insertUser - A user named name; returns its ID
+func insertUser(t *testing.T, db *pgxpool.Pool, name string) string {
+	t.Helper()
+
+	var id string
+	err := db.QueryRow(context.Background(), `
+		INSERT INTO users (name, username, email, password)
+		VALUES ($1, $1, $1 || '@example.com', 'not-a-hash')
+		RETURNING id::text
+	`, name).Scan(&id)
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	return id
+}
+
+// insertPost - A published post; returns its ID
+func insertPost(t *testing.T, db *pgxpool.Pool, authorID, title, content string, tags ...string) string {
+	t.Helper()
+
+	var id string
+	err := db.QueryRow(context.Background(), `
+		INSERT INTO posts (author_id, title, content, tags)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id::text
+	`, authorID, title, content, tags).Scan(&id)
+	if err != nil {
+		t.Fatalf("insert post: %v", err)
+	}
+	return id
+}
+
+func TestMigrationsKeepUsernamesUnique(t *testing.T) {
+	db := testDB(t)
+
+	insertUser(t, db, "taken")
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO users (name, username, email, password)
+		VALUES ('Other', 'taken', 'other@example.com', 'not-a-hash')
+	`)
+	if err == nil {
+		t.Error("two users share a username")
+	}
+}
@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SearchRepository - Post embeddings, keyword search and similarity queries
+type SearchRepository struct {
+	db       *pgxpool.Pool
+	pgvector bool
+}
+
+func NewSearchRepository(db *pgxpool.Pool) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// Only posts the public can see are searchable or suggested
+const searchVisible = `(p.review_status IS NULL OR p.review_status = 'approved')`
+
+// embeddingHash - Must match the text the service embeds (title, tags, content)
+const embeddingHash = `md5(p.title || E'\n' || COALESCE(array_to_string(p.tags, ','), '') || E'\n' || p.content)`
+
+// DetectPgvector - Use pgvector for similarity when the extension is installed
+func (r *SearchRepository) DetectPgvector(ctx context.Context) (bool, error) {
+	var installed bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'vector')`).Scan(&installed)
+	if err != nil {
+		return false, fmt.Errorf("failed to check for pgvector: %w", err)
+	}
+
+	r.pgvector = installed
+	return installed, nil
+}
+
+func scanPostHit(row pgx.Row) (*model.PostSearchHit, error) {
+	var (
+		post  model.Post
+		score float64
+	)
+	err := row.Scan(
+		&post.ID,
+		&post.AuthorID,
+		&post.Title,
+		&post.Content,
+		&post.ImageURL,
+		&post.Category,
+		&post.Tags,
+		&post.IsPublished,
+		&post.ViewCount,
+		&post.AIGenerated,
+		&post.ReviewStatus,
+		&post.ReviewReason,
+		&post.ReviewedBy,
+		&post.ReviewedAt,
+		&post.GenerationJobID,
+		&post.CreatedAt,
+		&post.UpdatedAt,
+		&score,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &model.PostSearchHit{Post: &post, Score: score}, nil
+}
+
+func (r *SearchRepository) queryHits(ctx context.Context, query string, args ...any) ([]model.PostSearchHit, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search posts: %w", err)
+	}
+	defer rows.Close()
+
+	hits := []model.PostSearchHit{}
+	for rows.Next() {
+		hit, err := scanPostHit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		hits = append(hits, *hit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search results: %w", err)
+	}
+
+	return hits, nil
+}
+
+// ListStale - Posts with no embedding, an embedding from another model, or edited since embedding
+func (r *SearchRepository) ListStale(ctx context.Context, embeddingModel string, limit int) ([]*model.EmbeddingSource, error) {
+	query := `
+		SELECT p.id, p.title, p.content, p.tags, p.category, ` + embeddingHash + `
+		FROM posts p
+		LEFT JOIN post_embeddings e ON e.post_id = p.id
+		WHERE e.post_id IS NULL
+			OR e.model <> $1
+			OR e.content_hash <> ` + embeddingHash + `
+		ORDER BY p.created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, embeddingModel, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch posts to embed: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []*model.EmbeddingSource
+	for rows.Next() {
+		var source model.EmbeddingSource
+		if err := rows.Scan(&source.PostID, &source.Title, &source.Content, &source.Tags, &source.Category, &source.ContentHash); err != nil {
+			return nil, fmt.Errorf("failed to scan post to embed: %w", err)
+		}
+		sources = append(sources, &source)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating posts to embed: %w", err)
+	}
+
+	return sources, nil
+}
+
+// CountStale - Posts waiting for an embedding
+func (r *SearchRepository) CountStale(ctx context.Context, embeddingModel string) (int64, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM posts p
+		LEFT JOIN post_embeddings e ON e.post_id = p.id
+		WHERE e.post_id IS NULL
+			OR e.model <> $1
+			OR e.content_hash <> ` + embeddingHash
+
+	var count int64
+	if err := r.db.QueryRow(ctx, query, embeddingModel).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count posts to embed: %w", err)
+	}
+	return count, nil
+}
+
+// UpsertEmbedding - Store or replace a post's embedding
+func (r *SearchRepository) UpsertEmbedding(ctx context.Context, embedding *model.PostEmbedding) error {
+	query := `
+		INSERT INTO post_embeddings (post_id, model, dims, embedding, content_hash)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (post_id) DO UPDATE
+		SET model = EXCLUDED.model, dims = EXCLUDED.dims, embedding = EXCLUDED.embedding,
+			content_hash = EXCLUDED.content_hash, embedded_at = CURRENT_TIMESTAMP
+		RETURNING embedded_at
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		embedding.PostID,
+		embedding.Model,
+		embedding.Dims,
+		embedding.Embedding,
+		embedding.ContentHash,
+	).Scan(&embedding.EmbeddedAt)
+
+	if err != nil {
+		// The post may have been deleted while it was being embedded
+		return fmt.Errorf("failed to save embedding: %w", err)
+	}
+
+	return nil
+}
+
+// FindEmbedding - A post's embedding, nil if it has none
+func (r *SearchRepository) FindEmbedding(ctx context.Context, postID string) (*model.PostEmbedding, error) {
+	query := `
+		SELECT post_id, model, dims, embedding, content_hash, embedded_at
+		FROM post_embeddings
+		WHERE post_id = $1
+	`
+
+	var embedding model.PostEmbedding
+	err := r.db.QueryRow(ctx, query, postID).Scan(
+		&embedding.PostID,
+		&embedding.Model,
+		&embedding.Dims,
+		&embedding.Embedding,
+		&embedding.ContentHash,
+		&embedding.EmbeddedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find embedding: %w", err)
+	}
+
+	return &embedding, nil
+}
+
+// DeleteAllEmbeddings - Drop every embedding so the next backfill rebuilds them
+func (r *SearchRepository) DeleteAllEmbeddings(ctx context.Context) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM post_embeddings`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete embeddings: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// NearestPosts - Public posts most similar to vector, excluding excludeID when set
+func (r *SearchRepository) NearestPosts(ctx context.Context, vector []float32, embeddingModel, excludeID string, limit, offset int) ([]model.PostSearchHit, error) {
+	// Embeddings are unit length, so cosine similarity is the dot product
+	similarity := `(SELECT SUM(a * b) FROM unnest(e.embedding, $1::real[]) AS u(a, b))`
+	if r.pgvector {
+		similarity = `(1 - (e.embedding::vector <=> $1::real[]::vector))`
+	}
+
+	query := `
+		SELECT ` + prefixedPostColumns + `, s.score
+		FROM (
+			SELECT e.post_id, ` + similarity + ` AS score
+			FROM post_embeddings e
+			WHERE e.model = $2 AND e.dims = $3
+				AND ($4 = '' OR e.post_id::text <> $4)
+		) s
+		JOIN posts p ON p.id = s.post_id
+		WHERE ` + searchVisible + `
+		ORDER BY s.score DESC
+		LIMIT $5 OFFSET $6
+	`
+
+	return r.queryHits(ctx, query, vector, embeddingModel, len(vector), excludeID, limit, offset)
+}
+
+// KeywordSearch - Full-text search over titles and content
+func (r *SearchRepository) KeywordSearch(ctx context.Context, text string, limit, offset int) ([]model.PostSearchHit, error) {
+	query := `
+		SELECT ` + prefixedPostColumns + `,
+			ts_rank(to_tsvector('english', p.title || ' ' || p.content), q) AS score
+		FROM posts p, websearch_to_tsquery('english', $1) q
+		WHERE to_tsvector('english', p.title || ' ' || p.content) @@ q
+			AND ` + searchVisible + `
+		ORDER BY score DESC, p.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	return r.queryHits(ctx, query, text, limit, offset)
+}
+
+// RelatedByTags - Fallback similarity: shared tags, plus a bonus for the same category
+func (r *SearchRepository) RelatedByTags(ctx context.Context, postID string, tags []string, category *string, limit int) ([]model.PostSearchHit, error) {
+	query := `
+		SELECT * FROM (
+			SELECT ` + prefixedPostColumns + `,
+				(SELECT COUNT(*) FROM unnest(p.tags) t WHERE LOWER(t) = ANY($2))::float8
+					+ CASE WHEN $3::text IS NOT NULL AND p.category = $3 THEN 0.5 ELSE 0 END AS score
+			FROM posts p
+			WHERE p.id::text <> $1 AND ` + searchVisible + `
+		) related
+		WHERE score > 0
+		ORDER BY score DESC, created_at DESC
+		LIMIT $4
+	`
+
+	return r.queryHits(ctx, query, postID, tags, category, limit)
+}
+
+const prefixedPostColumns = `p.id, p.author_id, p.title, p.content, p.image_url, p.category, p.tags,
+	p.is_published, p.view_count, p.ai_generated, p.review_status, p.review_reason,
+	p.reviewed_by, p.reviewed_at, p.generation_job_id, p.created_at, p.updated_at`
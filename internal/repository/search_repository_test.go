@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/britinogn/quillhub/internal/model"
+)
+
+func upsertTestEmbedding(t *testing.T, repo *SearchRepository, postID, embeddingModel, hash string, vector ...float32) {
+	t.Helper()
+
+	embedding := &model.PostEmbedding{Model: embeddingModel, Dims: len(vector), Embedding: vector, ContentHash: hash}
+	if err := embedding.PostID.Scan(postID); err != nil {
+		t.Fatalf("post ID: %v", err)
+	}
+	if err := repo.UpsertEmbedding(context.Background(), embedding); err != nil {
+		t.Fatalf("UpsertEmbedding: %v", err)
+	}
+}
+
+// staleHashes - ListStale as post ID to content hash
+func staleHashes(t *testing.T, repo *SearchRepository, embeddingModel string) map[string]string {
+	t.Helper()
+
+	sources, err := repo.ListStale(context.Background(), embeddingModel, 100)
+	if err != nil {
+		t.Fatalf("ListStale: %v", err)
+	}
+	hashes := make(map[string]string, len(sources))
+	for _, source := range sources {
+		hashes[source.PostID.String()] = source.ContentHash
+	}
+	return hashes
+}
+
+func TestSearchRepositoryListStale(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo := NewSearchRepository(db)
+
+	author := insertUser(t, db, "writer")
+	missing := insertPost(t, db, author, "Never embedded", "Body")
+	fresh := insertPost(t, db, author, "Up to date", "Body", "go")
+	otherModel := insertPost(t, db, author, "Other model", "Body")
+	edited := insertPost(t, db, author, "Edited", "Body")
+	retagged := insertPost(t, db, author, "Retagged", "Body", "go")
+
+	hashes := staleHashes(t, repo, "model-a")
+	if len(hashes) != 5 {
+		t.Fatalf("%d stale posts before embedding, want 5", len(hashes))
+	}
+	for _, id := range []string{fresh, edited, retagged} {
+		upsertTestEmbedding(t, repo, id, "model-a", hashes[id], 1, 0)
+	}
+	upsertTestEmbedding(t, repo, otherModel, "model-b", hashes[otherModel], 1, 0)
+
+	if _, err := db.Exec(ctx, `UPDATE posts SET content = content || ' and more' WHERE id = $1`, edited); err != nil {
+		t.Fatalf("edit post: %v", err)
+	}
+	if _, err := db.Exec(ctx, `UPDATE posts SET tags = ARRAY['go', 'testing'] WHERE id = $1`, retagged); err != nil {
+		t.Fatalf("retag post: %v", err)
+	}
+
+	stale := staleHashes(t, repo, "model-a")
+	want := []string{missing, otherModel, edited, retagged}
+	if len(stale) != len(want) {
+		t.Errorf("stale posts = %v, want %v", stale, want)
+	}
+	for _, id := range want {
+		if _, ok := stale[id]; !ok {
+			t.Errorf("post %s is not listed as stale", id)
+		}
+	}
+	if _, ok := stale[fresh]; ok {
+		t.Errorf("post %s is listed as stale though its hash matches", fresh)
+	}
+	if stale[edited] == hashes[edited] {
+		t.Error("editing the content did not change the content hash")
+	}
+
+	count, err := repo.CountStale(ctx, "model-a")
+	if err != nil {
+		t.Fatalf("CountStale: %v", err)
+	}
+	if count != int64(len(want)) {
+		t.Errorf("CountStale = %d, want %d", count, len(want))
+	}
+
+	// Re-embedding with the new hash makes the post current again
+	upsertTestEmbedding(t, repo, edited, "model-a", stale[edited], 1, 0)
+	if _, ok := staleHashes(t, repo, "model-a")[edited]; ok {
+		t.Error("post is still stale after re-embedding")
+	}
+}
+
+func TestSearchRepositoryNearestPosts(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	author := insertUser(t, db, "writer")
+	same := insertPost(t, db, author, "Same direction", "Body")
+	near := insertPost(t, db, author, "Close", "Body")
+	orthogonal := insertPost(t, db, author, "Orthogonal", "Body")
+	otherDims := insertPost(t, db, author, "Other dims", "Body")
+	otherModel := insertPost(t, db, author, "Other model", "Body")
+
+	seed := NewSearchRepository(db)
+	upsertTestEmbedding(t, seed, same, "m", "h", 1, 0, 0)
+	upsertTestEmbedding(t, seed, near, "m", "h", 0.6, 0.8, 0)
+	upsertTestEmbedding(t, seed, orthogonal, "m", "h", 0, 0, 1)
+	upsertTestEmbedding(t, seed, otherDims, "m", "h", 1, 0)
+	upsertTestEmbedding(t, seed, otherModel, "other", "h", 1, 0, 0)
+
+	// The REAL[] fallback always runs; pgvector too where the extension is installed
+	modes := []bool{false}
+	if installed, err := seed.DetectPgvector(ctx); err != nil {
+		t.Fatalf("DetectPgvector: %v", err)
+	} else if installed {
+		modes = append(modes, true)
+	}
+
+	for _, pgvector := range modes {
+		repo := &SearchRepository{db: db, pgvector: pgvector}
+
+		assertNearest(t, repo, "", map[string]float64{same: 1, near: 0.6, orthogonal: 0}, []string{same, near, orthogonal})
+		assertNearest(t, repo, same, map[string]float64{near: 0.6, orthogonal: 0}, []string{near, orthogonal})
+	}
+}
+
+func assertNearest(t *testing.T, repo *SearchRepository, excludeID string, scores map[string]float64, order []string) {
+	t.Helper()
+
+	hits, err := repo.NearestPosts(context.Background(), []float32{1, 0, 0}, "m", excludeID, 10, 0)
+	if err != nil {
+		t.Fatalf("pgvector=%v: NearestPosts: %v", repo.pgvector, err)
+	}
+	if len(hits) != len(order) {
+		t.Fatalf("pgvector=%v: %d hits, want %d", repo.pgvector, len(hits), len(order))
+	}
+
+	if !sort.SliceIsSorted(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score }) {
+		t.Errorf("pgvector=%v: hits are not ordered by score", repo.pgvector)
+	}
+	for i, hit := range hits {
+		id := hit.Post.ID.String()
+		if id != order[i] {
+			t.Errorf("pgvector=%v: hit %d is %s, want %s", repo.pgvector, i, id, order[i])
+		}
+		if math.Abs(hit.Score-scores[id]) > 1e-5 {
+			t.Errorf("pgvector=%v: score of %s = %f, want %f", repo.pgvector, id, hit.Score, scores[id])
+		}
+	}
+}
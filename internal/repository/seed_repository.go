@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SeedRepository - Writes demo content in one transaction, with backdated timestamps
+type SeedRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSeedRepository(db *pgxpool.Pool) *SeedRepository {
+	return &SeedRepository{db: db}
+}
+
+// CountUsersByEmail - How many of the emails already have an account
+func (r *SeedRepository) CountUsersByEmail(ctx context.Context, emails []string) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE email = ANY($1)`, emails).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return count, nil
+}
+
+// Insert - Create the users, posts, comments and likes; passwordHash is used for every user
+func (r *SeedRepository) Insert(ctx context.Context, data *model.SeedData, passwordHash string, now time.Time) (*model.SeedResult, error) {
+	result := &model.SeedResult{}
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		// Accounts exist a little longer than their oldest post
+		var oldest time.Duration
+		for _, post := range data.Posts {
+			oldest = max(oldest, post.Age)
+		}
+		joined := now.Add(-oldest - 7*24*time.Hour)
+
+		userIDs := make([]string, len(data.Users))
+		for i, user := range data.Users {
+			err := tx.QueryRow(ctx, `
+				INSERT INTO users (name, username, email, password, role, bio, is_verified, created_at, updated_at)
+				VALUES ($1, $2, $3, $4, 'user', $5, true, $6, $6)
+				RETURNING id
+			`, user.Name, user.Username, user.Email, passwordHash, user.Bio, joined).Scan(&userIDs[i])
+			if err != nil {
+				return fmt.Errorf("failed to create user %s: %w", user.Username, err)
+			}
+			result.Users++
+		}
+
+		for _, post := range data.Posts {
+			published := now.Add(-post.Age)
+
+			var postID string
+			err := tx.QueryRow(ctx, `
+				INSERT INTO posts (title, content, image_url, tags, author_id, category, is_published, view_count, created_at, updated_at)
+				VALUES ($1, $2, '{}', $3, $4, $5, true, $6, $7, $7)
+				RETURNING id
+			`, post.Title, post.Content, post.Tags, userIDs[post.Author], post.Category, post.ViewCount, published).Scan(&postID)
+			if err != nil {
+				return fmt.Errorf("failed to create post %q: %w", post.Title, err)
+			}
+			result.Posts++
+
+			for _, comment := range post.Comments {
+				written := published.Add(comment.After)
+				if _, err := tx.Exec(ctx, `
+					INSERT INTO comments (text, post_id, author_id, created_at, updated_at)
+					VALUES ($1, $2, $3, $4, $4)
+				`, comment.Text, postID, userIDs[comment.Author], written); err != nil {
+					return fmt.Errorf("failed to create comment: %w", err)
+				}
+				result.Comments++
+			}
+
+			for i, liker := range post.LikedBy {
+				liked := published.Add(time.Duration(i+1) * time.Hour)
+				if _, err := tx.Exec(ctx, `
+					INSERT INTO likes (post_id, user_id, created_at)
+					VALUES ($1, $2, $3)
+					ON CONFLICT (post_id, user_id) DO NOTHING
+				`, postID, userIDs[liker], liked); err != nil {
+					return fmt.Errorf("failed to create like: %w", err)
+				}
+				result.Likes++
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
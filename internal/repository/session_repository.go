@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SessionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSessionRepository(db *pgxpool.Pool) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// Create - Persist a new login session
+func (r *SessionRepository) Create(ctx context.Context, session *model.Session) error {
+	query := `
+		INSERT INTO user_sessions (user_id, user_agent, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, last_seen_at
+	`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		session.UserID,
+		session.UserAgent,
+		session.IPAddress,
+		session.ExpiresAt,
+	).Scan(&session.ID, &session.CreatedAt, &session.LastSeenAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID - Get a session by ID
+func (r *SessionRepository) FindByID(ctx context.Context, sessionID string) (*model.Session, error) {
+	query := `
+		SELECT id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at
+		FROM user_sessions
+		WHERE id = $1
+	`
+
+	var session model.Session
+	err := r.db.QueryRow(ctx, query, sessionID).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	return &session, nil
+}
+
+// FindActiveByUserID - Get all sessions of a user that are neither revoked nor expired
+func (r *SessionRepository) FindActiveByUserID(ctx context.Context, userID string) ([]*model.Session, error) {
+	query := `
+		SELECT id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at
+		FROM user_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY last_seen_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*model.Session
+	for rows.Next() {
+		var session model.Session
+		err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.UserAgent,
+			&session.IPAddress,
+			&session.CreatedAt,
+			&session.LastSeenAt,
+			&session.ExpiresAt,
+			&session.RevokedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, &session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Touch - Update last_seen_at, at most once per minute to avoid a write on every request
+func (r *SessionRepository) Touch(ctx context.Context, sessionID string) error {
+	query := `
+		UPDATE user_sessions
+		SET last_seen_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND last_seen_at < CURRENT_TIMESTAMP - INTERVAL '1 minute'
+	`
+
+	if _, err := r.db.Exec(ctx, query, sessionID); err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke - Revoke a single session owned by the user
+func (r *SessionRepository) Revoke(ctx context.Context, sessionID, userID string) error {
+	query := `
+		UPDATE user_sessions
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, sessionID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("session not found")
+	}
+
+	return nil
+}
+
+// RevokeAllByUserID - Revoke every active session of a user ("sign out everywhere")
+func (r *SessionRepository) RevokeAllByUserID(ctx context.Context, userID string) (int64, error) {
+	query := `
+		UPDATE user_sessions
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	logger.Infof(ctx, "[SESSION-REPO] Revoked %d sessions for user: %s", result.RowsAffected(), userID)
+	return result.RowsAffected(), nil
+}
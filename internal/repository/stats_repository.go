@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StatsRepository - Rebuilds stored values that are derived from other tables
+type StatsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewStatsRepository(db *pgxpool.Pool) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+// RecountTopicUsage - Point every topic at the newest post generated about it; returns the topics changed
+func (r *StatsRepository) RecountTopicUsage(ctx context.Context) (int64, error) {
+	query := `
+		WITH latest AS (
+			SELECT DISTINCT ON (topic_id)
+				topic_id, post_id, COALESCE(finished_at, created_at) AS used_at
+			FROM ai_generation_jobs
+			WHERE topic_id IS NOT NULL AND status = 'succeeded'
+			ORDER BY topic_id, created_at DESC
+		)
+		UPDATE ai_topics t
+		SET last_used_at = latest.used_at, last_post_id = latest.post_id, updated_at = CURRENT_TIMESTAMP
+		FROM latest
+		WHERE t.id = latest.topic_id
+			AND (t.last_used_at IS NULL
+				OR t.last_used_at < latest.used_at
+				OR t.last_post_id IS DISTINCT FROM latest.post_id)
+	`
+
+	tag, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to recount topic usage: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// RecountAssistantTokens - Rebuild the assistant's daily token totals from the AI usage log;
+// returns the user-days changed. Request counts stay as they are: they include released reservations.
+func (r *StatsRepository) RecountAssistantTokens(ctx context.Context) (int64, error) {
+	query := `
+		WITH usage AS (
+			SELECT user_id, created_at::date AS day, -- created_at is stored in UTC
+				SUM(input_tokens) AS input_tokens, SUM(output_tokens) AS output_tokens
+			FROM ai_usage_events
+			WHERE feature = 'assistant' AND success AND user_id IS NOT NULL
+			GROUP BY user_id, created_at::date
+		)
+		UPDATE ai_assistant_usage a
+		SET input_tokens = usage.input_tokens, output_tokens = usage.output_tokens, updated_at = CURRENT_TIMESTAMP
+		FROM usage
+		WHERE a.user_id = usage.user_id AND a.day = usage.day
+			AND (a.input_tokens, a.output_tokens) IS DISTINCT FROM (usage.input_tokens, usage.output_tokens)
+	`
+
+	tag, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to recount assistant tokens: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TopicRepository - Topic and category pools for AI posting
+type TopicRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTopicRepository(db *pgxpool.Pool) *TopicRepository {
+	return &TopicRepository{db: db}
+}
+
+const aiCategoryColumns = `id, name, weight, enabled, created_at, updated_at`
+
+const aiTopicSelect = `
+	SELECT t.id, t.title, t.category_id, c.name, t.weight, t.enabled, t.cooldown_hours,
+		t.last_used_at, t.last_post_id, t.created_at, t.updated_at
+	FROM ai_topics t
+	LEFT JOIN ai_categories c ON c.id = t.category_id
+`
+
+func scanAICategory(row pgx.Row) (*model.AICategory, error) {
+	var category model.AICategory
+	err := row.Scan(
+		&category.ID,
+		&category.Name,
+		&category.Weight,
+		&category.Enabled,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func scanAITopic(row pgx.Row) (*model.AITopic, error) {
+	var topic model.AITopic
+	err := row.Scan(
+		&topic.ID,
+		&topic.Title,
+		&topic.CategoryID,
+		&topic.CategoryName,
+		&topic.Weight,
+		&topic.Enabled,
+		&topic.CooldownHours,
+		&topic.LastUsedAt,
+		&topic.LastPostID,
+		&topic.CreatedAt,
+		&topic.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &topic, nil
+}
+
+// ListCategories - All categories by name
+func (r *TopicRepository) ListCategories(ctx context.Context) ([]*model.AICategory, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+aiCategoryColumns+` FROM ai_categories ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch categories: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*model.AICategory
+	for rows.Next() {
+		category, err := scanAICategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// FindCategoryByID - Get a category by ID
+func (r *TopicRepository) FindCategoryByID(ctx context.Context, categoryID string) (*model.AICategory, error) {
+	return r.findCategory(ctx, `SELECT `+aiCategoryColumns+` FROM ai_categories WHERE id = $1`, categoryID)
+}
+
+// FindCategoryByName - Get a category by name (case-insensitive)
+func (r *TopicRepository) FindCategoryByName(ctx context.Context, name string) (*model.AICategory, error) {
+	return r.findCategory(ctx, `SELECT `+aiCategoryColumns+` FROM ai_categories WHERE LOWER(name) = LOWER($1)`, name)
+}
+
+func (r *TopicRepository) findCategory(ctx context.Context, query string, arg string) (*model.AICategory, error) {
+	category, err := scanAICategory(r.db.QueryRow(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find category: %w", err)
+	}
+	return category, nil
+}
+
+// CreateCategory - Add a category
+func (r *TopicRepository) CreateCategory(ctx context.Context, category *model.AICategory) error {
+	query := `
+		INSERT INTO ai_categories (name, weight, enabled)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, category.Name, category.Weight, category.Enabled).
+		Scan(&category.ID, &category.CreatedAt, &category.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateCategory - Save name, weight and enabled flag
+func (r *TopicRepository) UpdateCategory(ctx context.Context, category *model.AICategory) error {
+	query := `
+		UPDATE ai_categories
+		SET name = $2, weight = $3, enabled = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, category.ID, category.Name, category.Weight, category.Enabled).
+		Scan(&category.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCategory - Remove a category; its topics fall back to random categories
+func (r *TopicRepository) DeleteCategory(ctx context.Context, categoryID string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM ai_categories WHERE id = $1`, categoryID)
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("category not found")
+	}
+
+	return nil
+}
+
+// ListTopics - All topics by title
+func (r *TopicRepository) ListTopics(ctx context.Context) ([]*model.AITopic, error) {
+	rows, err := r.db.Query(ctx, aiTopicSelect+` ORDER BY t.title`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch topics: %w", err)
+	}
+	defer rows.Close()
+
+	var topics []*model.AITopic
+	for rows.Next() {
+		topic, err := scanAITopic(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan topic: %w", err)
+		}
+		topics = append(topics, topic)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating topics: %w", err)
+	}
+
+	return topics, nil
+}
+
+// FindTopicByID - Get a topic by ID
+func (r *TopicRepository) FindTopicByID(ctx context.Context, topicID string) (*model.AITopic, error) {
+	return r.findTopic(ctx, aiTopicSelect+` WHERE t.id = $1`, topicID)
+}
+
+// FindTopicByTitle - Get a topic by title (case-insensitive)
+func (r *TopicRepository) FindTopicByTitle(ctx context.Context, title string) (*model.AITopic, error) {
+	return r.findTopic(ctx, aiTopicSelect+` WHERE LOWER(t.title) = LOWER($1)`, title)
+}
+
+func (r *TopicRepository) findTopic(ctx context.Context, query string, arg string) (*model.AITopic, error) {
+	topic, err := scanAITopic(r.db.QueryRow(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find topic: %w", err)
+	}
+	return topic, nil
+}
+
+// CreateTopic - Add a topic
+func (r *TopicRepository) CreateTopic(ctx context.Context, topic *model.AITopic) error {
+	query := `
+		INSERT INTO ai_topics (title, category_id, weight, enabled, cooldown_hours)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, topic.Title, topic.CategoryID, topic.Weight, topic.Enabled, topic.CooldownHours).
+		Scan(&topic.ID, &topic.CreatedAt, &topic.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create topic: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTopic - Save the editable topic fields
+func (r *TopicRepository) UpdateTopic(ctx context.Context, topic *model.AITopic) error {
+	query := `
+		UPDATE ai_topics
+		SET title = $2, category_id = $3, weight = $4, enabled = $5, cooldown_hours = $6,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, topic.ID, topic.Title, topic.CategoryID, topic.Weight, topic.Enabled, topic.CooldownHours).
+		Scan(&topic.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update topic: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteTopic - Remove a topic
+func (r *TopicRepository) DeleteTopic(ctx context.Context, topicID string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM ai_topics WHERE id = $1`, topicID)
+	if err != nil {
+		return fmt.Errorf("failed to delete topic: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("topic not found")
+	}
+
+	return nil
+}
+
+// PickTopic - Weighted random choice among enabled topics that are out of cooldown; nil if none
+func (r *TopicRepository) PickTopic(ctx context.Context) (*model.AITopic, error) {
+	// -ln(u)/weight gives a weighted random order (Efraimidis-Spirakis)
+	query := aiTopicSelect + `
+		WHERE t.enabled
+			AND (t.category_id IS NULL OR c.enabled)
+			AND (t.last_used_at IS NULL
+				OR t.last_used_at + make_interval(hours => t.cooldown_hours) <= CURRENT_TIMESTAMP)
+		ORDER BY -ln(1.0 - random()) / t.weight
+		LIMIT 1
+	`
+
+	topic, err := scanAITopic(r.db.QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to pick topic: %w", err)
+	}
+	return topic, nil
+}
+
+// PickCategory - Weighted random choice among enabled categories; nil if none
+func (r *TopicRepository) PickCategory(ctx context.Context) (*model.AICategory, error) {
+	query := `
+		SELECT ` + aiCategoryColumns + `
+		FROM ai_categories
+		WHERE enabled
+		ORDER BY -ln(1.0 - random()) / weight
+		LIMIT 1
+	`
+
+	category, err := scanAICategory(r.db.QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to pick category: %w", err)
+	}
+	return category, nil
+}
+
+// MarkTopicUsed - Start the topic's cooldown after a post was published
+func (r *TopicRepository) MarkTopicUsed(ctx context.Context, topicID, postID string) error {
+	query := `
+		UPDATE ai_topics
+		SET last_used_at = CURRENT_TIMESTAMP, last_post_id = $2
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, topicID, postID); err != nil {
+		return fmt.Errorf("failed to mark topic used: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TranslationRepository - Post translations, one per post and language
+type TranslationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTranslationRepository(db *pgxpool.Pool) *TranslationRepository {
+	return &TranslationRepository{db: db}
+}
+
+const translationColumns = `id, post_id, language, title, content, tags, source, status, error,
+	model, translated_by, source_updated_at, created_at, updated_at`
+
+func scanTranslation(row pgx.Row) (*model.PostTranslation, error) {
+	var translation model.PostTranslation
+	err := row.Scan(
+		&translation.ID,
+		&translation.PostID,
+		&translation.Language,
+		&translation.Title,
+		&translation.Content,
+		&translation.Tags,
+		&translation.Source,
+		&translation.Status,
+		&translation.Error,
+		&translation.Model,
+		&translation.TranslatedBy,
+		&translation.SourceUpdatedAt,
+		&translation.CreatedAt,
+		&translation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+func (r *TranslationRepository) list(ctx context.Context, query string, args ...any) ([]*model.PostTranslation, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := []*model.PostTranslation{}
+	for rows.Next() {
+		translation, err := scanTranslation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan translation: %w", err)
+		}
+		translations = append(translations, translation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating translations: %w", err)
+	}
+
+	return translations, nil
+}
+
+// Get - One translation of a post; nil if missing
+func (r *TranslationRepository) Get(ctx context.Context, postID, language string) (*model.PostTranslation, error) {
+	query := `SELECT ` + translationColumns + ` FROM post_translations WHERE post_id = $1 AND language = $2`
+
+	translation, err := scanTranslation(r.db.QueryRow(ctx, query, postID, language))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find translation: %w", err)
+	}
+	return translation, nil
+}
+
+// ListByPost - Every translation of a post in any state, by language
+func (r *TranslationRepository) ListByPost(ctx context.Context, postID string) ([]*model.PostTranslation, error) {
+	query := `SELECT ` + translationColumns + ` FROM post_translations WHERE post_id = $1 ORDER BY language`
+	return r.list(ctx, query, postID)
+}
+
+// ListReadable - Translations into one language that have text, for a page of posts
+func (r *TranslationRepository) ListReadable(ctx context.Context, postIDs []string, language string) ([]*model.PostTranslation, error) {
+	query := `
+		SELECT ` + translationColumns + `
+		FROM post_translations
+		WHERE post_id = ANY($1::uuid[]) AND language = $2 AND content <> ''
+	`
+	return r.list(ctx, query, postIDs, language)
+}
+
+// StartAI - Create or reset a translation as pending; nil when one is already pending
+func (r *TranslationRepository) StartAI(ctx context.Context, postID, language, requestedBy string) (*model.PostTranslation, error) {
+	query := `
+		INSERT INTO post_translations (post_id, language, source, status, translated_by)
+		VALUES ($1, $2, 'ai', 'pending', $3)
+		ON CONFLICT (post_id, language) DO UPDATE
+		SET status = 'pending', error = NULL, translated_by = EXCLUDED.translated_by, updated_at = CURRENT_TIMESTAMP
+		WHERE post_translations.status <> 'pending'
+		RETURNING ` + translationColumns
+
+	translation, err := scanTranslation(r.db.QueryRow(ctx, query, postID, language, requestedBy))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to start translation: %w", err)
+	}
+	return translation, nil
+}
+
+// CompleteAI - Save the AI's text; false when the translation stopped being pending (e.g. edited by hand)
+func (r *TranslationRepository) CompleteAI(ctx context.Context, translation *model.PostTranslation) (bool, error) {
+	query := `
+		UPDATE post_translations
+		SET title = $2, content = $3, tags = $4, source = 'ai', status = 'ready', error = NULL,
+			model = $5, source_updated_at = $6, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending'
+	`
+
+	result, err := r.db.Exec(
+		ctx,
+		query,
+		translation.ID,
+		translation.Title,
+		translation.Content,
+		translation.Tags,
+		translation.Model,
+		translation.SourceUpdatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to save translation: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// FailAI - Mark a pending translation as failed; earlier text stays readable
+func (r *TranslationRepository) FailAI(ctx context.Context, translationID, reason string) error {
+	query := `
+		UPDATE post_translations
+		SET status = 'failed', error = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending'
+	`
+
+	if _, err := r.db.Exec(ctx, query, translationID, reason); err != nil {
+		return fmt.Errorf("failed to mark translation failed: %w", err)
+	}
+	return nil
+}
+
+// FailPending - Close translations a previous process left pending
+func (r *TranslationRepository) FailPending(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE post_translations
+		SET status = 'failed', error = 'interrupted by a server restart', updated_at = CURRENT_TIMESTAMP
+		WHERE status = 'pending'
+	`
+
+	result, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to close pending translations: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// SaveManual - Create or replace a translation written by hand
+func (r *TranslationRepository) SaveManual(ctx context.Context, translation *model.PostTranslation) error {
+	query := `
+		INSERT INTO post_translations
+			(post_id, language, title, content, tags, source, status, translated_by, source_updated_at)
+		VALUES ($1, $2, $3, $4, $5, 'manual', 'ready', $6, $7)
+		ON CONFLICT (post_id, language) DO UPDATE
+		SET title = EXCLUDED.title, content = EXCLUDED.content, tags = EXCLUDED.tags,
+			source = 'manual', status = 'ready', error = NULL, model = NULL,
+			translated_by = EXCLUDED.translated_by, source_updated_at = EXCLUDED.source_updated_at,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING ` + translationColumns
+
+	saved, err := scanTranslation(r.db.QueryRow(
+		ctx,
+		query,
+		translation.PostID,
+		translation.Language,
+		translation.Title,
+		translation.Content,
+		translation.Tags,
+		translation.TranslatedBy,
+		translation.SourceUpdatedAt,
+	))
+	if err != nil {
+		return fmt.Errorf("failed to save translation: %w", err)
+	}
+
+	*translation = *saved
+	return nil
+}
+
+// Delete - Remove one translation of a post
+func (r *TranslationRepository) Delete(ctx context.Context, postID, language string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM post_translations WHERE post_id = $1 AND language = $2`, postID, language)
+	if err != nil {
+		return fmt.Errorf("failed to delete translation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("translation not found")
+	}
+
+	return nil
+}
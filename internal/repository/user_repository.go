@@ -2,12 +2,12 @@ package repository
 
 import (
 	"context"
-	"log"
 	// "database/sql"
 	"errors"
 	"fmt"
 
 	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
 	"github.com/britinogn/quillhub/pkg/utils"
 
 	//"github.com/google/uuid"
@@ -28,22 +28,26 @@ func NewUserRepository(db *pgxpool.Pool) *UserRepository {
 	return &UserRepository{db: db}
 }
 
+// Create - Save a new user; an empty password leaves the account without a usable one
 func (u *UserRepository) Create(ctx context.Context, user *model.User) error {
     query := `
-        INSERT INTO users(name, username, email, password, role)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO users(name, username, email, password, role, password_set)
+        VALUES ($1, $2, $3, $4, $5, $6)
         RETURNING id, created_at, updated_at
     `
 
-
-    // Hash password
-    hashedPassword, err := utils.HashPassword(user.Password)
-    if err != nil {
-        return fmt.Errorf("failed to hash password: %w", err)
+    // Hash password; "!" is not a bcrypt hash, so nothing signs in with it
+    user.PasswordSet = user.Password != ""
+    hashedPassword := "!"
+    if user.PasswordSet {
+        var err error
+        if hashedPassword, err = utils.HashPassword(user.Password); err != nil {
+            return fmt.Errorf("failed to hash password: %w", err)
+        }
     }
 
     // Execute query and scan the returned values
-    err = u.db.QueryRow(
+    err := u.db.QueryRow(
         ctx,
         query,
         user.Name,
@@ -51,6 +55,7 @@ func (u *UserRepository) Create(ctx context.Context, user *model.User) error {
         user.Email,
         hashedPassword,
         user.Role,
+        user.PasswordSet,
     ).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
 
     if err != nil {
@@ -114,6 +119,59 @@ func (u *UserRepository) FindByUsername(ctx context.Context, username string) (*
 }
 
 
+// FindByID - Get a user by ID
+func (u *UserRepository) FindByID(ctx context.Context, userID string) (*model.User, error) {
+    query := `
+        SELECT id, name, username, email, password, password_set, role, profile_url, created_at, updated_at
+        FROM users
+        WHERE id = $1
+    `
+
+    var user model.User
+    err := u.db.QueryRow(ctx, query, userID).Scan(
+        &user.ID,
+        &user.Name,
+        &user.Username,
+        &user.Email,
+        &user.Password,
+        &user.PasswordSet,
+        &user.Role,
+        &user.ProfileURL,
+        &user.CreatedAt,
+        &user.UpdatedAt,
+    )
+
+    if err != nil {
+        if errors.Is(err, pgx.ErrNoRows) {
+            return nil, nil
+        }
+        return nil, fmt.Errorf("failed to find user by id: %w", err)
+    }
+
+    return &user, nil
+}
+
+// UpdateRole - Change a user's role
+func (u *UserRepository) UpdateRole(ctx context.Context, userID, role string) error {
+    query := `
+        UPDATE users
+        SET role = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2
+    `
+
+    result, err := u.db.Exec(ctx, query, role, userID)
+    if err != nil {
+        return fmt.Errorf("failed to update role: %w", err)
+    }
+
+    if result.RowsAffected() == 0 {
+        return errors.New("user not found")
+    }
+
+    logger.Infof(ctx, "[USER-REPO] Role of user %s changed to %s", userID, role)
+    return nil
+}
+
 // GetOrCreateAIBot - Get existing AI bot or create new one
 func (u *UserRepository) GetOrCreateAIBot(ctx context.Context) (string, error) {
 	// Check if AI bot user exists
@@ -122,7 +180,7 @@ func (u *UserRepository) GetOrCreateAIBot(ctx context.Context) (string, error) {
 	err := u.db.QueryRow(ctx, query).Scan(&userID)
 	
 	if err == nil {
-		log.Printf("[USER-REPO] AI Bot user already exists: %s", userID)
+		logger.Infof(ctx, "[USER-REPO] AI Bot user already exists: %s", userID)
 		return userID, nil
 	}
 	
@@ -151,6 +209,6 @@ func (u *UserRepository) GetOrCreateAIBot(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("failed to create AI bot user: %w", err)
 	}
 	
-	log.Printf("[USER-REPO] ✅ Created new AI Bot user: %s", userID)
+	logger.Infof(ctx, "[USER-REPO] ✅ Created new AI Bot user: %s", userID)
 	return userID, nil
 }
\ No newline at end of file
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/britinogn/quillhub/internal/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAccountRoutes(protected *gin.RouterGroup, accountHandler *handlers.AccountHandler) {
+	me := protected.Group("/me")
+	{
+		me.DELETE("", accountHandler.DeleteAccount)
+		me.GET("/deletion", accountHandler.GetDeletion)
+		me.DELETE("/deletion", accountHandler.CancelDeletion)
+
+		me.POST("/export", accountHandler.RequestExport)
+		me.GET("/exports", accountHandler.ListExports)
+		me.GET("/exports/:id", accountHandler.GetExport)
+		me.GET("/exports/:id/download", accountHandler.DownloadExport)
+	}
+}
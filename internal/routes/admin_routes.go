@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"github.com/britinogn/quillhub/internal/handlers"
+	"github.com/britinogn/quillhub/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAdminRoutes(protected *gin.RouterGroup, authHandler *handlers.AuthHandler, auditHandler *handlers.AuditHandler, inviteHandler *handlers.InviteHandler, autoPosterHandler *handlers.AutoPosterHandler, topicHandler *handlers.TopicHandler, generationJobHandler *handlers.GenerationJobHandler, postReviewHandler *handlers.PostReviewHandler, aiUsageHandler *handlers.AIUsageHandler, personaHandler *handlers.PersonaHandler) {
+	admin := protected.Group("/admin")
+	admin.Use(middleware.AdminOnly())
+	{
+		admin.GET("/audit-logs", auditHandler.GetAuditLogs)
+		admin.GET("/audit-logs/export", auditHandler.ExportAuditLogs)
+
+		admin.PATCH("/users/:id/role", authHandler.ChangeUserRole)
+
+		admin.POST("/invites", inviteHandler.CreateInvite)
+		admin.GET("/invites", inviteHandler.ListInvites)
+		admin.DELETE("/invites/:id", inviteHandler.RevokeInvite)
+
+		admin.GET("/auto-poster", autoPosterHandler.GetStatus)
+		admin.POST("/auto-poster/start", autoPosterHandler.Start)
+		admin.POST("/auto-poster/stop", autoPosterHandler.Stop)
+		admin.POST("/auto-poster/run", autoPosterHandler.PostNow)
+		admin.PUT("/auto-poster/schedule", autoPosterHandler.UpdateSchedule)
+
+		admin.GET("/ai/topics", topicHandler.ListTopics)
+		admin.POST("/ai/topics", topicHandler.CreateTopic)
+		admin.PATCH("/ai/topics/:id", topicHandler.UpdateTopic)
+		admin.DELETE("/ai/topics/:id", topicHandler.DeleteTopic)
+		admin.GET("/ai/categories", topicHandler.ListCategories)
+		admin.POST("/ai/categories", topicHandler.CreateCategory)
+		admin.PATCH("/ai/categories/:id", topicHandler.UpdateCategory)
+		admin.DELETE("/ai/categories/:id", topicHandler.DeleteCategory)
+
+		admin.GET("/ai/personas", personaHandler.ListPersonas)
+		admin.POST("/ai/personas", personaHandler.CreatePersona)
+		admin.GET("/ai/personas/:id", personaHandler.GetPersona)
+		admin.PATCH("/ai/personas/:id", personaHandler.UpdatePersona)
+		admin.DELETE("/ai/personas/:id", personaHandler.DeletePersona)
+		admin.POST("/ai/personas/:id/preview", personaHandler.PreviewPrompt)
+
+		admin.GET("/ai/jobs", generationJobHandler.ListJobs)
+		admin.GET("/ai/jobs/:id", generationJobHandler.GetJob)
+
+		admin.GET("/ai/usage", aiUsageHandler.GetUsage)
+		admin.GET("/ai/budget", aiUsageHandler.GetBudget)
+
+		admin.GET("/reviews", postReviewHandler.ListQueue)
+		admin.GET("/reviews/:id", postReviewHandler.GetPost)
+		admin.PATCH("/reviews/:id", postReviewHandler.EditPost)
+		admin.POST("/reviews/:id/approve", postReviewHandler.ApprovePost)
+		admin.POST("/reviews/:id/reject", postReviewHandler.RejectPost)
+		admin.POST("/reviews/:id/regenerate", postReviewHandler.RegeneratePost)
+	}
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/britinogn/quillhub/internal/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAssistantRoutes(protected *gin.RouterGroup, assistantHandler *handlers.AssistantHandler) {
+	assist := protected.Group("/ai/assist")
+	{
+		assist.GET("/quota", assistantHandler.GetQuota)
+		assist.POST("/titles", assistantHandler.SuggestTitles)
+		assist.POST("/summary", assistantHandler.Summarize)
+		assist.POST("/tags", assistantHandler.SuggestTags)
+		assist.POST("/rewrite", assistantHandler.Rewrite)
+	}
+}
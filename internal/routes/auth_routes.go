@@ -6,13 +6,16 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterAuthRoutes(rg *gin.RouterGroup, authHandler *handlers.AuthHandler) {
-	auth := rg.Group("/auth")
+func RegisterAuthRoutes(public *gin.RouterGroup, protected *gin.RouterGroup, authHandler *handlers.AuthHandler, limits middleware.RateLimits) {
+	auth := public.Group("/auth")
 	{
-		auth.POST("/signup", authHandler.Register)
-		auth.POST("/login", authHandler.Login)
+		auth.POST("/signup", limits.Auth, authHandler.Register)
+		auth.POST("/login", limits.Auth, authHandler.Login)
+		auth.GET("/registration", authHandler.GetRegistrationInfo)
 	}
 
-	auth.Use(middleware.AdminOnly())
-	auth.POST("/admins", authHandler.RegisterAdmin)
+	// Admin creation needs the caller's token, so it lives on the protected group
+	admins := protected.Group("/auth/admins")
+	admins.Use(middleware.AdminOnly())
+	admins.POST("", authHandler.RegisterAdmin)
 }
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/britinogn/quillhub/internal/handlers"
+	"github.com/britinogn/quillhub/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterModerationRoutes(protected *gin.RouterGroup, moderationHandler *handlers.ModerationHandler) {
+	moderation := protected.Group("/moderation")
+	moderation.Use(middleware.ModeratorOnly())
+	{
+		moderation.GET("/comments", moderationHandler.ListQueue)
+		moderation.POST("/comments/:id/approve", moderationHandler.ApproveComment)
+		moderation.POST("/comments/:id/reject", moderationHandler.RejectComment)
+	}
+}
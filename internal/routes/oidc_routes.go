@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/britinogn/quillhub/internal/handlers"
+	"github.com/britinogn/quillhub/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterOIDCRoutes(
+	public *gin.RouterGroup,
+	protected *gin.RouterGroup,
+	oidcHandler *handlers.OIDCHandler,
+	limits middleware.RateLimits,
+) {
+
+	// Public
+	oidc := public.Group("/auth/oidc")
+	{
+		oidc.GET("/providers", oidcHandler.ListProviders)
+		oidc.GET("/:provider/login", limits.Auth, oidcHandler.Login)
+		oidc.GET("/:provider/callback", limits.Auth, oidcHandler.Callback)
+	}
+
+	// Protected
+	identities := protected.Group("/me/identities")
+	{
+		identities.GET("", oidcHandler.ListIdentities)
+		identities.POST("/:provider", oidcHandler.LinkIdentity)
+		identities.DELETE("/:id", oidcHandler.UnlinkIdentity)
+	}
+}
@@ -2,23 +2,32 @@ package routes
 
 import (
 	"github.com/britinogn/quillhub/internal/handlers"
+	"github.com/britinogn/quillhub/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
 func RegisterPostRoutes(
 	public *gin.RouterGroup,
 	protected *gin.RouterGroup,
+	limits middleware.RateLimits,
 	postHandler *handlers.PostHandler,
 	commentHandler *handlers.CommentHandler,
+	searchHandler *handlers.SearchHandler,
+	translationHandler *handlers.TranslationHandler,
+	postImageHandler *handlers.PostImageHandler,
 ) {
 
 	// Public
 	publicPosts := public.Group("/posts")
 	{
 		publicPosts.GET("", postHandler.GetAllPosts)
+		publicPosts.GET("/search", searchHandler.Search)
 		publicPosts.GET("/:id", postHandler.GetPostById)
 		publicPosts.GET("/author/:authorId", postHandler.GetPostsByAuthorID)
 		publicPosts.GET("/:id/comments", commentHandler.GetCommentsByPostID)
+		publicPosts.GET("/:id/related", searchHandler.Related)
+		publicPosts.GET("/:id/translations", translationHandler.ListTranslations)
+		publicPosts.GET("/:id/translations/:lang", translationHandler.GetTranslation)
 	}
 
 	// Protected
@@ -28,6 +37,15 @@ func RegisterPostRoutes(
 		protectedPosts.PUT("/:id", postHandler.Update)
 		protectedPosts.DELETE("/:id", postHandler.Delete)
 
-		protectedPosts.POST("/:postId/comments", commentHandler.CreateComment)
+		protectedPosts.POST("/:postId/comments", limits.Comment, commentHandler.CreateComment)
+
+		// Translations: AI requests by the author or an admin, hand edits by the author
+		protectedPosts.POST("/:postId/translations", translationHandler.RequestTranslation)
+		protectedPosts.PUT("/:id/translations/:lang", translationHandler.UpdateTranslation)
+		protectedPosts.DELETE("/:id/translations/:lang", translationHandler.DeleteTranslation)
+
+		// Image alt text and captions: author overrides and fresh AI descriptions
+		protectedPosts.PATCH("/:id/images/:imageId", postImageHandler.UpdateImage)
+		protectedPosts.POST("/:postId/images/:imageId/describe", postImageHandler.RedescribeImage)
 	}
 }
@@ -10,13 +10,32 @@ import (
 
 func RegisterRoutes(
 	router *gin.Engine,
+	sessionValidator middleware.SessionValidator,
+	limits middleware.RateLimits,
 	authHandler *handlers.AuthHandler,
 	postHandler *handlers.PostHandler,
 	commentHandler *handlers.CommentHandler,
 	dashboardHandler *handlers.DashboardHandler,
+	oidcHandler *handlers.OIDCHandler,
+	sessionHandler *handlers.SessionHandler,
+	auditHandler *handlers.AuditHandler,
+	inviteHandler *handlers.InviteHandler,
+	accountHandler *handlers.AccountHandler,
+	autoPosterHandler *handlers.AutoPosterHandler,
+	topicHandler *handlers.TopicHandler,
+	generationJobHandler *handlers.GenerationJobHandler,
+	postReviewHandler *handlers.PostReviewHandler,
+	assistantHandler *handlers.AssistantHandler,
+	searchHandler *handlers.SearchHandler,
+	moderationHandler *handlers.ModerationHandler,
+	aiUsageHandler *handlers.AIUsageHandler,
+	personaHandler *handlers.PersonaHandler,
+	translationHandler *handlers.TranslationHandler,
+	postImageHandler *handlers.PostImageHandler,
 ) {
 
 	api := router.Group("/api")
+	api.Use(limits.Read)
 
 	// Health
 	api.GET("/health", func(c *gin.Context) {
@@ -37,12 +56,18 @@ func RegisterRoutes(
 
 	// Protected
 	protected := api.Group("")
-	protected.Use(middleware.AuthMiddleware())
+	protected.Use(middleware.AuthMiddleware(sessionValidator), limits.Write)
 	// protected.Use(middleware.AdminOnly())
 
 	// Register separated routes
-	RegisterAuthRoutes(public, authHandler)
-	RegisterPostRoutes(public, protected, postHandler, commentHandler)
+	RegisterAuthRoutes(public, protected, authHandler, limits)
+	RegisterPostRoutes(public, protected, limits, postHandler, commentHandler, searchHandler, translationHandler, postImageHandler)
 	RegisterCommentRoutes(public, protected, commentHandler)
 	RegisterDashboardRoutes(protected, dashboardHandler)
+	RegisterOIDCRoutes(public, protected, oidcHandler, limits)
+	RegisterSessionRoutes(protected, sessionHandler)
+	RegisterAccountRoutes(protected, accountHandler)
+	RegisterAssistantRoutes(protected, assistantHandler)
+	RegisterModerationRoutes(protected, moderationHandler)
+	RegisterAdminRoutes(protected, authHandler, auditHandler, inviteHandler, autoPosterHandler, topicHandler, generationJobHandler, postReviewHandler, aiUsageHandler, personaHandler)
 }
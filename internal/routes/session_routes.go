@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/britinogn/quillhub/internal/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterSessionRoutes(protected *gin.RouterGroup, sessionHandler *handlers.SessionHandler) {
+	protected.POST("/auth/logout", sessionHandler.Logout)
+
+	sessions := protected.Group("/me/sessions")
+	{
+		sessions.GET("", sessionHandler.ListSessions)
+		sessions.DELETE("", sessionHandler.RevokeAllSessions)
+		sessions.DELETE("/:id", sessionHandler.RevokeSession)
+	}
+}
@@ -0,0 +1,800 @@
+// internal/services/account_service.go
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/britinogn/quillhub/pkg/utils"
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Account deletion modes
+const (
+	DeletionModeAnonymize = "anonymize"
+	DeletionModeDelete    = "delete"
+)
+
+// Data export statuses
+const (
+	ExportStatusPending    = "pending"
+	ExportStatusProcessing = "processing"
+	ExportStatusCompleted  = "completed"
+	ExportStatusFailed     = "failed"
+)
+
+// maxExportImageBytes - Images larger than this are listed by URL instead of copied into the archive
+const maxExportImageBytes = 20 << 20
+
+// cloudinaryImageHost - Where Cloudinary serves uploaded images (https://res.cloudinary.com/<cloud>/image/upload/...)
+const cloudinaryImageHost = "res.cloudinary.com"
+
+var (
+	ErrExportInProgress    = errors.New("a data export is already in progress")
+	ErrExportNotFound      = errors.New("data export not found")
+	ErrExportNotReady      = errors.New("data export is not ready for download")
+	ErrInvalidDeletionMode = errors.New("deletion mode must be 'anonymize' or 'delete'")
+	ErrNoPendingDeletion   = errors.New("no pending account deletion")
+	ErrAccountNotDeletable = errors.New("this account cannot be deleted")
+)
+
+type AccountRepo interface {
+	CreateExport(ctx context.Context, userID string) (*model.DataExport, error)
+	FindExport(ctx context.Context, exportID, userID string) (*model.DataExport, error)
+	ListExports(ctx context.Context, userID string) ([]*model.DataExport, error)
+	HasActiveExport(ctx context.Context, userID string) (bool, error)
+	ClaimPendingExport(ctx context.Context) (*model.DataExport, error)
+	RequeueStaleExports(ctx context.Context) (int64, error)
+	CompleteExport(ctx context.Context, exportID, filePath string, fileSize int64, expiresAt time.Time) error
+	FailExport(ctx context.Context, exportID, reason string) error
+	DeleteExpiredExports(ctx context.Context) ([]string, error)
+	DeleteExportsByUserID(ctx context.Context, userID string) ([]string, error)
+	GetProfile(ctx context.Context, userID string) (*model.UserProfile, error)
+	GetCommentsByAuthor(ctx context.Context, userID string) ([]*model.Comment, error)
+	GetLikesByUser(ctx context.Context, userID string) ([]model.UserLike, error)
+	ScheduleDeletion(ctx context.Context, userID, mode string, scheduledFor time.Time) (*model.AccountDeletion, error)
+	FindDeletion(ctx context.Context, userID string) (*model.AccountDeletion, error)
+	CancelDeletion(ctx context.Context, userID string) (bool, error)
+	FindDueDeletions(ctx context.Context, limit int) ([]*model.AccountDeletion, error)
+	HardDelete(ctx context.Context, userID string) error
+	Anonymize(ctx context.Context, userID, username, email, passwordHash string) error
+}
+
+// AccountConfig - Where exports are written and how long deletions wait
+type AccountConfig struct {
+	ExportDir       string
+	ExportTTL       time.Duration
+	DeletionCoolOff time.Duration
+	CloudName       string // exports copy images from this Cloudinary cloud only
+}
+
+// LoadAccountConfig - The export and deletion settings from the app config
+func LoadAccountConfig(app config.AccountConfig, cld config.CloudinaryConfig) AccountConfig {
+	return AccountConfig{
+		ExportDir:       app.ExportDir,
+		ExportTTL:       app.ExportTTL,
+		DeletionCoolOff: app.DeletionCoolOff,
+		CloudName:       cld.CloudName,
+	}
+}
+
+type AccountService struct {
+	repo       AccountRepo
+	posts      PostRepo
+	cld        *cloudinary.Cloudinary
+	audit      *AuditService
+	config     AccountConfig
+	httpClient *http.Client
+	wake       chan struct{}
+	stopChan   chan struct{}
+	mu         sync.Mutex
+	isRunning  bool
+}
+
+func NewAccountService(repo AccountRepo, posts PostRepo, cld *cloudinary.Cloudinary, audit *AuditService, config AccountConfig) *AccountService {
+	return &AccountService{
+		repo:   repo,
+		posts:  posts,
+		cld:    cld,
+		audit:  audit,
+		config: config,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+			// A redirect must not lead the download anywhere the first URL could not go
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 5 {
+					return errors.New("too many redirects")
+				}
+				return checkExportImageURL(req.URL, config.CloudName)
+			},
+		},
+		wake:     make(chan struct{}, 1),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start - Run the background worker that builds exports and finalizes deletions
+func (s *AccountService) Start() {
+	s.mu.Lock()
+	if s.isRunning {
+		s.mu.Unlock()
+		return
+	}
+	s.isRunning = true
+	s.mu.Unlock()
+
+	if err := os.MkdirAll(s.config.ExportDir, 0o750); err != nil {
+		logger.Warnf(context.Background(), "[ACCOUNT-SERVICE] ⚠️  Cannot create export directory %s: %v", s.config.ExportDir, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if requeued, err := s.repo.RequeueStaleExports(ctx); err != nil {
+		logger.Errorf(ctx, "[ACCOUNT-SERVICE] Failed to requeue stale exports: %v", err)
+	} else if requeued > 0 {
+		logger.Infof(ctx, "[ACCOUNT-SERVICE] Requeued %d interrupted exports", requeued)
+	}
+	cancel()
+
+	ticker := time.NewTicker(time.Minute)
+	go func() {
+		s.runMaintenance()
+		for {
+			select {
+			case <-s.wake:
+				s.processExports()
+			case <-ticker.C:
+				s.runMaintenance()
+			case <-s.stopChan:
+				ticker.Stop()
+				s.mu.Lock()
+				s.isRunning = false
+				s.mu.Unlock()
+				logger.Infof(ctx, "[ACCOUNT-SERVICE] ⏹️  Background worker stopped")
+				return
+			}
+		}
+	}()
+
+	logger.Infof(ctx, "[ACCOUNT-SERVICE] ✅ Background worker started (exports: %s, deletion cool-off: %s)", s.config.ExportDir, s.config.DeletionCoolOff)
+}
+
+// Stop - Stop the background worker
+func (s *AccountService) Stop() {
+	s.mu.Lock()
+	running := s.isRunning
+	s.mu.Unlock()
+
+	if running {
+		s.stopChan <- struct{}{}
+	}
+}
+
+func (s *AccountService) runMaintenance() {
+	s.processExports()
+	s.finalizeDueDeletions()
+	s.purgeExpiredExports()
+}
+
+// notify - Wake the worker without blocking the request
+func (s *AccountService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// RequestExport - Queue a ZIP of the user's data; built in the background
+func (s *AccountService) RequestExport(ctx context.Context, userID string) (*model.DataExportResponse, error) {
+	active, err := s.repo.HasActiveExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return nil, ErrExportInProgress
+	}
+
+	export, err := s.repo.CreateExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionExportRequest,
+		TargetType: "user",
+		TargetID:   userID,
+	})
+
+	s.notify()
+
+	response := toDataExportResponse(export)
+	return &response, nil
+}
+
+// ListExports - The user's exports, newest first
+func (s *AccountService) ListExports(ctx context.Context, userID string) ([]model.DataExportResponse, error) {
+	exports, err := s.repo.ListExports(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.DataExportResponse, 0, len(exports))
+	for _, export := range exports {
+		responses = append(responses, toDataExportResponse(export))
+	}
+	return responses, nil
+}
+
+// GetExport - Status of one export
+func (s *AccountService) GetExport(ctx context.Context, userID, exportID string) (*model.DataExportResponse, error) {
+	export, err := s.findExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toDataExportResponse(export)
+	return &response, nil
+}
+
+// ExportFile - Path of a finished archive for download
+func (s *AccountService) ExportFile(ctx context.Context, userID, exportID string) (string, error) {
+	export, err := s.findExport(ctx, userID, exportID)
+	if err != nil {
+		return "", err
+	}
+
+	if export.Status != ExportStatusCompleted || export.FilePath == nil {
+		return "", ErrExportNotReady
+	}
+	if export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt) {
+		return "", ErrExportNotFound
+	}
+
+	return *export.FilePath, nil
+}
+
+func (s *AccountService) findExport(ctx context.Context, userID, exportID string) (*model.DataExport, error) {
+	var exportUUID pgtype.UUID
+	if err := exportUUID.Scan(exportID); err != nil {
+		return nil, ErrExportNotFound
+	}
+
+	export, err := s.repo.FindExport(ctx, exportID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if export == nil {
+		return nil, ErrExportNotFound
+	}
+	return export, nil
+}
+
+// processExports - Build every queued export
+func (s *AccountService) processExports() {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		export, err := s.repo.ClaimPendingExport(ctx)
+		if err != nil {
+			logger.Errorf(ctx, "[ACCOUNT-SERVICE] ❌ Failed to claim export: %v", err)
+			cancel()
+			return
+		}
+		if export == nil {
+			cancel()
+			return
+		}
+
+		s.buildExport(ctx, export)
+		cancel()
+	}
+}
+
+func (s *AccountService) buildExport(ctx context.Context, export *model.DataExport) {
+	exportID := export.ID.String()
+	userID := export.UserID.String()
+	logger.Infof(ctx, "[ACCOUNT-SERVICE] 📦 Building export %s for user %s", exportID, userID)
+
+	filePath := filepath.Join(s.config.ExportDir, exportID+".zip")
+	size, err := s.writeArchive(ctx, userID, filePath)
+	if err != nil {
+		_ = os.Remove(filePath)
+		logger.Errorf(ctx, "[ACCOUNT-SERVICE] ❌ Export %s failed: %v", exportID, err)
+		if failErr := s.repo.FailExport(ctx, exportID, "failed to build export"); failErr != nil {
+			logger.Errorf(ctx, "[ACCOUNT-SERVICE] Failed to mark export %s failed: %v", exportID, failErr)
+		}
+		return
+	}
+
+	if err := s.repo.CompleteExport(ctx, exportID, filePath, size, time.Now().Add(s.config.ExportTTL)); err != nil {
+		_ = os.Remove(filePath)
+		logger.Errorf(ctx, "[ACCOUNT-SERVICE] ❌ Failed to complete export %s: %v", exportID, err)
+		return
+	}
+
+	logger.Infof(ctx, "[ACCOUNT-SERVICE] ✅ Export %s ready (%d bytes)", exportID, size)
+}
+
+// writeArchive - ZIP with JSON files, one Markdown file per post and copies of post images
+func (s *AccountService) writeArchive(ctx context.Context, userID, filePath string) (int64, error) {
+	profile, err := s.repo.GetProfile(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if profile == nil {
+		return 0, ErrUserNotFound
+	}
+
+	posts, err := s.posts.FindByAuthorID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	comments, err := s.repo.GetCommentsByAuthor(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	likes, err := s.repo.GetLikesByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(s.config.ExportDir, 0o750); err != nil {
+		return 0, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+
+	postResponses := make([]model.PostResponse, 0, len(posts))
+	for _, post := range posts {
+		postResponses = append(postResponses, model.PostResponse{
+			ID:          post.ID.String(),
+			Title:       post.Title,
+			Content:     post.Content,
+			AuthorID:    post.AuthorID.String(),
+			ImageURL:    post.ImageURL,
+			Tags:        post.Tags,
+			Category:    post.Category,
+			IsPublished: post.IsPublished,
+			ViewCount:   post.ViewCount,
+			AIGenerated: post.AIGenerated,
+			CreatedAt:   post.CreatedAt,
+			UpdatedAt:   post.UpdatedAt,
+		})
+	}
+
+	commentResponses := make([]model.CommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		commentResponses = append(commentResponses, model.CommentResponse{
+			ID:        comment.ID.String(),
+			PostID:    comment.PostID.String(),
+			AuthorID:  comment.AuthorID.String(),
+			Text:      comment.Text,
+			CreatedAt: comment.CreatedAt,
+			UpdatedAt: comment.UpdatedAt,
+		})
+	}
+
+	if likes == nil {
+		likes = []model.UserLike{}
+	}
+
+	jsonFiles := []struct {
+		name  string
+		value any
+	}{
+		{"profile.json", profile},
+		{"posts.json", postResponses},
+		{"comments.json", commentResponses},
+		{"likes.json", likes},
+	}
+	for _, f := range jsonFiles {
+		if err := writeZipJSON(archive, f.name, f.value); err != nil {
+			return 0, err
+		}
+	}
+
+	var skippedImages []string
+	for _, post := range postResponses {
+		if err := writeZipFile(archive, path.Join("posts", postMarkdownName(post)), []byte(postMarkdown(post))); err != nil {
+			return 0, err
+		}
+
+		for i, imageURL := range post.ImageURL {
+			name := path.Join("images", fmt.Sprintf("%s-%d%s", post.ID, i+1, path.Ext(imageURL)))
+			if err := s.copyImage(ctx, archive, name, imageURL); err != nil {
+				logger.Infof(ctx, "[ACCOUNT-SERVICE] Skipping image %s: %v", imageURL, err)
+				skippedImages = append(skippedImages, imageURL)
+			}
+		}
+	}
+
+	readme := exportReadme(profile, len(postResponses), len(commentResponses), len(likes), skippedImages)
+	if err := writeZipFile(archive, "README.md", []byte(readme)); err != nil {
+		return 0, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return 0, fmt.Errorf("failed to finalize export archive: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat export archive: %w", err)
+	}
+	return info.Size(), nil
+}
+
+// copyImage - Download an image into the archive; only images in the app's Cloudinary cloud are
+// fetched, so a stored URL cannot make the server request anything else
+func (s *AccountService) copyImage(ctx context.Context, archive *zip.Writer, name, imageURL string) error {
+	parsed, err := url.Parse(imageURL)
+	if err != nil {
+		return err
+	}
+	if err := checkExportImageURL(parsed, s.config.CloudName); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if resp.ContentLength > maxExportImageBytes {
+		return fmt.Errorf("image too large (%d bytes)", resp.ContentLength)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxExportImageBytes+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxExportImageBytes {
+		return errors.New("image too large")
+	}
+
+	return writeZipFile(archive, name, data)
+}
+
+// checkExportImageURL - An https URL of an image in the given Cloudinary cloud
+func checkExportImageURL(u *url.URL, cloudName string) error {
+	if cloudName == "" {
+		return errors.New("no Cloudinary cloud is configured")
+	}
+	if u.Scheme != "https" || u.Host != cloudinaryImageHost || !strings.HasPrefix(u.Path, "/"+cloudName+"/") {
+		return fmt.Errorf("not an image of Cloudinary cloud %q", cloudName)
+	}
+	return nil
+}
+
+// purgeExpiredExports - Remove expired archives from disk
+func (s *AccountService) purgeExpiredExports() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	paths, err := s.repo.DeleteExpiredExports(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "[ACCOUNT-SERVICE] Failed to purge expired exports: %v", err)
+		return
+	}
+	removeFiles(paths)
+}
+
+// RequestDeletion - Schedule account deletion after the cool-off period (immediately if it is 0)
+func (s *AccountService) RequestDeletion(ctx context.Context, userID, mode string) (*model.AccountDeletionResponse, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		mode = DeletionModeAnonymize
+	}
+	if mode != DeletionModeAnonymize && mode != DeletionModeDelete {
+		return nil, ErrInvalidDeletionMode
+	}
+
+	profile, err := s.repo.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, ErrUserNotFound
+	}
+	if profile.Role == "bot" {
+		return nil, ErrAccountNotDeletable
+	}
+
+	deletion, err := s.repo.ScheduleDeletion(ctx, userID, mode, time.Now().Add(s.config.DeletionCoolOff))
+	if err != nil {
+		return nil, err
+	}
+	if deletion == nil {
+		return nil, ErrUserNotFound
+	}
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAccountDeleteRequest,
+		TargetType: "user",
+		TargetID:   userID,
+		After:      map[string]any{"mode": mode, "scheduled_for": deletion.ScheduledFor},
+	})
+
+	if s.config.DeletionCoolOff == 0 {
+		if err := s.finalizeDeletion(ctx, deletion); err != nil {
+			return nil, err
+		}
+		return &model.AccountDeletionResponse{Mode: mode, Status: "completed"}, nil
+	}
+
+	logger.Infof(ctx, "[ACCOUNT-SERVICE] Account %s scheduled for %s on %s", userID, mode, deletion.ScheduledFor.Format(time.RFC3339))
+	return toAccountDeletionResponse(deletion), nil
+}
+
+// GetDeletion - The user's pending deletion request
+func (s *AccountService) GetDeletion(ctx context.Context, userID string) (*model.AccountDeletionResponse, error) {
+	deletion, err := s.repo.FindDeletion(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if deletion == nil {
+		return nil, ErrNoPendingDeletion
+	}
+	return toAccountDeletionResponse(deletion), nil
+}
+
+// CancelDeletion - Keep the account during the cool-off period
+func (s *AccountService) CancelDeletion(ctx context.Context, userID string) error {
+	cancelled, err := s.repo.CancelDeletion(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return ErrNoPendingDeletion
+	}
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAccountDeleteCancel,
+		TargetType: "user",
+		TargetID:   userID,
+	})
+	return nil
+}
+
+// finalizeDueDeletions - Apply deletions whose cool-off period has ended
+func (s *AccountService) finalizeDueDeletions() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	deletions, err := s.repo.FindDueDeletions(ctx, 50)
+	if err != nil {
+		logger.Errorf(ctx, "[ACCOUNT-SERVICE] Failed to fetch due deletions: %v", err)
+		return
+	}
+
+	for _, deletion := range deletions {
+		if err := s.finalizeDeletion(ctx, deletion); err != nil {
+			logger.Errorf(ctx, "[ACCOUNT-SERVICE] ❌ Failed to finalize deletion of %s: %v", deletion.UserID.String(), err)
+		}
+	}
+}
+
+func (s *AccountService) finalizeDeletion(ctx context.Context, deletion *model.AccountDeletion) error {
+	userID := deletion.UserID.String()
+
+	exportFiles, err := s.repo.DeleteExportsByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	removeFiles(exportFiles)
+
+	switch deletion.Mode {
+	case DeletionModeDelete:
+		posts, err := s.posts.FindByAuthorID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.HardDelete(ctx, userID); err != nil {
+			return err
+		}
+
+		for _, post := range posts {
+			s.destroyImages(ctx, post.ImageURL)
+		}
+
+		s.audit.Record(ctx, AuditEvent{
+			Action:     AuditActionAccountDelete,
+			TargetType: "user",
+			TargetID:   userID,
+			ActorID:    userID,
+			After:      map[string]any{"posts_deleted": len(posts)},
+		})
+
+	default:
+		secret, err := randomToken(32)
+		if err != nil {
+			return err
+		}
+		passwordHash, err := utils.HashPassword(secret)
+		if err != nil {
+			return fmt.Errorf("failed to hash password: %w", err)
+		}
+
+		// Placeholders keep the unique username/email columns satisfied
+		placeholder := "deleted-" + strings.ReplaceAll(userID, "-", "")
+		if err := s.repo.Anonymize(ctx, userID, placeholder, placeholder+"@deleted.invalid", passwordHash); err != nil {
+			return err
+		}
+
+		s.audit.Record(ctx, AuditEvent{
+			Action:     AuditActionAccountAnonymize,
+			TargetType: "user",
+			TargetID:   userID,
+			ActorID:    userID,
+		})
+	}
+
+	logger.Infof(ctx, "[ACCOUNT-SERVICE] 🗑️  Account %s finalized (%s)", userID, deletion.Mode)
+	return nil
+}
+
+// destroyImages - Best-effort removal of post images from Cloudinary
+func (s *AccountService) destroyImages(ctx context.Context, imageURLs []string) {
+	if s.cld == nil {
+		return
+	}
+	for _, imageURL := range imageURLs {
+		publicID := extractPublicID(imageURL)
+		if publicID == "" {
+			continue
+		}
+		if _, err := s.cld.Upload.Destroy(ctx, uploader.DestroyParams{PublicID: publicID}); err != nil {
+			logger.Errorf(ctx, "[ACCOUNT-SERVICE] Failed to delete image %s: %v", publicID, err)
+		}
+	}
+}
+
+func toDataExportResponse(export *model.DataExport) model.DataExportResponse {
+	response := model.DataExportResponse{
+		ID:          export.ID.String(),
+		Status:      export.Status,
+		FileSize:    export.FileSize,
+		Error:       export.Error,
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+	}
+	if export.Status == ExportStatusCompleted {
+		downloadURL := "/api/me/exports/" + response.ID + "/download"
+		response.DownloadURL = &downloadURL
+	}
+	return response
+}
+
+func toAccountDeletionResponse(deletion *model.AccountDeletion) *model.AccountDeletionResponse {
+	return &model.AccountDeletionResponse{
+		Mode:         deletion.Mode,
+		Status:       "scheduled",
+		RequestedAt:  &deletion.RequestedAt,
+		ScheduledFor: &deletion.ScheduledFor,
+	}
+}
+
+func writeZipJSON(archive *zip.Writer, name string, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return writeZipFile(archive, name, data)
+}
+
+func writeZipFile(archive *zip.Writer, name string, data []byte) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to export: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to export: %w", name, err)
+	}
+	return nil
+}
+
+func postMarkdownName(post model.PostResponse) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, post.Title)
+	slug = strings.Trim(slug, "-")
+	for strings.Contains(slug, "--") {
+		slug = strings.ReplaceAll(slug, "--", "-")
+	}
+	if len(slug) > 60 {
+		slug = strings.TrimRight(slug[:60], "-")
+	}
+	if slug == "" {
+		slug = post.ID
+	}
+	return post.CreatedAt.Format("2006-01-02") + "-" + slug + ".md"
+}
+
+func postMarkdown(post model.PostResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", post.Title)
+	fmt.Fprintf(&b, "- ID: %s\n", post.ID)
+	fmt.Fprintf(&b, "- Published: %t\n", post.IsPublished)
+	fmt.Fprintf(&b, "- Created: %s\n", post.CreatedAt.UTC().Format(time.RFC3339))
+	if post.Category != nil {
+		fmt.Fprintf(&b, "- Category: %s\n", *post.Category)
+	}
+	if len(post.Tags) > 0 {
+		fmt.Fprintf(&b, "- Tags: %s\n", strings.Join(post.Tags, ", "))
+	}
+	b.WriteString("\n")
+	b.WriteString(post.Content)
+	b.WriteString("\n")
+	return b.String()
+}
+
+func exportReadme(profile *model.UserProfile, posts, comments, likes int, skippedImages []string) string {
+	var b strings.Builder
+	b.WriteString("# QuillHub data export\n\n")
+	fmt.Fprintf(&b, "Account: %s (@%s)\n", profile.Name, profile.Username)
+	fmt.Fprintf(&b, "Generated: %s\n\n", time.Now().UTC().Format(time.RFC3339))
+	b.WriteString("| File | Contents |\n|------|----------|\n")
+	b.WriteString("| `profile.json` | Your profile |\n")
+	fmt.Fprintf(&b, "| `posts.json` | %d posts |\n", posts)
+	fmt.Fprintf(&b, "| `comments.json` | %d comments |\n", comments)
+	fmt.Fprintf(&b, "| `likes.json` | %d liked posts |\n", likes)
+	b.WriteString("| `posts/` | Each post as Markdown |\n")
+	b.WriteString("| `images/` | Images attached to your posts |\n")
+
+	if len(skippedImages) > 0 {
+		b.WriteString("\nThese images could not be copied and are listed by URL:\n\n")
+		for _, imageURL := range skippedImages {
+			fmt.Fprintf(&b, "- %s\n", imageURL)
+		}
+	}
+	return b.String()
+}
+
+func removeFiles(paths []string) {
+	for _, p := range paths {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Errorf(context.Background(), "[ACCOUNT-SERVICE] Failed to remove %s: %v", p, err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestCheckExportImageURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		cloudName string
+		ok        bool
+	}{
+		{"https://res.cloudinary.com/quill/image/upload/v1/posts/a.jpg", "quill", true},
+		{"https://res.cloudinary.com/other/image/upload/v1/posts/a.jpg", "quill", false},
+		{"https://res.cloudinary.com/quillhub/image/upload/v1/posts/a.jpg", "quill", false},
+		{"http://res.cloudinary.com/quill/image/upload/v1/posts/a.jpg", "quill", false},
+		{"https://res.cloudinary.com.evil.example/quill/a.jpg", "quill", false},
+		{"https://res.cloudinary.com:8443/quill/a.jpg", "quill", false},
+		{"https://169.254.169.254/latest/meta-data/", "quill", false},
+		{"http://localhost:5432/", "quill", false},
+		{"https://res.cloudinary.com/quill/image/upload/v1/posts/a.jpg", "", false},
+	}
+
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatalf("parse %s: %v", tt.url, err)
+		}
+		if err := checkExportImageURL(u, tt.cloudName); (err == nil) != tt.ok {
+			t.Errorf("%s in cloud %q: err = %v, want allowed %v", tt.url, tt.cloudName, err, tt.ok)
+		}
+	}
+}
+
+func TestCopyImageOnlyFetchesCloudinary(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte("internal secret"))
+	}))
+	defer server.Close()
+
+	s := NewAccountService(nil, nil, nil, nil, AccountConfig{CloudName: "quill"})
+	archive := zip.NewWriter(io.Discard)
+	if err := s.copyImage(context.Background(), archive, "images/a.jpg", server.URL+"/quill/a.jpg"); err == nil {
+		t.Error("copied an image from outside Cloudinary")
+	}
+	if hits != 0 {
+		t.Errorf("the server was requested %d times", hits)
+	}
+
+	// Redirects out of the cloud are refused too
+	redirect, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err := s.httpClient.CheckRedirect(redirect, []*http.Request{{}}); err == nil {
+		t.Error("followed a redirect out of Cloudinary")
+	}
+}
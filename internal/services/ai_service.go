@@ -6,244 +6,248 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
-	"math/rand"
-	"os"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/britinogn/quillhub/internal/model"
-	"cloud.google.com/go/ai/generativelanguage/apiv1beta/generativelanguagepb"
-	"google.golang.org/api/option"
-	generativelanguage "cloud.google.com/go/ai/generativelanguage/apiv1beta"
 )
 
+var (
+	ErrNoLLMProvider        = errors.New("no LLM provider configured")
+	ErrInvalidGeneratedPost = errors.New("failed to parse generated post")
+)
+
+// Limits every generated post must meet before it is saved
+const (
+	DefaultPostMinWords   = 150
+	DefaultPostMaxWords   = 350
+	maxGeneratedTitleLen  = 200 // same as the posts.title column
+	minGeneratedTags      = 3
+	maxGeneratedTags      = 8
+	maxRepairPreviousSize = 20000 // characters of the bad answer sent back for repair
+)
+
+// PostValidationError - A well-formed answer that breaks the post schema
+type PostValidationError struct {
+	Problems []string
+}
+
+func (e *PostValidationError) Error() string {
+	return "generated post failed validation: " + strings.Join(e.Problems, "; ")
+}
+
+func (e *PostValidationError) Unwrap() error {
+	return ErrInvalidGeneratedPost
+}
+
 type AIService struct {
-	apiKey string
-	client *generativelanguage.GenerativeClient
+	provider LLMProvider
+	usage    *AIUsageService
 }
 
-func NewAIService() *AIService {
-	ctx := context.Background()
-	apiKey := os.Getenv("GEMINI_API_KEY")
-	
-	client, err := generativelanguage.NewGenerativeClient(ctx, option.WithAPIKey(apiKey))
-	if err != nil {
-		log.Printf("[AI-SERVICE] Failed to create Gemini client: %v", err)
-		return &AIService{apiKey: apiKey}
-	}
+// NewAIService - provider may be nil; generation then fails with a clear error.
+// usage may be nil; calls are then neither recorded nor budgeted
+func NewAIService(provider LLMProvider, usage *AIUsageService) *AIService {
+	return &AIService{provider: provider, usage: usage}
+}
 
-	return &AIService{
-		apiKey: apiKey,
-		client: client,
-	}
+// Provider - The configured LLM provider (nil when none is configured)
+func (s *AIService) Provider() LLMProvider {
+	return s.provider
 }
 
-// Close - Clean up the client
+// Close - Clean up the provider
 func (s *AIService) Close() error {
-	if s.client != nil {
-		return s.client.Close()
+	if s.provider != nil {
+		return s.provider.Close()
 	}
 	return nil
 }
 
-// GenerateRandomTopic - Generate a random tech topic
-func (s *AIService) GenerateRandomTopic() string {
-	topics := []string{
-		"Go programming best practices",
-		"Building scalable microservices",
-		"Docker and containerization",
-		"Kubernetes deployment strategies",
-		"RESTful API design patterns",
-		"PostgreSQL performance optimization",
-		"JWT authentication implementation",
-		"GraphQL vs REST APIs",
-		"CI/CD pipeline setup",
-		"Cloud architecture patterns",
-		"Git workflow strategies",
-		"Test-driven development",
-		"Database indexing strategies",
-		"Caching strategies with Redis",
-		"Message queues and async processing",
-		"gRPC vs HTTP/REST",
-		"Serverless architecture",
-		"API rate limiting techniques",
-		"Monitoring and observability",
-		"Security best practices in web development",
-
-		// MORE
-
-		"Weird food combinations people actually love",
-		"Hidden gems in your city you probably never noticed",
-		"The psychology behind why we procrastinate (and how to stop)",
-		"Best cheap date ideas that actually work",
-		"Why adults still love cartoons and nostalgic games",
-		"The most underrated travel destinations in 2025",
-		"How to travel the world on a tight budget",
-		"Scary travel stories that actually happened",
-		"Beautiful small towns you should visit before they become tourist traps",
-		"The world's strangest museums and why they're worth seeing",
-		"Dark humor jokes that are too good to be true",
-		"Dad jokes so bad they're actually good",
-		"Memes that perfectly describe adult life in 2025",
-		"The funniest autocorrect fails of all time",
-		"Why Gen Z humor is completely different from Millennials",
-		"Mind-blowing historical facts most people don't know",
-		"How the human brain actually learns new things",
-		"The real story behind everyday inventions",
-		"Why some people are naturally good at languages",
-		"The science of why music makes us feel emotions",
-		"Countries with the weirdest laws still in effect",
-		"The world's happiest countries — and why they’re happy",
-		"Secret traditions only locals know about",
-		"Countries where time moves differently (literally)",
-		"The most polite and most rude countries according to travelers",
-		"Why we yawn — and why it’s contagious",
-		"The weirdest things doctors have found inside people",
-		"How your body changes when you fall in love",
-		"Foods that are secretly good for your brain",
-		"Why some people never get sick (genetics or luck?)",
-		"Animals with the strangest superpowers",
-		"Why cats are secretly plotting world domination",
-		"The psychology of why we love true crime",
-		"Conspiracy theories that turned out to be true",
-		"The most satisfying optical illusions ever",
-
-	}
-
-	rand.Seed(time.Now().UnixNano())
-	return topics[rand.Intn(len(topics))]
-}
-
-// GenerateRandomCategory - Generate a random category
-func (s *AIService) GenerateRandomCategory() string {
-	categories := []string{
-		"Backend Development",
-		"DevOps",
-		"Architecture",
-		"Security",
-		"Databases",
-		"Cloud Computing",
-		"Best Practices",
-
-		// New related categories
-		"Microservices",
-		"API Design",
-		"System Design",
-		"Performance Optimization",
-		"Scalability",
-		"Distributed Systems",
-		"Containerization",
-		"Orchestration",
-		"Infrastructure as Code",
-		"CI/CD Pipelines",
-		"Observability & Monitoring",
-		"Logging & Tracing",
-		"Testing Strategies",
-
-		// New related categories
-		"Fun & Weird Facts",
-		"Travel & Hidden Places",
-		"Jokes & Humor",
-		"Psychology & Mind",
-		"Countries & Cultures",
-		"Health & Body Science",
-		"Animals & Nature",
-		"Life Hacks & Tips",
-		"Memes & Internet Culture",
-		"History & True Stories",
-		"Love & Relationships",
-		"Food & Eating Habits",
-		"Daily Life & Adulthood",
-		"Conspiracy & Mysteries",
-		"Optical Illusions & Brain Tricks",
-		"Education & Learning Hacks",
-		"Budget & Money Saving",
-		"Strange Traditions",
-		"Dark Humor & Edgy Jokes",
-		"Personal Development",
-	}
-
-	rand.Seed(time.Now().UnixNano())
-	return categories[rand.Intn(len(categories))]
-}
-
-// GenerateBlogPost - Generate a blog post using Gemini AI SDK
-func (s *AIService) GenerateBlogPost(ctx context.Context, topic string) (*model.AIGeneratedPost, error) {
-	if s.apiKey == "" {
-		return nil, errors.New("GEMINI_API_KEY not set")
-	}
-
-	if s.client == nil {
-		return nil, errors.New("Gemini client not initialized")
-	}
-
-	log.Printf("[AI-SERVICE] Generating blog post for topic: %s", topic)
-
-	// Create prompt
-	prompt := fmt.Sprintf(`You are a skilled blogger who adapts tone to the topic.
-
-Topic: "%s"
-
-Rules:
-- Create a catchy, engaging title
-- Write 150–350 words of well-structured content
-- If the topic is technical/programming → use professional tone, clear explanations, code examples in markdown blocks
-- If the topic is fun/travel/jokes/psychology/countries/health/life → use light, relatable, humorous tone
-- Structure: short intro, main body (sections or bullets), quick conclusion
-- End with a question or call-to-action to engage readers
-- Use markdown for formatting (headings, bold, code blocks, lists)
-- No first person ("I", "we") — neutral voice
-
-Return ONLY valid JSON in this exact format, nothing else:
+// Complete - Send one request to the configured provider, within budget, and record its usage
+func (s *AIService) Complete(ctx context.Context, req LLMRequest) (*LLMResponse, error) {
+	if s.provider == nil {
+		return nil, ErrNoLLMProvider
+	}
+	if err := s.usage.CheckBudget(ctx, req.Feature); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := s.provider.Generate(ctx, req)
+	s.usage.Record(ctx, s.provider, req, resp, err, time.Since(start))
+
+	return resp, err
+}
+
+// Usage - Usage accounting (nil when disabled)
+func (s *AIService) Usage() *AIUsageService {
+	return s.usage
+}
+
+// NormalizePostRequest - Copy of req with default word bounds filled in
+func NormalizePostRequest(req *model.AIPostRequest) *model.AIPostRequest {
+	spec := *req
+	if spec.MinLength <= 0 {
+		spec.MinLength = DefaultPostMinWords
+	}
+	if spec.MaxLength <= 0 {
+		spec.MaxLength = DefaultPostMaxWords
+	}
+	if spec.MaxLength < spec.MinLength {
+		spec.MaxLength = spec.MinLength
+	}
+	return &spec
+}
+
+// BlogPostRepairPrompt - Ask the model to fix an answer that failed parsing or validation
+func BlogPostRepairPrompt(req *model.AIPostRequest, previous string, cause error) string {
+	spec := NormalizePostRequest(req)
+
+	problems := []string{cause.Error()}
+	var validationErr *PostValidationError
+	if errors.As(cause, &validationErr) {
+		problems = validationErr.Problems
+	}
+
+	if utf8.RuneCountInString(previous) > maxRepairPreviousSize {
+		previous = string([]rune(previous)[:maxRepairPreviousSize])
+	}
+
+	return fmt.Sprintf(`Your previous answer for a blog post about "%s" could not be used.
+
+Problems:
+- %s
+
+Requirements: a title of at most %d characters, %d–%d words of content, and %d to %d tags.
+Fix only what is needed and keep the rest of the post as it is.
+
+Previous answer:
+<<<
+%s
+>>>
+
+%s`, spec.Topic, strings.Join(problems, "\n- "), maxGeneratedTitleLen, spec.MinLength, spec.MaxLength, minGeneratedTags, maxGeneratedTags, previous, blogPostFormat)
+}
+
+const blogPostFormat = `Return ONLY valid JSON in this exact format, nothing else:
 {
 	"title": "Your title here",
 	"content": "Full post text here (markdown ok)",
 	"tags": ["tag1", "tag2", "tag3", "tag4", "tag5"]
-} 
-Only return valid JSON, nothing else.`, topic)
-
-	// Call Gemini API using SDK
-	req := &generativelanguagepb.GenerateContentRequest{
-		Model: "models/gemini-flash-latest", // Free tier model
-		Contents: []*generativelanguagepb.Content{
-			{
-				Parts: []*generativelanguagepb.Part{
-					{
-						Data: &generativelanguagepb.Part_Text{
-							Text: prompt,
-						},
-					},
-				},
-			},
-		},
-	}
-
-	resp, err := s.client.GenerateContent(ctx, req)
+}
+Only return valid JSON, nothing else.`
+
+// generatedPostJSON - Lenient shape of the model's answer; tags may come as a list or a comma-separated string
+type generatedPostJSON struct {
+	Title   string          `json:"title"`
+	Content string          `json:"content"`
+	Tags    json.RawMessage `json:"tags"`
+}
+
+// ParseBlogPost - Decode the model's JSON answer, tidy it up and check it against the post schema
+func ParseBlogPost(text string, req *model.AIPostRequest) (*model.AIGeneratedPost, error) {
+	spec := NormalizePostRequest(req)
+
+	var raw generatedPostJSON
+	if err := json.Unmarshal([]byte(cleanJSONResponse(text)), &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGeneratedPost, err)
+	}
+
+	tags, err := decodeGeneratedTags(raw.Tags)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate content: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGeneratedPost, err)
+	}
+
+	post := &model.AIGeneratedPost{
+		Title:   cleanGeneratedTitle(raw.Title),
+		Content: strings.TrimSpace(raw.Content),
+		Tags:    cleanGeneratedTags(tags),
 	}
 
-	// Extract response text
-	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
-		return nil, errors.New("empty response from Gemini API")
+	if problems := validateGeneratedPost(post, spec); len(problems) > 0 {
+		return nil, &PostValidationError{Problems: problems}
 	}
+	return post, nil
+}
 
-	responseText := resp.Candidates[0].Content.Parts[0].GetText()
-	
-	// Clean markdown code fences if present
-	responseText = strings.TrimPrefix(responseText, "```json")
-	responseText = strings.TrimPrefix(responseText, "```")
-	responseText = strings.TrimSuffix(responseText, "```")
-	responseText = strings.TrimSpace(responseText)
+// decodeGeneratedTags - Tags given as a JSON list or a comma-separated string
+func decodeGeneratedTags(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
 
-	// Parse JSON response
-	var generatedPost model.AIGeneratedPost
-	if err := json.Unmarshal([]byte(responseText), &generatedPost); err != nil {
-		log.Printf("[AI-SERVICE] Failed to parse JSON. Raw response: %s", responseText)
-		return nil, fmt.Errorf("failed to parse generated post: %w", err)
+	var tags []string
+	if err := json.Unmarshal(raw, &tags); err != nil {
+		var joined string
+		if err := json.Unmarshal(raw, &joined); err != nil {
+			return nil, errors.New("tags must be a list of strings")
+		}
+		tags = strings.Split(joined, ",")
 	}
+	return tags, nil
+}
+
+func validateGeneratedPost(post *model.AIGeneratedPost, spec *model.AIPostRequest) []string {
+	var problems []string
 
-	log.Printf("[AI-SERVICE] Successfully generated post: %s", generatedPost.Title)
-	return &generatedPost, nil
-}
\ No newline at end of file
+	switch titleLen := utf8.RuneCountInString(post.Title); {
+	case titleLen == 0:
+		problems = append(problems, "title is missing")
+	case titleLen > maxGeneratedTitleLen:
+		problems = append(problems, fmt.Sprintf("title has %d characters, the limit is %d", titleLen, maxGeneratedTitleLen))
+	}
+
+	if post.Content == "" {
+		problems = append(problems, "content is missing")
+	} else if words := countWords(post.Content); words < spec.MinLength || words > spec.MaxLength {
+		problems = append(problems, fmt.Sprintf("content has %d words, it must have %d–%d", words, spec.MinLength, spec.MaxLength))
+	}
+
+	if len(post.Tags) < minGeneratedTags {
+		problems = append(problems, fmt.Sprintf("%d distinct tag(s) given, at least %d are needed", len(post.Tags), minGeneratedTags))
+	}
+
+	return problems
+}
+
+// cleanGeneratedTitle - Drop markdown heading marks and wrapping quotes models sometimes add
+func cleanGeneratedTitle(title string) string {
+	title = strings.TrimSpace(title)
+	title = strings.TrimSpace(strings.TrimLeft(title, "#"))
+	if len(title) >= 2 && (title[0] == '"' && title[len(title)-1] == '"' || title[0] == '\'' && title[len(title)-1] == '\'') {
+		title = strings.TrimSpace(title[1 : len(title)-1])
+	}
+	return title
+}
+
+// cleanGeneratedTags - Lowercase, without '#', blanks or duplicates, at most maxGeneratedTags
+func cleanGeneratedTags(tags []string) []string {
+	cleaned := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(tag), "#")))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		cleaned = append(cleaned, tag)
+		if len(cleaned) == maxGeneratedTags {
+			break
+		}
+	}
+	return cleaned
+}
+
+// countWords - Words with at least one letter or digit, so markdown marks like "##" and "-" do not count
+func countWords(text string) int {
+	count := 0
+	for _, field := range strings.Fields(text) {
+		if strings.IndexFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) }) >= 0 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,406 @@
+// internal/services/ai_usage_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+)
+
+// Features that call the LLM, for usage accounting and budgets
+const (
+	UsageFeaturePostGeneration   = "post_generation"
+	UsageFeatureAssistant        = "assistant"
+	UsageFeatureModeration       = "moderation"
+	UsageFeatureTranslation      = "translation"
+	UsageFeatureImageDescription = "image_description"
+	UsageFeatureBotReply         = "bot_reply"
+	UsageFeatureOther            = "other"
+
+	usageBudgetTotal = "total"
+)
+
+const (
+	usageRecordTimeout = 5 * time.Second
+	usageSpendCacheTTL = time.Minute
+	maxUsageReportDays = 366
+	// estimatedImageTokens - Input tokens counted for an attached image (Gemini's flat rate)
+	estimatedImageTokens = 258
+)
+
+var (
+	ErrAIBudgetExceeded  = errors.New("monthly AI budget exceeded")
+	ErrInvalidUsageRange = errors.New("invalid usage range")
+)
+
+type AIUsageRepo interface {
+	Record(ctx context.Context, event *model.AIUsageEvent) error
+	SpendByFeature(ctx context.Context, since time.Time) (map[string]float64, error)
+	Totals(ctx context.Context, groupBy string, from, to time.Time, limit int) ([]model.AIUsageTotals, error)
+}
+
+// ModelPrice - USD per million tokens
+type ModelPrice struct {
+	Input  float64
+	Output float64
+}
+
+// defaultModelPrices - Published list prices at the time of writing; override with AI_MODEL_PRICES
+var defaultModelPrices = map[string]ModelPrice{
+	"gemini-flash-latest":   {Input: 0.30, Output: 2.50},
+	"gemini-2.5-flash":      {Input: 0.30, Output: 2.50},
+	"gemini-2.5-flash-lite": {Input: 0.10, Output: 0.40},
+	"gemini-2.5-pro":        {Input: 1.25, Output: 10.00},
+	"gemini-2.0-flash":      {Input: 0.10, Output: 0.40},
+	"gemini-1.5-flash":      {Input: 0.075, Output: 0.30},
+	"gemini-1.5-pro":        {Input: 1.25, Output: 5.00},
+	"gpt-4o-mini":           {Input: 0.15, Output: 0.60},
+	"gpt-4o":                {Input: 2.50, Output: 10.00},
+	"gpt-4.1-mini":          {Input: 0.40, Output: 1.60},
+	"gpt-4.1":               {Input: 2.00, Output: 8.00},
+	"fake-deterministic":    {},
+}
+
+// AIUsageConfig - Prices and monthly budgets
+type AIUsageConfig struct {
+	Prices         map[string]ModelPrice
+	MonthlyBudget  float64            // USD across all features, 0 = no budget
+	FeatureBudgets map[string]float64 // USD per feature
+}
+
+// LoadAIUsageConfig - The built-in prices, with the configured ones added or replacing them, and the budgets
+func LoadAIUsageConfig(app config.AIUsageConfig) AIUsageConfig {
+	cfg := AIUsageConfig{
+		Prices:         make(map[string]ModelPrice, len(defaultModelPrices)+len(app.ModelPrices)),
+		MonthlyBudget:  app.MonthlyBudget,
+		FeatureBudgets: make(map[string]float64, len(app.FeatureBudgets)),
+	}
+	for name, price := range defaultModelPrices {
+		cfg.Prices[name] = price
+	}
+	for name, price := range app.ModelPrices {
+		cfg.Prices[normalizeModelName(name)] = ModelPrice{Input: price.Input, Output: price.Output}
+	}
+	for feature, budget := range app.FeatureBudgets {
+		cfg.FeatureBudgets[feature] = budget
+	}
+
+	return cfg
+}
+
+// AIUsageService - Records every LLM call and enforces monthly budgets
+type AIUsageService struct {
+	repo AIUsageRepo
+	cfg  AIUsageConfig
+
+	mu       sync.Mutex
+	month    time.Time          // month the cached spend belongs to
+	spend    map[string]float64 // cost per feature this month
+	loadedAt time.Time
+	unpriced map[string]bool // models already warned about
+}
+
+func NewAIUsageService(repo AIUsageRepo, cfg AIUsageConfig) *AIUsageService {
+	if cfg.MonthlyBudget > 0 {
+		logger.Infof(context.Background(), "[AI-USAGE] Monthly AI budget: $%.2f", cfg.MonthlyBudget)
+	}
+	for feature, budget := range cfg.FeatureBudgets {
+		logger.Infof(context.Background(), "[AI-USAGE] Monthly budget for %s: $%.2f", feature, budget)
+	}
+	return &AIUsageService{repo: repo, cfg: cfg, unpriced: make(map[string]bool)}
+}
+
+// Record - Store one LLM call; resp is nil when the call failed before an answer came back
+func (s *AIUsageService) Record(ctx context.Context, provider LLMProvider, req LLMRequest, resp *LLMResponse, callErr error, latency time.Duration) {
+	if s == nil || provider == nil {
+		return
+	}
+
+	event := &model.AIUsageEvent{
+		Feature:   req.Feature,
+		Provider:  provider.Name(),
+		Model:     provider.Model(),
+		Success:   callErr == nil,
+		LatencyMs: latency.Milliseconds(),
+		CreatedAt: time.Now().UTC(),
+	}
+	if event.Feature == "" {
+		event.Feature = UsageFeatureOther
+	}
+	if req.Model != "" {
+		event.Model = req.Model
+	}
+	if req.UserID != "" {
+		_ = event.UserID.Scan(req.UserID)
+	}
+
+	if resp != nil {
+		if resp.Model != "" {
+			event.Model = resp.Model
+		}
+		event.InputTokens = resp.InputTokens
+		event.OutputTokens = resp.OutputTokens
+		// Not every provider reports usage; estimate so budgets still work
+		if event.InputTokens == 0 && event.OutputTokens == 0 {
+			event.InputTokens = estimateTokens(req.System) + estimateTokens(req.Prompt) + estimatedImageTokens*len(req.Images)
+			event.OutputTokens = estimateTokens(resp.Text)
+			event.EstimatedTokens = true
+		}
+	}
+	event.CostUSD = s.cost(event.Model, event.InputTokens, event.OutputTokens)
+
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), usageRecordTimeout)
+	defer cancel()
+	if err := s.repo.Record(recordCtx, event); err != nil {
+		logger.Warnf(ctx, "[AI-USAGE] ⚠️  %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	if s.spend != nil && s.month.Equal(usageMonth(event.CreatedAt)) {
+		s.spend[event.Feature] += event.CostUSD
+	}
+	s.mu.Unlock()
+}
+
+// CheckBudget - ErrAIBudgetExceeded when the overall or the feature's monthly budget is used up
+func (s *AIUsageService) CheckBudget(ctx context.Context, feature string) error {
+	if s == nil || (s.cfg.MonthlyBudget <= 0 && len(s.cfg.FeatureBudgets) == 0) {
+		return nil
+	}
+
+	spend, err := s.monthSpend(ctx)
+	if err != nil {
+		// Budgets are a safety net; a failed lookup must not take AI features down
+		logger.Warnf(ctx, "[AI-USAGE] ⚠️  Could not check budget: %v", err)
+		return nil
+	}
+
+	if s.cfg.MonthlyBudget > 0 {
+		var total float64
+		for _, cost := range spend {
+			total += cost
+		}
+		if total >= s.cfg.MonthlyBudget {
+			return fmt.Errorf("%w: $%.2f of $%.2f spent", ErrAIBudgetExceeded, total, s.cfg.MonthlyBudget)
+		}
+	}
+	if budget, ok := s.cfg.FeatureBudgets[feature]; ok && spend[feature] >= budget {
+		return fmt.Errorf("%w for %s: $%.2f of $%.2f spent", ErrAIBudgetExceeded, feature, spend[feature], budget)
+	}
+	return nil
+}
+
+// BudgetStatus - This month's spend against every budget
+func (s *AIUsageService) BudgetStatus(ctx context.Context) (*model.AIBudgetStatus, error) {
+	spend, err := s.monthSpend(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	month := usageMonth(time.Now().UTC())
+	status := &model.AIBudgetStatus{
+		Month:    month.Format("2006-01"),
+		ResetsAt: month.AddDate(0, 1, 0),
+		Features: []model.AIBudget{},
+	}
+
+	var total float64
+	features := make(map[string]bool)
+	for feature, cost := range spend {
+		total += cost
+		features[feature] = true
+	}
+	for feature := range s.cfg.FeatureBudgets {
+		features[feature] = true
+	}
+
+	status.Total = newAIBudget(usageBudgetTotal, total, s.cfg.MonthlyBudget, s.cfg.MonthlyBudget > 0)
+
+	names := make([]string, 0, len(features))
+	for feature := range features {
+		names = append(names, feature)
+	}
+	sort.Strings(names)
+	for _, feature := range names {
+		limit, ok := s.cfg.FeatureBudgets[feature]
+		status.Features = append(status.Features, newAIBudget(feature, spend[feature], limit, ok))
+	}
+
+	return status, nil
+}
+
+// Summary - Month-to-date headline numbers for the admin dashboard
+func (s *AIUsageService) Summary(ctx context.Context) (*model.AIUsageSummary, error) {
+	budget, err := s.BudgetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	month := usageMonth(now)
+	summary := &model.AIUsageSummary{Budget: *budget}
+
+	totals, err := s.repo.Totals(ctx, "", month, now.Add(time.Minute), 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(totals) > 0 {
+		summary.RequestsThisMonth = totals[0].Requests
+		summary.TokensThisMonth = totals[0].InputTokens + totals[0].OutputTokens
+	}
+
+	today, err := s.repo.Totals(ctx, "", now.Truncate(24*time.Hour), now.Add(time.Minute), 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(today) > 0 {
+		summary.CostTodayUSD = today[0].CostUSD
+	}
+
+	summary.AutoPosterPaused = s.CheckBudget(ctx, UsageFeaturePostGeneration) != nil
+	return summary, nil
+}
+
+// Report - Usage over the last `days` days (UTC), broken down by day, feature, model and user
+func (s *AIUsageService) Report(ctx context.Context, days int) (*model.AIUsageReport, error) {
+	if days < 1 || days > maxUsageReportDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", ErrInvalidUsageRange, maxUsageReportDays)
+	}
+
+	now := time.Now().UTC()
+	to := now.Add(time.Minute)
+	from := now.Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+	report := &model.AIUsageReport{From: from, To: now, Totals: model.AIUsageTotals{Key: "total"}}
+
+	totals, err := s.repo.Totals(ctx, "", from, to, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(totals) > 0 {
+		report.Totals = totals[0]
+	}
+
+	if report.ByDay, err = s.repo.Totals(ctx, "day", from, to, maxUsageReportDays); err != nil {
+		return nil, err
+	}
+	if report.ByFeature, err = s.repo.Totals(ctx, "feature", from, to, 100); err != nil {
+		return nil, err
+	}
+	if report.ByModel, err = s.repo.Totals(ctx, "model", from, to, 100); err != nil {
+		return nil, err
+	}
+	if report.TopUsers, err = s.repo.Totals(ctx, "user", from, to, 20); err != nil {
+		return nil, err
+	}
+
+	budget, err := s.BudgetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Budget = *budget
+
+	return report, nil
+}
+
+// monthSpend - Cost per feature this month, cached briefly so budget checks stay cheap
+func (s *AIUsageService) monthSpend(ctx context.Context) (map[string]float64, error) {
+	month := usageMonth(time.Now().UTC())
+
+	s.mu.Lock()
+	if s.spend != nil && s.month.Equal(month) && time.Since(s.loadedAt) < usageSpendCacheTTL {
+		spend := make(map[string]float64, len(s.spend))
+		for feature, cost := range s.spend {
+			spend[feature] = cost
+		}
+		s.mu.Unlock()
+		return spend, nil
+	}
+	s.mu.Unlock()
+
+	spend, err := s.repo.SpendByFeature(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.month = month
+	s.spend = make(map[string]float64, len(spend))
+	for feature, cost := range spend {
+		s.spend[feature] = cost
+	}
+	s.loadedAt = time.Now()
+	s.mu.Unlock()
+
+	return spend, nil
+}
+
+// cost - Estimated USD for a call; unknown models cost nothing and are logged once
+func (s *AIUsageService) cost(modelName string, inputTokens, outputTokens int) float64 {
+	price, ok := s.price(modelName)
+	if !ok {
+		s.mu.Lock()
+		warned := s.unpriced[modelName]
+		s.unpriced[modelName] = true
+		s.mu.Unlock()
+		if !warned {
+			logger.Warnf(context.Background(), "[AI-USAGE] ⚠️  No price for model %q, recording cost as 0 (set AI_MODEL_PRICES)", modelName)
+		}
+		return 0
+	}
+	return (float64(inputTokens)*price.Input + float64(outputTokens)*price.Output) / 1_000_000
+}
+
+// price - Exact match first, then the longest known prefix ("gpt-4o-mini-2024-07-18" → "gpt-4o-mini")
+func (s *AIUsageService) price(modelName string) (ModelPrice, bool) {
+	name := normalizeModelName(modelName)
+	if price, ok := s.cfg.Prices[name]; ok {
+		return price, true
+	}
+
+	best := ""
+	for known := range s.cfg.Prices {
+		if strings.HasPrefix(name, known) && len(known) > len(best) {
+			best = known
+		}
+	}
+	if best == "" {
+		return ModelPrice{}, false
+	}
+	return s.cfg.Prices[best], true
+}
+
+func newAIBudget(feature string, spent, limit float64, hasLimit bool) model.AIBudget {
+	budget := model.AIBudget{Feature: feature, SpentUSD: spent}
+	if hasLimit {
+		budget.LimitUSD = &limit
+		remaining := max(limit-spent, 0)
+		budget.RemainingUSD = &remaining
+		budget.Exceeded = spent >= limit
+	}
+	return budget
+}
+
+// usageMonth - Start of the UTC month
+func usageMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// normalizeModelName - "models/gemini-1.5-pro" → "gemini-1.5-pro"
+func normalizeModelName(name string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(name)), "models/")
+}
+
+// estimateTokens - About four characters per token
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
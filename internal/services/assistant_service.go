@@ -0,0 +1,379 @@
+// internal/services/assistant_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+)
+
+const (
+	assistantCallTimeout  = 60 * time.Second
+	maxAssistantContent   = 20000 // characters of draft sent to the model
+	maxAssistantSelection = 5000
+	maxAssistantTags      = 8
+	assistantTaxonomyTags = 200
+)
+
+// Rewrite goals
+const (
+	RewriteGoalGrammar = "grammar"
+	RewriteGoalClarity = "clarity"
+	RewriteGoalConcise = "concise"
+)
+
+var (
+	ErrAssistantQuotaExceeded = errors.New("daily AI assistant quota reached")
+	ErrAssistantInputTooLong  = errors.New("text is too long for the assistant")
+	ErrAssistantInvalidInput  = errors.New("invalid assistant request")
+	ErrAssistantUnavailable   = errors.New("AI assistant is unavailable")
+	ErrAssistantBadResponse   = errors.New("AI assistant returned an unusable answer")
+)
+
+type AssistantRepo interface {
+	ReserveRequest(ctx context.Context, userID string, day time.Time, limit int) (bool, error)
+	ReleaseRequest(ctx context.Context, userID string, day time.Time) error
+	AddTokens(ctx context.Context, userID string, day time.Time, inputTokens, outputTokens int) error
+	GetRequests(ctx context.Context, userID string, day time.Time) (int, error)
+	ListPopularTags(ctx context.Context, limit int) ([]string, error)
+	ListCategories(ctx context.Context) ([]string, error)
+}
+
+// AssistantService - Writing help for human authors, metered per user per UTC day
+type AssistantService struct {
+	ai         *AIService
+	repo       AssistantRepo
+	dailyQuota int // 0 means unlimited
+}
+
+// NewAssistantService - dailyQuota is requests per user per UTC day, 0 = unlimited
+func NewAssistantService(ai *AIService, repo AssistantRepo, dailyQuota int) *AssistantService {
+	return &AssistantService{ai: ai, repo: repo, dailyQuota: max(dailyQuota, 0)}
+}
+
+// SuggestTitles - Candidate titles for a draft
+func (s *AssistantService) SuggestTitles(ctx context.Context, userID, role string, req *model.SuggestTitlesRequest) (*model.SuggestTitlesResponse, error) {
+	if err := checkAssistantText(req.Content, maxAssistantContent); err != nil {
+		return nil, err
+	}
+
+	count := req.Count
+	if count <= 0 {
+		count = 5
+	}
+	if count > 10 {
+		count = 10
+	}
+
+	prompt := fmt.Sprintf(`Suggest %d engaging, accurate titles for the blog post below.
+Each title must be under 100 characters, match the post's tone and avoid clickbait.
+%s
+Return ONLY JSON: {"titles": ["...", "..."]}
+
+Post:
+"""
+%s
+"""`, count, currentTitleLine(req.Title), req.Content)
+
+	var out struct {
+		Titles []string `json:"titles"`
+	}
+	if err := s.call(ctx, userID, role, prompt, &out); err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, 0, count)
+	seen := make(map[string]bool)
+	for _, title := range out.Titles {
+		title = strings.Trim(strings.TrimSpace(title), `"`)
+		key := strings.ToLower(title)
+		if title == "" || len(title) > 200 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		titles = append(titles, title)
+		if len(titles) == count {
+			break
+		}
+	}
+	if len(titles) == 0 {
+		return nil, ErrAssistantBadResponse
+	}
+
+	return &model.SuggestTitlesResponse{Titles: titles}, nil
+}
+
+// Summarize - A short excerpt of a draft
+func (s *AssistantService) Summarize(ctx context.Context, userID, role string, req *model.SummarizeRequest) (*model.SummarizeResponse, error) {
+	if err := checkAssistantText(req.Content, maxAssistantContent); err != nil {
+		return nil, err
+	}
+
+	maxWords := req.MaxWords
+	if maxWords <= 0 {
+		maxWords = 50
+	}
+	if maxWords > 150 {
+		maxWords = 150
+	}
+
+	prompt := fmt.Sprintf(`Write a summary of the blog post below for use as an excerpt on post cards.
+Use at most %d words, plain text without markdown, in the same language and voice as the post.
+%s
+Return ONLY JSON: {"summary": "..."}
+
+Post:
+"""
+%s
+"""`, maxWords, currentTitleLine(req.Title), req.Content)
+
+	var out struct {
+		Summary string `json:"summary"`
+	}
+	if err := s.call(ctx, userID, role, prompt, &out); err != nil {
+		return nil, err
+	}
+
+	words := strings.Fields(out.Summary)
+	if len(words) == 0 {
+		return nil, ErrAssistantBadResponse
+	}
+	if len(words) > maxWords {
+		words = words[:maxWords]
+	}
+
+	return &model.SummarizeResponse{Summary: strings.Join(words, " ")}, nil
+}
+
+// SuggestTags - Tags and a category, preferring what the site already uses
+func (s *AssistantService) SuggestTags(ctx context.Context, userID, role string, req *model.SuggestTagsRequest) (*model.SuggestTagsResponse, error) {
+	if err := checkAssistantText(req.Content, maxAssistantContent); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.repo.ListPopularTags(ctx, assistantTaxonomyTags)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt := fmt.Sprintf(`Classify the blog post below.
+Pick 3 to %d tags. Strongly prefer tags from the existing list; only propose a new tag when nothing fits.
+Pick exactly one category from the existing categories, or null if none fits.
+Tags are lowercase, one to three words.
+%s
+Existing tags: %s
+Existing categories: %s
+
+Return ONLY JSON: {"tags": ["..."], "category": "..." }
+
+Post:
+"""
+%s
+"""`, maxAssistantTags, currentTitleLine(req.Title), strings.Join(tags, ", "), strings.Join(categories, ", "), req.Content)
+
+	var out struct {
+		Tags     []string `json:"tags"`
+		Category *string  `json:"category"`
+	}
+	if err := s.call(ctx, userID, role, prompt, &out); err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		known[tag] = true
+	}
+
+	response := &model.SuggestTagsResponse{Tags: []string{}}
+	seen := make(map[string]bool)
+	for _, tag := range out.Tags {
+		tag = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tag), "#")))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		if known[tag] {
+			response.Tags = append(response.Tags, tag)
+		} else {
+			response.NewTags = append(response.NewTags, tag)
+		}
+		if len(seen) == maxAssistantTags {
+			break
+		}
+	}
+
+	// Only ever return a category that already exists, spelled the way the site spells it
+	if out.Category != nil {
+		wanted := strings.TrimSpace(*out.Category)
+		for _, category := range categories {
+			if strings.EqualFold(category, wanted) {
+				match := category
+				response.Category = &match
+				break
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// Rewrite - Propose a grammar, clarity or concision rewrite of a selection
+func (s *AssistantService) Rewrite(ctx context.Context, userID, role string, req *model.RewriteRequest) (*model.RewriteResponse, error) {
+	if err := checkAssistantText(req.Selection, maxAssistantSelection); err != nil {
+		return nil, err
+	}
+	if utf8.RuneCountInString(req.Context) > maxAssistantContent {
+		return nil, ErrAssistantInputTooLong
+	}
+
+	goal := strings.ToLower(strings.TrimSpace(req.Goal))
+	var instruction string
+	switch goal {
+	case "", RewriteGoalClarity:
+		instruction = "Make it clearer and easier to read while keeping the meaning, tone and length roughly the same."
+	case RewriteGoalGrammar:
+		instruction = "Fix spelling, grammar and punctuation only. Do not change wording that is already correct."
+	case RewriteGoalConcise:
+		instruction = "Make it more concise. Remove filler and repetition without losing information."
+	default:
+		return nil, fmt.Errorf("%w: goal must be %s, %s or %s", ErrAssistantInvalidInput, RewriteGoalGrammar, RewriteGoalClarity, RewriteGoalConcise)
+	}
+
+	contextBlock := ""
+	if strings.TrimSpace(req.Context) != "" {
+		contextBlock = fmt.Sprintf("\nSurrounding text, for reference only (do not rewrite it):\n\"\"\"\n%s\n\"\"\"\n", req.Context)
+	}
+
+	prompt := fmt.Sprintf(`Rewrite the selected text from a blog post. %s
+Keep markdown formatting and code blocks intact. Keep the author's language.
+List the main changes as short phrases.
+%s
+Return ONLY JSON: {"rewrite": "...", "changes": ["..."]}
+
+Selection:
+"""
+%s
+"""`, instruction, contextBlock, req.Selection)
+
+	var out model.RewriteResponse
+	if err := s.call(ctx, userID, role, prompt, &out); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(out.Rewrite) == "" {
+		return nil, ErrAssistantBadResponse
+	}
+
+	return &out, nil
+}
+
+// GetQuota - Today's usage for a user
+func (s *AssistantService) GetQuota(ctx context.Context, userID, role string) (*model.AssistantQuota, error) {
+	day := assistantDay()
+	used, err := s.repo.GetRequests(ctx, userID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := &model.AssistantQuota{
+		Used:     used,
+		ResetsAt: day.Add(24 * time.Hour),
+	}
+	if s.unlimited(role) {
+		quota.Unlimited = true
+		return quota, nil
+	}
+
+	quota.Limit = s.dailyQuota
+	quota.Remaining = max(s.dailyQuota-used, 0)
+	return quota, nil
+}
+
+// call - Reserve quota, ask the model for JSON and decode it into out; failed calls do not count
+func (s *AssistantService) call(ctx context.Context, userID, role, prompt string, out any) error {
+	if s.ai.Provider() == nil {
+		return ErrNoLLMProvider
+	}
+
+	day := assistantDay()
+	limit := s.dailyQuota
+	if s.unlimited(role) {
+		limit = 0
+	}
+
+	ok, err := s.repo.ReserveRequest(ctx, userID, day, limit)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAssistantQuotaExceeded
+	}
+
+	release := func() {
+		if err := s.repo.ReleaseRequest(context.WithoutCancel(ctx), userID, day); err != nil {
+			logger.Warnf(ctx, "[ASSISTANT-SERVICE] ⚠️  Failed to release request for %s: %v", userID, err)
+		}
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, assistantCallTimeout)
+	defer cancel()
+
+	resp, err := s.ai.Complete(callCtx, LLMRequest{Prompt: prompt, JSON: true, Feature: UsageFeatureAssistant, UserID: userID})
+	if err != nil {
+		release()
+		if errors.Is(err, ErrAIBudgetExceeded) {
+			logger.Infof(ctx, "[ASSISTANT-SERVICE] %v", err)
+			return err
+		}
+		logger.Errorf(ctx, "[ASSISTANT-SERVICE] ❌ Provider error: %v", err)
+		return fmt.Errorf("%w: %v", ErrAssistantUnavailable, err)
+	}
+
+	if err := s.repo.AddTokens(ctx, userID, day, resp.InputTokens, resp.OutputTokens); err != nil {
+		logger.Warnf(ctx, "[ASSISTANT-SERVICE] ⚠️  %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(cleanJSONResponse(resp.Text)), out); err != nil {
+		release()
+		logger.Errorf(ctx, "[ASSISTANT-SERVICE] Failed to parse JSON. Raw response: %s", resp.Text)
+		return ErrAssistantBadResponse
+	}
+
+	return nil
+}
+
+func (s *AssistantService) unlimited(role string) bool {
+	return s.dailyQuota == 0 || role == "admin"
+}
+
+// assistantDay - Quotas reset at midnight UTC
+func assistantDay() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+func checkAssistantText(text string, limit int) error {
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("%w: text must not be empty", ErrAssistantInvalidInput)
+	}
+	if utf8.RuneCountInString(text) > limit {
+		return fmt.Errorf("%w (max %d characters)", ErrAssistantInputTooLong, limit)
+	}
+	return nil
+}
+
+func currentTitleLine(title string) string {
+	if strings.TrimSpace(title) == "" {
+		return ""
+	}
+	return fmt.Sprintf("Current title: %q", strings.TrimSpace(title))
+}
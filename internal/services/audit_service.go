@@ -0,0 +1,278 @@
+// internal/services/audit_service.go
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/britinogn/quillhub/pkg/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Audit actions
+const (
+	AuditActionAdminRegister        = "user.register_admin"
+	AuditActionLoginSucceeded       = "auth.login"
+	AuditActionLoginFailed          = "auth.login_failed"
+	AuditActionSessionRevoke        = "session.revoke"
+	AuditActionSessionRevokeAll     = "session.revoke_all"
+	AuditActionIdentityLink         = "identity.link"
+	AuditActionIdentityUnlink       = "identity.unlink"
+	AuditActionPostDelete           = "post.delete"
+	AuditActionTranslationDelete    = "post_translation.delete"
+	AuditActionCommentDelete        = "comment.delete"
+	AuditActionRoleChange           = "user.role_change"
+	AuditActionInviteCreate         = "invite.create"
+	AuditActionInviteRevoke         = "invite.revoke"
+	AuditActionInviteRedeem         = "invite.redeem"
+	AuditActionExportRequest        = "account.export_request"
+	AuditActionAccountDeleteRequest = "account.delete_request"
+	AuditActionAccountDeleteCancel  = "account.delete_cancel"
+	AuditActionAccountDelete        = "account.delete"
+	AuditActionAccountAnonymize     = "account.anonymize"
+	AuditActionAutoPosterStart      = "auto_poster.start"
+	AuditActionAutoPosterStop       = "auto_poster.stop"
+	AuditActionAutoPosterRun        = "auto_poster.run"
+	AuditActionAutoPosterSchedule   = "auto_poster.schedule"
+	AuditActionAutoPosterBudget     = "auto_poster.budget_pause"
+	AuditActionAITopicCreate        = "ai_topic.create"
+	AuditActionAITopicUpdate        = "ai_topic.update"
+	AuditActionAITopicDelete        = "ai_topic.delete"
+	AuditActionAICategoryCreate     = "ai_category.create"
+	AuditActionAICategoryUpdate     = "ai_category.update"
+	AuditActionAICategoryDelete     = "ai_category.delete"
+	AuditActionAIPersonaCreate      = "ai_persona.create"
+	AuditActionAIPersonaUpdate      = "ai_persona.update"
+	AuditActionAIPersonaDelete      = "ai_persona.delete"
+	AuditActionPostReviewEdit       = "post_review.edit"
+	AuditActionPostReviewApprove    = "post_review.approve"
+	AuditActionPostReviewReject     = "post_review.reject"
+	AuditActionPostReviewRegenerate = "post_review.regenerate"
+	AuditActionCommentApprove       = "comment.approve"
+	AuditActionCommentReject        = "comment.reject"
+)
+
+// maxAuditExportRows - Upper bound for a single CSV export
+const maxAuditExportRows = 50000
+
+var ErrInvalidAuditFilter = errors.New("invalid audit log filter")
+
+type AuditRepo interface {
+	Create(ctx context.Context, entry *model.AuditLog) error
+	List(ctx context.Context, filter model.AuditLogFilter) ([]*model.AuditLog, error)
+	Count(ctx context.Context, filter model.AuditLogFilter) (int64, error)
+}
+
+// AuditEvent - What a service reports; actor, IP and request ID come from the context
+type AuditEvent struct {
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     any
+	After      any
+	// ActorID overrides the context actor (e.g. logins, where no token exists yet)
+	ActorID string
+}
+
+type AuditService struct {
+	repo AuditRepo
+}
+
+func NewAuditService(repo AuditRepo) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+type PaginatedAuditLogsResponse struct {
+	TotalPages     int                      `json:"totalPages"`
+	TotalDocuments int64                    `json:"totalDocuments"`
+	Page           int                      `json:"page"`
+	Limit          int                      `json:"limit"`
+	Logs           []model.AuditLogResponse `json:"logs"`
+}
+
+// Record - Append an audit entry; failures are logged and never block the audited action
+func (s *AuditService) Record(ctx context.Context, event AuditEvent) {
+	if s == nil {
+		return
+	}
+
+	entry := &model.AuditLog{
+		Action:     event.Action,
+		TargetType: optionalString(event.TargetType),
+		TargetID:   optionalString(event.TargetID),
+	}
+
+	actorID := event.ActorID
+	if actor, ok := utils.ActorFrom(ctx); ok {
+		if actorID == "" {
+			actorID = actor.UserID
+		}
+		entry.ActorRole = optionalString(actor.Role)
+	}
+	if actorID != "" {
+		_ = entry.ActorID.Scan(actorID)
+	}
+
+	info := utils.RequestInfoFrom(ctx)
+	entry.IPAddress = optionalString(info.IPAddress)
+	entry.RequestID = optionalString(info.RequestID)
+
+	var err error
+	if entry.BeforeState, err = snapshot(event.Before); err != nil {
+		logger.Errorf(ctx, "[AUDIT-SERVICE] Failed to encode before snapshot for %s: %v", event.Action, err)
+	}
+	if entry.AfterState, err = snapshot(event.After); err != nil {
+		logger.Errorf(ctx, "[AUDIT-SERVICE] Failed to encode after snapshot for %s: %v", event.Action, err)
+	}
+
+	// Detach from request cancellation so the entry is still written if the client disconnects
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if err := s.repo.Create(writeCtx, entry); err != nil {
+		logger.Errorf(ctx, "[AUDIT-SERVICE] ❌ Failed to record %s on %s/%s: %v", event.Action, event.TargetType, event.TargetID, err)
+	}
+}
+
+// GetLogs - Paginated audit entries for the admin endpoint
+func (s *AuditService) GetLogs(ctx context.Context, filter model.AuditLogFilter, page, limit int) (*PaginatedAuditLogsResponse, error) {
+	if err := validateAuditFilter(filter); err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+	filter.Limit = limit
+	filter.Offset = (page - 1) * limit
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]model.AuditLogResponse, 0, len(entries))
+	for _, entry := range entries {
+		logs = append(logs, toAuditLogResponse(entry))
+	}
+
+	return &PaginatedAuditLogsResponse{
+		TotalPages:     int(math.Ceil(float64(total) / float64(limit))),
+		TotalDocuments: total,
+		Page:           page,
+		Limit:          limit,
+		Logs:           logs,
+	}, nil
+}
+
+// ExportCSV - Write matching audit entries as CSV
+func (s *AuditService) ExportCSV(ctx context.Context, filter model.AuditLogFilter, w io.Writer) error {
+	if err := validateAuditFilter(filter); err != nil {
+		return err
+	}
+
+	filter.Limit = maxAuditExportRows
+	filter.Offset = 0
+
+	entries, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	header := []string{"id", "created_at", "actor_id", "actor_role", "action", "target_type", "target_id", "ip_address", "request_id", "before", "after"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, entry := range entries {
+		actorID := ""
+		if entry.ActorID.Valid {
+			actorID = entry.ActorID.String()
+		}
+
+		record := []string{
+			entry.ID.String(),
+			entry.CreatedAt.UTC().Format(time.RFC3339),
+			actorID,
+			derefString(entry.ActorRole),
+			entry.Action,
+			derefString(entry.TargetType),
+			derefString(entry.TargetID),
+			derefString(entry.IPAddress),
+			derefString(entry.RequestID),
+			string(entry.BeforeState),
+			string(entry.AfterState),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func validateAuditFilter(filter model.AuditLogFilter) error {
+	if filter.ActorID != "" {
+		var actorUUID pgtype.UUID
+		if err := actorUUID.Scan(filter.ActorID); err != nil {
+			return fmt.Errorf("%w: actor_id must be a UUID", ErrInvalidAuditFilter)
+		}
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidAuditFilter)
+	}
+	return nil
+}
+
+func toAuditLogResponse(entry *model.AuditLog) model.AuditLogResponse {
+	response := model.AuditLogResponse{
+		ID:         entry.ID.String(),
+		ActorRole:  entry.ActorRole,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		IPAddress:  entry.IPAddress,
+		RequestID:  entry.RequestID,
+		Before:     entry.BeforeState,
+		After:      entry.AfterState,
+		CreatedAt:  entry.CreatedAt,
+	}
+	if entry.ActorID.Valid {
+		actorID := entry.ActorID.String()
+		response.ActorID = &actorID
+	}
+	return response
+}
+
+// snapshot - Encode a before/after value as JSON (nil stays empty)
+func snapshot(value any) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
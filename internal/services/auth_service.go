@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/britinogn/quillhub/internal/model"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/britinogn/quillhub/pkg/logger"
 	"github.com/britinogn/quillhub/pkg/utils"
 )
 
@@ -18,22 +20,37 @@ var (
 	ErrInvalidCredentials = errors.New("invalid email/username or password")
 	ErrInvalidToken = errors.New("invalid token")
     ErrDatabaseOperation = errors.New("database operation failed")
+    ErrUserNotFound      = errors.New("user not found")
+    ErrCannotChangeOwnRole = errors.New("admins cannot change their own role")
+    ErrAdminRequired     = errors.New("only admins can create admin users")
 )
 type UserRepo interface {
 	Create(ctx context.Context, user *model.User) error
+	FindByID(ctx context.Context, userID string) (*model.User, error)
 	FindByEmail(ctx context.Context, email string) (*model.User, error)
 	FindByUsername(ctx context.Context, username string) (*model.User, error) 
+	UpdateRole(ctx context.Context, userID, role string) error
 }
 
 type AuthService struct {
-	repo UserRepo
+	repo     UserRepo
+	sessions *SessionService
+	audit    *AuditService
+	invites  InviteRepo
+	policy   RegistrationPolicy
 }
 
-func NewAuthService(repo UserRepo) *AuthService{
-	return &AuthService{repo: repo}
+func NewAuthService(repo UserRepo, sessions *SessionService, audit *AuditService, invites InviteRepo, policy RegistrationPolicy) *AuthService{
+	return &AuthService{repo: repo, sessions: sessions, audit: audit, invites: invites, policy: policy}
 }
 
-func (s *AuthService) Register(ctx context.Context, user *model.User) error {
+// RegistrationInfo - Current registration mode for the signup form
+func (s *AuthService) RegistrationInfo() model.RegistrationInfo {
+	return s.policy.Info()
+}
+
+// Register - Public signup; always creates a "user" unless an admin-issued invite grants another role
+func (s *AuthService) Register(ctx context.Context, user *model.User, inviteCode string) error {
 	if user == nil {
 		return ErrInvalidInput
 	}
@@ -46,22 +63,19 @@ func (s *AuthService) Register(ctx context.Context, user *model.User) error {
 
 
 	//   ONE combined check for all required fields
-	if name == "" ||
-		username == "" ||
-		email == "" ||
-		password == "" {
-		return errors.New("name, username, email, and password are required")
+	if err := requireFields("name", name, "username", username, "email", email, "password", password); err != nil {
+		return err
 	}
 
 	// Optional: very basic extra rules 
 	if len(username) < 3 {
-		return errors.New("username must be at least 3 characters")
+		return utils.Invalid("username", "username must be at least 3 characters")
 	}
 	if len(password) < 8 {
-		return errors.New("password must be at least 8 characters")  
+		return utils.Invalid("password", "password must be at least 8 characters")
 	}
 	if !strings.Contains(email, "@"){
-		return errors.New("invalid email format")
+		return utils.Invalid("email", "invalid email format")
 	}
 
 	// Check if username already exists
@@ -82,28 +96,121 @@ func (s *AuthService) Register(ctx context.Context, user *model.User) error {
 		return ErrEmailAlreadyRegistered
 	}
 
-	// Update the passed user object directly
+	// Enforce registration mode
+	inviteCode = strings.TrimSpace(inviteCode)
+	needsInvite, policyErr := s.policy.RequiresInvite(email)
+	if needsInvite && inviteCode == "" {
+		return policyErr
+	}
+
+	// Update the passed user object directly; clients never choose their role
 	user.Name = name
 	user.Username = username
 	user.Email = email
-
-	if user.Role == "" {
-		user.Role = "user"
+	user.Role = "user"
+
+	// Redeem invite (single use) - claimed before creating the user so it cannot be used twice
+	var invite *model.InviteCode
+	if inviteCode != "" {
+		invite, err = s.invites.Claim(ctx, inviteCode)
+		if err != nil {
+			return err
+		}
+		if invite == nil {
+			return ErrInvalidInvite
+		}
+		if invite.Email != nil && *invite.Email != email {
+			s.releaseInvite(ctx, invite)
+			return ErrInvalidInvite
+		}
+		user.Role = invite.Role
 	}
-	// Block invalid roles early
-	// if user.Role != "" && user.Role != "user" {
-	// 	return errors.New("cannot select role during registration - only 'user' allowed")
-	// }
 
 	// Create user - this will populate ID and CreatedAt
 	if err := s.repo.Create(ctx, user); err != nil {
+		if invite != nil {
+			s.releaseInvite(ctx, invite)
+		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
+	if invite != nil {
+		if err := s.invites.MarkUsedBy(ctx, invite.ID.String(), user.ID.String()); err != nil {
+			logger.Errorf(ctx, "[AUTH-SERVICE] Failed to record invite redemption: %v", err)
+		}
+		s.audit.Record(ctx, AuditEvent{
+			Action:     AuditActionInviteRedeem,
+			TargetType: "invite",
+			TargetID:   invite.ID.String(),
+			ActorID:    user.ID.String(),
+			After:      map[string]string{"user_id": user.ID.String(), "role": user.Role},
+		})
+	}
+
 	return nil
 }
 
-func (s *AuthService) Login(ctx context.Context, identifier, password string) (*model.User, string, error) {
+func (s *AuthService) releaseInvite(ctx context.Context, invite *model.InviteCode) {
+	if err := s.invites.Release(ctx, invite.ID.String()); err != nil {
+		logger.Errorf(ctx, "[AUTH-SERVICE] Failed to release invite %s: %v", invite.ID.String(), err)
+	}
+}
+
+// ChangeUserRole - Admin flow for granting or removing elevated roles; the target's sessions
+// are revoked so tokens claiming the old role stop working
+func (s *AuthService) ChangeUserRole(ctx context.Context, targetUserID, role, requestingUserID string) (*model.User, error) {
+	role = strings.ToLower(strings.TrimSpace(role))
+	if !assignableRoles[role] {
+		return nil, ErrInvalidRole
+	}
+
+	if targetUserID == requestingUserID {
+		return nil, ErrCannotChangeOwnRole
+	}
+
+	var targetUUID pgtype.UUID
+	if err := targetUUID.Scan(targetUserID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	user, err := s.repo.FindByID(ctx, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Role == "bot" {
+		return nil, ErrUserNotFound
+	}
+
+	previousRole := user.Role
+	if previousRole == role {
+		user.Password = ""
+		return user, nil
+	}
+
+	if err := s.repo.UpdateRole(ctx, targetUserID, role); err != nil {
+		return nil, fmt.Errorf("failed to change role: %w", err)
+	}
+	user.Role = role
+	user.Password = ""
+
+	// Tokens carry the role they were issued with; sign the user out so the next one has the new role
+	if _, err := s.sessions.RevokeAllSessions(ctx, targetUserID); err != nil {
+		return nil, fmt.Errorf("role changed, but failed to sign out the user: %w", err)
+	}
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionRoleChange,
+		TargetType: "user",
+		TargetID:   targetUserID,
+		Before:     map[string]string{"role": previousRole},
+		After:      map[string]string{"role": role},
+	})
+
+	logger.Infof(ctx, "[AUTH-SERVICE] Role of %s changed from %s to %s by %s", targetUserID, previousRole, role, requestingUserID)
+	return user, nil
+}
+
+func (s *AuthService) Login(ctx context.Context, identifier, password string, meta model.SessionMeta) (*model.User, string, error) {
 	if identifier == "" || password == "" {
 		return nil, "",  ErrInvalidCredentials
 	}
@@ -128,11 +235,13 @@ func (s *AuthService) Login(ctx context.Context, identifier, password string) (*
     }
 
 	if user == nil {
+        s.audit.Record(ctx, AuditEvent{Action: AuditActionLoginFailed, TargetType: "user", TargetID: identifier})
         return nil, "", ErrInvalidCredentials
     }
 
 	//Check hash password
 	if !utils.CheckPasswordHash(password, user.Password){
+		s.audit.Record(ctx, AuditEvent{Action: AuditActionLoginFailed, TargetType: "user", TargetID: user.ID.String(), ActorID: user.ID.String()})
 		return nil, "", ErrInvalidCredentials
 	}
 	user.Password = ""
@@ -144,12 +253,14 @@ func (s *AuthService) Login(ctx context.Context, identifier, password string) (*
     //     return nil, "", fmt.Errorf("failed to generate token: %w", err)
     // }
 
-	// Generate token 
-    token, err := utils.GenerateToken(user.ID.String(), user.Email, user.Username, user.Role)
+	// Create session and generate token bound to it
+    token, err := s.sessions.IssueToken(ctx, user, meta)
     if err != nil {
-        return nil, "", fmt.Errorf("failed to generate token: %w", err)
+        return nil, "", fmt.Errorf("failed to start session: %w", err)
     }
 
+	s.audit.Record(ctx, AuditEvent{Action: AuditActionLoginSucceeded, TargetType: "user", TargetID: user.ID.String(), ActorID: user.ID.String()})
+
 
 	return user, token, nil
 
@@ -159,9 +270,15 @@ func (s *AuthService) Login(ctx context.Context, identifier, password string) (*
 func (s *AuthService) RegisterAdmin(ctx context.Context, user *model.User, requestingUserRole string) error {
     // Check if the requesting user is an admin
     if requestingUserRole != "admin" {
-        return errors.New("unauthorized: only admins can create admin users")
+        return ErrAdminRequired
     }
 
+    return s.CreateAdmin(ctx, user)
+}
+
+// CreateAdmin - Create an admin account without a requesting admin; used by RegisterAdmin
+// and by the create-admin command, which bootstraps the first admin
+func (s *AuthService) CreateAdmin(ctx context.Context, user *model.User) error {
     // Same validation as Register
     if user == nil {
         return ErrInvalidInput
@@ -172,18 +289,18 @@ func (s *AuthService) RegisterAdmin(ctx context.Context, user *model.User, reque
     email := strings.ToLower(strings.TrimSpace(user.Email))
     password := strings.TrimSpace(user.Password)
 
-    if name == "" || username == "" || email == "" || password == "" {
-        return errors.New("name, username, email, and password are required")
+    if err := requireFields("name", name, "username", username, "email", email, "password", password); err != nil {
+        return err
     }
 
     if len(username) < 3 {
-        return errors.New("username must be at least 3 characters")
+        return utils.Invalid("username", "username must be at least 3 characters")
     }
     if len(password) < 6 {
-        return errors.New("password must be at least 6 characters")
+        return utils.Invalid("password", "password must be at least 6 characters")
     }
     if !strings.Contains(email, "@") {
-        return errors.New("invalid email format")
+        return utils.Invalid("email", "invalid email format")
     }
 
     // Check username
@@ -214,5 +331,33 @@ func (s *AuthService) RegisterAdmin(ctx context.Context, user *model.User, reque
         return fmt.Errorf("failed to create admin user: %w", err)
     }
 
+    s.audit.Record(ctx, AuditEvent{
+        Action:     AuditActionAdminRegister,
+        TargetType: "user",
+        TargetID:   user.ID.String(),
+        After: model.UserResponse{
+            ID:        user.ID.String(),
+            Name:      user.Name,
+            Username:  user.Username,
+            Email:     user.Email,
+            Role:      user.Role,
+            CreatedAt: user.CreatedAt,
+        },
+    })
+
     return nil
-}
\ No newline at end of file
+}
+
+// requireFields - A validation error naming every empty value; takes name, value pairs
+func requireFields(pairs ...string) error {
+	var fields []utils.FieldError
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			fields = append(fields, utils.FieldError{Field: pairs[i], Message: pairs[i] + " is required"})
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &utils.ValidationError{Fields: fields}
+}
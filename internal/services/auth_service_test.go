@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/utils"
+)
+
+func TestChangeUserRoleRevokesOldTokens(t *testing.T) {
+	ctx := context.Background()
+
+	moderator := &model.User{ID: newUUID(), Email: "mod@example.com", Username: "mod", Role: "moderator"}
+	bystander := &model.User{ID: newUUID(), Email: "user@example.com", Username: "user", Role: "user"}
+	users := newMemoryUserRepo(moderator, bystander)
+	sessions := NewSessionService(&memorySessionRepo{sessions: make(map[string]*model.Session)}, nil,
+		utils.NewTokenSigner("test-secret-that-is-long-enough-for-hs256", time.Hour))
+	auth := NewAuthService(users, sessions, nil, nil, openPolicy())
+
+	issue := func(user *model.User) *utils.Claims {
+		t.Helper()
+		token, err := sessions.IssueToken(ctx, user, model.SessionMeta{})
+		if err != nil {
+			t.Fatalf("IssueToken: %v", err)
+		}
+		claims, err := sessions.VerifyToken(token)
+		if err != nil {
+			t.Fatalf("VerifyToken: %v", err)
+		}
+		return claims
+	}
+	oldToken := issue(moderator)
+	otherToken := issue(bystander)
+
+	if _, err := auth.ChangeUserRole(ctx, moderator.ID.String(), "user", newUUID().String()); err != nil {
+		t.Fatalf("ChangeUserRole: %v", err)
+	}
+
+	if oldToken.Role != "moderator" {
+		t.Fatalf("token role = %q, want the role it was issued with", oldToken.Role)
+	}
+	if err := sessions.ValidateSession(ctx, oldToken.SessionID, oldToken.UserID); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("demoted user's old token: ValidateSession = %v, want %v", err, ErrSessionRevoked)
+	}
+	if err := sessions.ValidateSession(ctx, otherToken.SessionID, otherToken.UserID); err != nil {
+		t.Errorf("another user's session was revoked: %v", err)
+	}
+
+	newToken := issue(users.users[moderator.ID.String()])
+	if newToken.Role != "user" {
+		t.Errorf("new token role = %q, want user", newToken.Role)
+	}
+	if err := sessions.ValidateSession(ctx, newToken.SessionID, newToken.UserID); err != nil {
+		t.Errorf("new token after the role change: %v", err)
+	}
+}
@@ -3,86 +3,160 @@ package services
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/britinogn/quillhub/config"
 	"github.com/britinogn/quillhub/internal/model"
-	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/robfig/cron/v3"
+)
+
+const minAutoPosterInterval = config.MinAutoPosterInterval
+
+var (
+	ErrAutoPosterRunning     = errors.New("auto-poster is already running")
+	ErrAutoPosterNotRunning  = errors.New("auto-poster is not running")
+	ErrAutoPosterBusy        = errors.New("a post is already being generated")
+	ErrInvalidPosterSchedule = errors.New("invalid auto-poster schedule")
 )
 
 type AutoPosterService struct {
-	aiService   *AIService
-	postRepo    PostRepo
-	botUserID   string
-	ticker      *time.Ticker
-	stopChan    chan bool
-	mu          sync.Mutex  // ✅ Prevent concurrent posting
-	isRunning   bool        // ✅ Track running state
+	jobs     *GenerationJobService
+	topics   *TopicService
+	postRepo PostRepo
+	personas *PersonaService
+	audit    *AuditService
+	usage    *AIUsageService
+
+	requireReview bool // AUTO_POSTER_REQUIRE_REVIEW: queue posts for an admin instead of publishing
+
+	mu        sync.Mutex // ✅ Guards everything below
+	isRunning bool       // ✅ Track running state
+	schedule  cron.Schedule
+	interval  time.Duration // set when scheduled by interval
+	cronExpr  string        // set when scheduled by cron expression
+	stopChan  chan struct{}
+	runners   map[string]*personaRunner // by persona ID
+	manual    map[string]*personaRunner // disabled personas run by hand, while they run
+	defaultID string
+
+	budgetPaused bool // runs are skipped while the AI budget is used up
+	pausedReason *string
 }
 
-func NewAutoPosterService(aiService *AIService, postRepo PostRepo, botUserID string) *AutoPosterService {
-	return &AutoPosterService{
-		aiService: aiService,
-		postRepo:  postRepo,
-		botUserID: botUserID,
-		stopChan:  make(chan bool),
-		isRunning: false,
+// personaRunner - Schedule loop and run history of one persona
+type personaRunner struct {
+	persona  *model.BotPersona
+	schedule cron.Schedule // nil: follows the main schedule
+	stop     chan struct{} // nil while the loop is not running
+	wake     chan struct{}
+	nextRun  time.Time
+
+	generating    bool // ✅ Prevent concurrent posting by the same persona
+	lastRunAt     *time.Time
+	lastSuccessAt *time.Time
+	lastError     *string
+	lastPostID    *string
+	totalRuns     int64
+	totalFailures int64
+}
+
+func NewAutoPosterService(jobs *GenerationJobService, topics *TopicService, postRepo PostRepo, personas *PersonaService, audit *AuditService, usage *AIUsageService, cfg config.AutoPosterConfig) *AutoPosterService {
+	s := &AutoPosterService{
+		jobs:     jobs,
+		topics:   topics,
+		postRepo: postRepo,
+		personas: personas,
+		audit:    audit,
+		usage:    usage,
+		runners:  make(map[string]*personaRunner),
+		manual:   make(map[string]*personaRunner),
+
+		requireReview: cfg.RequireReview,
 	}
+
+	// Main schedule from the cron expression or the interval (validated by config.Load);
+	// personas without a schedule of their own post on it
+	if cfg.Cron != "" {
+		if err := s.setCron(cfg.Cron); err == nil {
+			return s
+		}
+		logger.Warnf(context.Background(), "[AUTO-POSTER] ⚠️  Invalid AUTO_POSTER_CRON %q, falling back to interval", cfg.Cron)
+	}
+	if err := s.setInterval(cfg.Interval); err != nil {
+		logger.Warnf(context.Background(), "[AUTO-POSTER] ⚠️  Invalid AUTO_POSTER_INTERVAL %s, using %s", cfg.Interval, config.Defaults().AutoPoster.Interval)
+		_ = s.setInterval(config.Defaults().AutoPoster.Interval)
+	}
+
+	return s
 }
 
-// Start - Start the auto-posting scheduler (every 25 minutes)
-func (s *AutoPosterService) Start() {
+// Start - Start a scheduler loop for every enabled persona; fails, staying stopped, if the
+// personas cannot be loaded
+func (s *AutoPosterService) Start() error {
 	s.mu.Lock()
 	if s.isRunning {
-		log.Println("[AUTO-POSTER] ⚠️  Service already running")
 		s.mu.Unlock()
-		return
+		logger.Warnf(context.Background(), "[AUTO-POSTER] ⚠️  Service already running")
+		return ErrAutoPosterRunning
 	}
 	s.isRunning = true
+	s.stopChan = make(chan struct{})
+	stop := s.stopChan
 	s.mu.Unlock()
 
-	log.Println("[AUTO-POSTER] 🤖 Starting auto-poster service (posts every 25 minutes)")
-
-	// Create ticker for 25 minutes
-	s.ticker = time.NewTicker(1 * time.Hour)
-
-	// ✅ Post immediately on start (optional - comment out if not needed)
-	go s.createAndPostBlog()
-
-	// Start the scheduler
-	go func() {
-		for {
-			select {
-			case <-s.ticker.C:
-				log.Println("[AUTO-POSTER] ⏰ Timer triggered - creating new post")
-				go s.createAndPostBlog()
-			case <-s.stopChan:
-				log.Println("[AUTO-POSTER] ⏹️  Stopping auto-poster service")
-				s.ticker.Stop()
-				s.mu.Lock()
-				s.isRunning = false
-				s.mu.Unlock()
-				return
-			}
+	logger.Infof(context.Background(), "[AUTO-POSTER] 🤖 Starting auto-poster service (%s)", s.describeSchedule())
+
+	if err := s.syncPersonas(context.Background()); err != nil {
+		// Nothing was scheduled; report stopped so a later Start can try again
+		s.mu.Lock()
+		for _, runner := range s.runners {
+			s.stopLoop(runner)
 		}
-	}()
+		s.isRunning = false
+		s.stopChan = nil
+		s.mu.Unlock()
+		logger.Errorf(context.Background(), "[AUTO-POSTER] ❌ Failed to load personas: %v", err)
+		return fmt.Errorf("failed to load personas: %w", err)
+	}
+
+	// ✅ Post immediately on start as the default persona (optional - comment out if not needed)
+	s.mu.Lock()
+	first := s.runners[s.defaultID]
+	s.mu.Unlock()
+	if first != nil {
+		go s.run(first, GenerationTriggerSchedule)
+	}
 
-	log.Println("[AUTO-POSTER] ✅ Auto-poster service started successfully")
+	// Reload schedules when admins change personas
+	go s.watch(stop)
+
+	logger.Infof(context.Background(), "[AUTO-POSTER] ✅ Auto-poster service started successfully")
+	return nil
 }
 
-// Stop - Stop the auto-posting scheduler
-func (s *AutoPosterService) Stop() {
+// Stop - Stop every scheduler loop
+func (s *AutoPosterService) Stop() error {
 	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if !s.isRunning {
-		log.Println("[AUTO-POSTER] ⚠️  Service not running")
-		s.mu.Unlock()
-		return
+		logger.Warnf(context.Background(), "[AUTO-POSTER] ⚠️  Service not running")
+		return ErrAutoPosterNotRunning
 	}
-	s.mu.Unlock()
 
-	s.stopChan <- true
-	log.Println("[AUTO-POSTER] 🛑 Stop signal sent")
+	close(s.stopChan)
+	for _, runner := range s.runners {
+		s.stopLoop(runner)
+	}
+	s.isRunning = false
+	logger.Infof(context.Background(), "[AUTO-POSTER] 🛑 Auto-poster stopped")
+	return nil
 }
 
 // IsRunning - Check if auto-poster is currently running
@@ -92,58 +166,593 @@ func (s *AutoPosterService) IsRunning() bool {
 	return s.isRunning
 }
 
-// createAndPostBlog - Generate and publish a blog post
-func (s *AutoPosterService) createAndPostBlog() {
-	// ✅ Use timeout context to prevent hanging
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-	defer cancel()
+// watch - Keep persona loops in line with the personas table while running
+func (s *AutoPosterService) watch(stop chan struct{}) {
+	for {
+		select {
+		case <-s.personas.Changes():
+			if err := s.syncPersonas(context.Background()); err != nil {
+				logger.Warnf(context.Background(), "[AUTO-POSTER] ⚠️  Failed to reload personas: %v", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// syncPersonas - Add, reschedule or drop persona runners to match the enabled personas
+func (s *AutoPosterService) syncPersonas(ctx context.Context) error {
+	personas, err := s.personas.ListEnabled(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool, len(personas))
+	for _, persona := range personas {
+		schedule, err := personaSchedule(persona)
+		if err != nil {
+			logger.Warnf(ctx, "[AUTO-POSTER] ⚠️  Persona %q has an invalid schedule, skipping it: %v", persona.Name, err)
+			continue
+		}
+
+		id := persona.ID.String()
+		seen[id] = true
+		if persona.IsDefault {
+			s.defaultID = id
+		}
+
+		runner, ok := s.runners[id]
+		if !ok {
+			// A manual run still in progress keeps its busy flag
+			runner, ok = s.manual[id]
+			if ok {
+				delete(s.manual, id)
+			} else {
+				runner = &personaRunner{}
+			}
+			s.runners[id] = runner
+		}
+		runner.persona = persona
+		runner.schedule = schedule
+
+		if s.isRunning {
+			if runner.stop == nil {
+				s.startLoop(runner)
+			} else {
+				wakeLoop(runner)
+			}
+		}
+	}
 
-	log.Println("[AUTO-POSTER] 📝 Generating new AI blog post...")
+	for id, runner := range s.runners {
+		if !seen[id] {
+			s.stopLoop(runner)
+			delete(s.runners, id)
+		}
+	}
 
-	// Generate random topic and category
-	topic := s.aiService.GenerateRandomTopic()
-	category := s.aiService.GenerateRandomCategory()
+	return nil
+}
 
-	log.Printf("[AUTO-POSTER] 💡 Topic: %s | Category: %s", topic, category)
+// startLoop - Caller holds s.mu
+func (s *AutoPosterService) startLoop(runner *personaRunner) {
+	runner.stop = make(chan struct{})
+	runner.wake = make(chan struct{}, 1)
+	go s.loop(runner, runner.stop, runner.wake)
+}
 
-	// Generate blog post using AI
-	generatedPost, err := s.aiService.GenerateBlogPost(ctx, topic)
+// stopLoop - Caller holds s.mu
+func (s *AutoPosterService) stopLoop(runner *personaRunner) {
+	if runner.stop != nil {
+		close(runner.stop)
+		runner.stop = nil
+		runner.wake = nil
+	}
+	runner.nextRun = time.Time{}
+}
+
+// wakeLoop - Make a loop pick up a new schedule; caller holds s.mu
+func wakeLoop(runner *personaRunner) {
+	select {
+	case runner.wake <- struct{}{}:
+	default:
+	}
+}
+
+// loop - Wait for the persona's next scheduled time, post, repeat
+func (s *AutoPosterService) loop(runner *personaRunner, stop, wake chan struct{}) {
+	for {
+		s.mu.Lock()
+		next := s.scheduleOf(runner).Next(time.Now())
+		runner.nextRun = next
+		name := runner.persona.Name
+		s.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+			logger.Infof(context.Background(), "[AUTO-POSTER] ⏰ Timer triggered - creating new post as %s", name)
+			go s.run(runner, GenerationTriggerSchedule)
+		case <-wake:
+			timer.Stop()
+		case <-stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// scheduleOf - The persona's own schedule, or the main one; caller holds s.mu
+func (s *AutoPosterService) scheduleOf(runner *personaRunner) cron.Schedule {
+	if runner.schedule != nil {
+		return runner.schedule
+	}
+	return s.schedule
+}
+
+// PostNow - Manually trigger a post by a persona ("" for the default persona)
+func (s *AutoPosterService) PostNow(ctx context.Context, personaID string) error {
+	var (
+		persona *model.BotPersona
+		err     error
+	)
+	if personaID == "" {
+		persona, err = s.personas.Default(ctx)
+	} else {
+		persona, err = s.personas.Get(ctx, personaID)
+	}
 	if err != nil {
-		log.Printf("[AUTO-POSTER] ❌ Failed to generate post: %v", err)
-		return
+		return err
+	}
+
+	if err := s.usage.CheckBudget(ctx, UsageFeaturePostGeneration); err != nil {
+		return err
+	}
+
+	id := persona.ID.String()
+	s.mu.Lock()
+	runner, ok := s.runners[id]
+	if !ok {
+		// Disabled personas can still be run by hand; the runner has no loop and is
+		// kept in s.manual until its run ends, so concurrent calls see it busy
+		runner, ok = s.manual[id]
+		if !ok {
+			runner = &personaRunner{persona: persona}
+			s.manual[id] = runner
+		}
+	}
+	if runner.generating {
+		s.mu.Unlock()
+		return ErrAutoPosterBusy
+	}
+	s.begin(runner)
+	s.mu.Unlock()
+
+	logger.Infof(ctx, "[AUTO-POSTER] 🚀 Manual post creation triggered for %s", persona.Name)
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAutoPosterRun,
+		TargetType: "auto_poster",
+		TargetID:   persona.ID.String(),
+		After:      map[string]string{"persona": persona.Name},
+	})
+	go s.generate(runner, GenerationTriggerManual)
+	return nil
+}
+
+// StartScheduler - Admin start
+func (s *AutoPosterService) StartScheduler(ctx context.Context) error {
+	if err := s.Start(); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, AuditEvent{Action: AuditActionAutoPosterStart, TargetType: "auto_poster"})
+	return nil
+}
+
+// StopScheduler - Admin stop
+func (s *AutoPosterService) StopScheduler(ctx context.Context) error {
+	if err := s.Stop(); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, AuditEvent{Action: AuditActionAutoPosterStop, TargetType: "auto_poster"})
+	return nil
+}
+
+// UpdateSchedule - Switch the main schedule to a new interval or cron expression; takes effect immediately
+func (s *AutoPosterService) UpdateSchedule(ctx context.Context, req *model.UpdateAutoPosterScheduleRequest) (*model.AutoPosterStatus, error) {
+	interval := strings.TrimSpace(req.Interval)
+	expr := strings.TrimSpace(req.Cron)
+
+	schedule, err := parsePosterSchedule(interval, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.describeSchedule()
+
+	s.mu.Lock()
+	s.schedule = schedule
+	s.cronExpr = expr
+	s.interval = 0
+	if expr == "" {
+		s.interval, _ = time.ParseDuration(interval)
+	}
+
+	// Wake the loops on the main schedule so it is used for their next run
+	now := time.Now()
+	for _, runner := range s.runners {
+		if runner.schedule == nil && runner.stop != nil {
+			runner.nextRun = s.schedule.Next(now)
+			wakeLoop(runner)
+		}
+	}
+	s.mu.Unlock()
+
+	after := s.describeSchedule()
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAutoPosterSchedule,
+		TargetType: "auto_poster",
+		Before:     map[string]string{"schedule": before},
+		After:      map[string]string{"schedule": after},
+	})
+	logger.Infof(ctx, "[AUTO-POSTER] 🗓️  Schedule changed: %s → %s", before, after)
+
+	status := s.Status()
+	return &status, nil
+}
+
+// parsePosterSchedule - Exactly one of a Go duration (at least a minute) or a 5-field cron expression
+func parsePosterSchedule(interval, expr string) (cron.Schedule, error) {
+	interval = strings.TrimSpace(interval)
+	expr = strings.TrimSpace(expr)
+
+	if (interval == "") == (expr == "") {
+		return nil, fmt.Errorf("%w: set exactly one of interval or cron", ErrInvalidPosterSchedule)
+	}
+
+	if expr != "" {
+		schedule, err := cron.ParseStandard(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPosterSchedule, err)
+		}
+		return schedule, nil
+	}
+
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: interval must be a duration like 30m or 2h", ErrInvalidPosterSchedule)
+	}
+	if d < minAutoPosterInterval {
+		return nil, fmt.Errorf("%w: interval must be at least %s", ErrInvalidPosterSchedule, minAutoPosterInterval)
+	}
+	return cron.Every(d), nil
+}
+
+// personaSchedule - nil when the persona follows the main schedule
+func personaSchedule(persona *model.BotPersona) (cron.Schedule, error) {
+	if persona.ScheduleInterval == nil && persona.ScheduleCron == nil {
+		return nil, nil
 	}
+	return parsePosterSchedule(derefString(persona.ScheduleInterval), derefString(persona.ScheduleCron))
+}
+
+func (s *AutoPosterService) setInterval(d time.Duration) error {
+	schedule, err := parsePosterSchedule(d.String(), "")
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schedule = schedule
+	s.interval = d
+	s.cronExpr = ""
+	return nil
+}
+
+func (s *AutoPosterService) setCron(expr string) error {
+	schedule, err := parsePosterSchedule("", expr)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schedule = schedule
+	s.cronExpr = expr
+	s.interval = 0
+	return nil
+}
+
+func (s *AutoPosterService) describeSchedule() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cronExpr != "" {
+		return "cron " + s.cronExpr
+	}
+	return "every " + s.interval.String()
+}
+
+// Status - Current scheduler state; the top-level run fields sum up every persona
+func (s *AutoPosterService) Status() model.AutoPosterStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	// Parse bot user UUID
-	var botUserUUID pgtype.UUID
-	if err := botUserUUID.Scan(s.botUserID); err != nil {
-		log.Printf("[AUTO-POSTER] ❌ Invalid bot user ID: %v", err)
+	status := model.AutoPosterStatus{
+		Running:       s.isRunning,
+		RequireReview: s.requireReview,
+		BudgetPaused:  s.budgetPaused,
+		PausedReason:  s.pausedReason,
+		Personas:      []model.PersonaRunStatus{},
+	}
+
+	if s.cronExpr != "" {
+		status.ScheduleType = "cron"
+		status.Cron = s.cronExpr
+	} else {
+		status.ScheduleType = "interval"
+		status.Interval = s.interval.String()
+	}
+
+	for _, runner := range s.runners {
+		run := s.runnerStatus(runner)
+		status.Personas = append(status.Personas, run)
+
+		status.Generating = status.Generating || run.Generating
+		status.TotalRuns += run.TotalRuns
+		status.TotalFailures += run.TotalFailures
+		if run.NextRunAt != nil && (status.NextRunAt == nil || run.NextRunAt.Before(*status.NextRunAt)) {
+			status.NextRunAt = run.NextRunAt
+		}
+		if run.LastRunAt != nil && (status.LastRunAt == nil || run.LastRunAt.After(*status.LastRunAt)) {
+			status.LastRunAt = run.LastRunAt
+			status.LastError = run.LastError
+		}
+		if run.LastSuccessAt != nil && (status.LastSuccessAt == nil || run.LastSuccessAt.After(*status.LastSuccessAt)) {
+			status.LastSuccessAt = run.LastSuccessAt
+			status.LastPostID = run.LastPostID
+		}
+	}
+
+	// Default persona first, then by name
+	sort.Slice(status.Personas, func(i, j int) bool {
+		if (status.Personas[i].PersonaID == s.defaultID) != (status.Personas[j].PersonaID == s.defaultID) {
+			return status.Personas[i].PersonaID == s.defaultID
+		}
+		return status.Personas[i].Name < status.Personas[j].Name
+	})
+
+	return status
+}
+
+// runnerStatus - Caller holds s.mu
+func (s *AutoPosterService) runnerStatus(runner *personaRunner) model.PersonaRunStatus {
+	run := model.PersonaRunStatus{
+		PersonaID:     runner.persona.ID.String(),
+		Name:          runner.persona.Name,
+		Schedule:      "main",
+		Generating:    runner.generating,
+		LastRunAt:     runner.lastRunAt,
+		LastSuccessAt: runner.lastSuccessAt,
+		LastError:     runner.lastError,
+		LastPostID:    runner.lastPostID,
+		TotalRuns:     runner.totalRuns,
+		TotalFailures: runner.totalFailures,
+	}
+
+	switch {
+	case runner.persona.ScheduleCron != nil:
+		run.Schedule = "cron " + *runner.persona.ScheduleCron
+	case runner.persona.ScheduleInterval != nil:
+		run.Schedule = "every " + *runner.persona.ScheduleInterval
+	}
+
+	if runner.stop != nil && !runner.nextRun.IsZero() {
+		next := runner.nextRun
+		run.NextRunAt = &next
+	}
+	return run
+}
+
+// run - Generate one post as the runner's persona and record the outcome; skipped if the persona
+// is already generating or the AI budget is used up
+func (s *AutoPosterService) run(runner *personaRunner, trigger string) {
+	if !s.withinBudget() {
 		return
 	}
 
-	// Create the post
-	post := &model.Post{
-		Title:       generatedPost.Title,
-		Content:     generatedPost.Content,
-		AuthorID:    botUserUUID,
-		Tags:        generatedPost.Tags,
-		Category:    &category,
-		IsPublished: true,
-		ImageURL:   []string{},
+	s.mu.Lock()
+	if runner.generating {
+		name := runner.persona.Name
+		s.mu.Unlock()
+		logger.Warnf(context.Background(), "[AUTO-POSTER] ⚠️  Previous post by %s still generating, skipping this run", name)
+		return
 	}
+	s.begin(runner)
+	s.mu.Unlock()
 
-	// Save to database
-	if err := s.postRepo.Create(ctx, post); err != nil {
-		log.Printf("[AUTO-POSTER] ❌ Failed to save post: %v", err)
+	s.generate(runner, trigger)
+}
+
+// begin - Mark the runner as generating; caller holds s.mu and has checked it is not
+func (s *AutoPosterService) begin(runner *personaRunner) {
+	runner.generating = true
+	now := time.Now()
+	runner.lastRunAt = &now
+	runner.totalRuns++
+}
+
+// generate - The post for a run started with begin, and its outcome
+func (s *AutoPosterService) generate(runner *personaRunner, trigger string) {
+	s.mu.Lock()
+	persona := runner.persona
+	s.mu.Unlock()
+
+	postID, err := s.createAndPostBlog(persona, trigger)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	runner.generating = false
+	if id := persona.ID.String(); s.manual[id] == runner {
+		delete(s.manual, id)
+	}
+	if err != nil {
+		message := err.Error()
+		runner.lastError = &message
+		runner.totalFailures++
 		return
 	}
+	finished := time.Now()
+	runner.lastSuccessAt = &finished
+	runner.lastError = nil
+	runner.lastPostID = &postID
+}
+
+// withinBudget - Pause (once, audited) while the post generation budget is exceeded and
+// resume by itself when it allows runs again, e.g. at the start of the next month
+func (s *AutoPosterService) withinBudget() bool {
+	ctx := context.Background()
+	err := s.usage.CheckBudget(ctx, UsageFeaturePostGeneration)
+
+	s.mu.Lock()
+	wasPaused := s.budgetPaused
+	if err != nil {
+		message := err.Error()
+		s.budgetPaused = true
+		s.pausedReason = &message
+	} else {
+		s.budgetPaused = false
+		s.pausedReason = nil
+	}
+	s.mu.Unlock()
+
+	switch {
+	case err != nil && !wasPaused:
+		logger.Infof(ctx, "[AUTO-POSTER] ⏸️  Paused: %v", err)
+		s.audit.Record(ctx, AuditEvent{
+			Action:     AuditActionAutoPosterBudget,
+			TargetType: "auto_poster",
+			After:      map[string]string{"reason": err.Error()},
+		})
+	case err != nil:
+		logger.Infof(ctx, "[AUTO-POSTER] ⏸️  Still paused, skipping this run: %v", err)
+	case wasPaused:
+		logger.Infof(ctx, "[AUTO-POSTER] ▶️  AI budget available again, resuming")
+	}
+
+	return err == nil
+}
+
+// createAndPostBlog - Generate and publish a blog post as the persona, recorded as a generation job
+func (s *AutoPosterService) createAndPostBlog(persona *model.BotPersona, trigger string) (string, error) {
+	// ✅ Use timeout context to prevent hanging (covers retries and backoff)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	logger.Infof(ctx, "[AUTO-POSTER] 📝 Generating new AI blog post as %s...", persona.Name)
+
+	// Pick a weighted topic that is out of cooldown, and a category
+	selection, err := s.topics.PickTopic(ctx)
+	if err != nil {
+		logger.Warnf(ctx, "[AUTO-POSTER] ⚠️  No topic to write about: %v", err)
+		return "", err
+	}
+
+	return s.postSelection(ctx, persona, trigger, selection)
+}
+
+// PostAbout - Generate a post on a given topic as a persona ("" for the default persona) and
+// wait for it; the scheduler is not involved, but the AI budget still applies
+func (s *AutoPosterService) PostAbout(ctx context.Context, personaID, topic, category string) (string, error) {
+	var (
+		persona *model.BotPersona
+		err     error
+	)
+	if personaID == "" {
+		persona, err = s.personas.Default(ctx)
+	} else {
+		persona, err = s.personas.Get(ctx, personaID)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	selection, err := s.topics.SelectTopic(ctx, topic, category)
+	if err != nil {
+		return "", err
+	}
+	if err := s.usage.CheckBudget(ctx, UsageFeaturePostGeneration); err != nil {
+		return "", err
+	}
+
+	// Same limit as scheduled runs (covers retries and backoff)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAutoPosterRun,
+		TargetType: "auto_poster",
+		TargetID:   persona.ID.String(),
+		After:      map[string]string{"persona": persona.Name, "topic": selection.Topic},
+	})
 
-	log.Printf("[AUTO-POSTER] ✅ Successfully posted: '%s' (ID: %s)", 
-		post.Title, post.ID.String())
-	log.Printf("[AUTO-POSTER] 🏷️  Tags: %v | Category: %s", post.Tags, *post.Category)
+	logger.Infof(ctx, "[AUTO-POSTER] 📝 Generating AI blog post about %q as %s...", selection.Topic, persona.Name)
+	return s.postSelection(ctx, persona, GenerationTriggerManual, selection)
 }
 
-// PostNow - Manually trigger a post creation (for testing/admin)
-func (s *AutoPosterService) PostNow() {
-	log.Println("[AUTO-POSTER] 🚀 Manual post creation triggered")
-	go s.createAndPostBlog()
-}
\ No newline at end of file
+// postSelection - Generate, save and publish (or queue) the post for a chosen topic
+func (s *AutoPosterService) postSelection(ctx context.Context, persona *model.BotPersona, trigger string, selection *model.TopicSelection) (string, error) {
+	category := selection.Category
+
+	logger.Infof(ctx, "[AUTO-POSTER] 💡 Topic: %s | Category: %s", selection.Topic, category)
+
+	postID, err := s.jobs.Run(ctx, trigger, selection, persona, func(ctx context.Context, jobID string, generatedPost *model.AIGeneratedPost) (string, error) {
+		post := &model.Post{
+			Title:       generatedPost.Title,
+			Content:     generatedPost.Content,
+			AuthorID:    persona.UserID,
+			Tags:        generatedPost.Tags,
+			Category:    &category,
+			IsPublished: true,
+			AIGenerated: true,
+			ImageURL:    []string{},
+		}
+		if jobID != "" {
+			_ = post.GenerationJobID.Scan(jobID)
+		}
+
+		// In review mode the post stays unpublished until an admin approves it
+		if s.requireReview {
+			pending := ReviewStatusPending
+			post.IsPublished = false
+			post.ReviewStatus = &pending
+		}
+
+		if err := s.postRepo.Create(ctx, post); err != nil {
+			return "", err
+		}
+
+		if s.requireReview {
+			logger.Infof(ctx, "[AUTO-POSTER] 📥 Queued for review: '%s' (ID: %s)", post.Title, post.ID.String())
+		} else {
+			logger.Infof(ctx, "[AUTO-POSTER] ✅ Successfully posted: '%s' (ID: %s)", post.Title, post.ID.String())
+		}
+		logger.Infof(ctx, "[AUTO-POSTER] 🏷️  Tags: %v | Category: %s", post.Tags, category)
+		return post.ID.String(), nil
+	})
+	if err != nil {
+		logger.Errorf(ctx, "[AUTO-POSTER] ❌ Failed to create post: %v", err)
+		return "", err
+	}
+
+	// Start the topic's cooldown so it is not picked again while the post is recent
+	if selection.TopicID != "" {
+		if err := s.topics.MarkUsed(ctx, selection.TopicID, postID); err != nil {
+			logger.Warnf(ctx, "[AUTO-POSTER] ⚠️  Failed to mark topic used: %v", err)
+		}
+	}
+
+	return postID, nil
+}
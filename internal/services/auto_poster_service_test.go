@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/internal/model"
+)
+
+// memoryPersonaRepo - Only the lookups the auto-poster makes
+type memoryPersonaRepo struct {
+	PersonaRepo
+	personas []*model.BotPersona
+	err      error
+}
+
+func (r *memoryPersonaRepo) FindByID(_ context.Context, personaID string) (*model.BotPersona, error) {
+	for _, persona := range r.personas {
+		if persona.ID.String() == personaID {
+			return persona, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryPersonaRepo) List(_ context.Context, enabledOnly bool) ([]*model.BotPersona, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var found []*model.BotPersona
+	for _, persona := range r.personas {
+		if persona.Enabled || !enabledOnly {
+			found = append(found, persona)
+		}
+	}
+	return found, nil
+}
+
+func newTestAutoPoster(personas *memoryPersonaRepo, topics TopicRepo) *AutoPosterService {
+	return NewAutoPosterService(nil, NewTopicService(topics, nil), nil, NewPersonaService(personas, nil, nil), nil, nil, config.Defaults().AutoPoster)
+}
+
+func TestAutoPosterStartFailsWithoutPersonas(t *testing.T) {
+	personas := &memoryPersonaRepo{
+		personas: []*model.BotPersona{{ID: newUUID(), Name: "Writer", Enabled: true}},
+		err:      errors.New("connection refused"),
+	}
+	poster := newTestAutoPoster(personas, nil)
+
+	if err := poster.Start(); err == nil || !errors.Is(err, personas.err) {
+		t.Fatalf("Start = %v, want the persona error", err)
+	}
+	if poster.IsRunning() {
+		t.Error("auto-poster reports running after a failed start")
+	}
+
+	// Once the personas load, starting again works
+	personas.err = nil
+	if err := poster.Start(); err != nil {
+		t.Fatalf("second Start: %v", err)
+	}
+	if status := poster.Status(); !status.Running || len(status.Personas) != 1 {
+		t.Errorf("status after start = %+v, want running with one persona", status)
+	}
+	if err := poster.Stop(); err != nil {
+		t.Errorf("Stop: %v", err)
+	}
+}
+
+// blockingTopicRepo - PickTopic waits for release, then fails the run
+type blockingTopicRepo struct {
+	TopicRepo
+	picked  chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingTopicRepo) PickTopic(context.Context) (*model.AITopic, error) {
+	r.picked <- struct{}{}
+	<-r.release
+	return nil, errors.New("no topics today")
+}
+
+func TestAutoPosterPostNowGuardsDisabledPersonas(t *testing.T) {
+	ctx := context.Background()
+	disabled := &model.BotPersona{ID: newUUID(), Name: "Retired"}
+	topics := &blockingTopicRepo{picked: make(chan struct{}), release: make(chan struct{})}
+	poster := newTestAutoPoster(&memoryPersonaRepo{personas: []*model.BotPersona{disabled}}, topics)
+
+	if err := poster.PostNow(ctx, disabled.ID.String()); err != nil {
+		t.Fatalf("first PostNow: %v", err)
+	}
+	<-topics.picked
+
+	if err := poster.PostNow(ctx, disabled.ID.String()); !errors.Is(err, ErrAutoPosterBusy) {
+		t.Errorf("PostNow while the first run is generating = %v, want %v", err, ErrAutoPosterBusy)
+	}
+
+	close(topics.release)
+	// The runner is dropped when its run ends, freeing the persona for another one
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		poster.mu.Lock()
+		done := len(poster.manual) == 0
+		poster.mu.Unlock()
+		if done {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("manual run never finished")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := poster.PostNow(ctx, disabled.ID.String()); err != nil {
+		t.Errorf("PostNow after the first run finished: %v", err)
+	}
+	<-topics.picked
+}
@@ -4,10 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/britinogn/quillhub/pkg/utils"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -28,12 +29,18 @@ type CommentRepo interface {
 type CommentService struct{
 	commentRepo 	CommentRepo
 	postRepo 		PostRepo
+	audit 			*AuditService
+	moderation 		*ModerationService
+	replies 		*BotReplyService
 }
 
-func NewCommentService(commentRepo CommentRepo, postRepo PostRepo) *CommentService {
+func NewCommentService(commentRepo CommentRepo, postRepo PostRepo, audit *AuditService, moderation *ModerationService, replies *BotReplyService) *CommentService {
 	return &CommentService{
 		commentRepo: commentRepo,
 		postRepo:    postRepo,
+		audit:       audit,
+		moderation:  moderation,
+		replies:     replies,
 	}
 }
 
@@ -42,15 +49,15 @@ func (s *CommentService) CreateComment(ctx context.Context, req *model.CreateCom
 	//Validate text
 	text := strings.TrimSpace(req.Text)
 	if text == ""{
-		return nil , errors.New("comment text is required")
+		return nil , utils.Invalid("text", "comment text is required")
 	}
 
 	if len(text) < 1 {
-		return nil, errors.New("comment must be at least 1 character long")
+		return nil, utils.Invalid("text", "comment must be at least 1 character long")
 	}
 
 	if len(text) > 1000 {
-		return nil, errors.New("comment must not exceed 1000 characters")
+		return nil, utils.Invalid("text", "comment must not exceed 1000 characters")
 	}
 
 	// Verify post exists
@@ -78,6 +85,17 @@ func (s *CommentService) CreateComment(ctx context.Context, req *model.CreateCom
 		Text:     text,
 		PostID:   postUUID,
 		AuthorID: authorUUID,
+		ModerationStatus: ModerationStatusApproved,
+	}
+
+	// Hold suspicious comments for a moderator
+	if s.moderation != nil {
+		result := s.moderation.Check(ctx, text)
+		comment.ModerationStatus = result.Status
+		comment.ToxicityScore = &result.Toxicity
+		comment.SpamScore = &result.Spam
+		comment.ModerationReasons = result.Reasons
+		comment.ModerationSource = &result.Source
 	}
 
 	// Save to database
@@ -85,7 +103,15 @@ func (s *CommentService) CreateComment(ctx context.Context, req *model.CreateCom
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
 
-	log.Printf("[COMMENT-SERVICE] Comment created successfully: %s", comment.ID.String())
+	if comment.ModerationStatus == ModerationStatusPending {
+		logger.Infof(ctx, "[COMMENT-SERVICE] Comment %s held for moderation: %s", comment.ID.String(), strings.Join(comment.ModerationReasons, ", "))
+		return comment, nil
+	}
+
+	logger.Infof(ctx, "[COMMENT-SERVICE] Comment created successfully: %s", comment.ID.String())
+
+	// Bot personas answer comments on their own posts (BOT_REPLIES)
+	s.replies.Notify(comment)
 	return comment, nil
 
 }
@@ -107,7 +133,7 @@ func (s *CommentService) GetCommentsByPostID(ctx context.Context, postID string)
 		return nil, fmt.Errorf("failed to get comments: %w", err)
 	}
 
-	log.Printf("[COMMENT-SERVICE] Found %d comments for post: %s", len(comments), postID)
+	logger.Infof(ctx, "[COMMENT-SERVICE] Found %d comments for post: %s", len(comments), postID)
 	return comments, nil
 }
 
@@ -132,14 +158,21 @@ func (s *CommentService) DeleteComment(ctx context.Context, commentID string, us
 		return fmt.Errorf("failed to delete comment: %w", err)
 	}
 
-	log.Printf("[COMMENT-SERVICE] Comment deleted successfully: %s", commentID)
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionCommentDelete,
+		TargetType: "comment",
+		TargetID:   commentID,
+		Before:     existing,
+	})
+
+	logger.Infof(ctx, "[COMMENT-SERVICE] Comment deleted successfully: %s", commentID)
 	return nil
 }
 
 func (s *CommentService) GetAllComments(ctx context.Context, postID string) ([]*model.Comment, error) {
 
 	if postID == "" {
-		return nil, errors.New("post ID is required")
+		return nil, utils.Invalid("post_id", "post ID is required")
 	}
 
 	comments, err := s.commentRepo.GetAllComments(ctx, postID)
@@ -4,9 +4,9 @@ package services
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
 )
 
 type DashboardRepo interface {
@@ -38,16 +38,17 @@ type DashboardRepo interface {
 }
 
 type DashboardService struct {
-	repo DashboardRepo
+	repo  DashboardRepo
+	usage *AIUsageService
 }
 
-func NewDashboardService(repo DashboardRepo) *DashboardService {
-	return &DashboardService{repo: repo}
+func NewDashboardService(repo DashboardRepo, usage *AIUsageService) *DashboardService {
+	return &DashboardService{repo: repo, usage: usage}
 }
 
 // GetAdminDashboard - Get complete admin dashboard data
 func (s *DashboardService) GetAdminDashboard(ctx context.Context) (*model.AdminDashboard, error) {
-	log.Printf("[DASHBOARD-SERVICE] Fetching admin dashboard")
+	logger.Infof(ctx, "[DASHBOARD-SERVICE] Fetching admin dashboard")
 
 	dashboard := &model.AdminDashboard{}
 
@@ -78,13 +79,22 @@ func (s *DashboardService) GetAdminDashboard(ctx context.Context) (*model.AdminD
 	dashboard.RecentComments = recentComments
 	dashboard.PostsByCategory = postsByCategory
 
-	log.Printf("[DASHBOARD-SERVICE] Admin dashboard fetched successfully")
+	// AI spend this month; the rest of the dashboard still loads if it fails
+	if s.usage != nil {
+		aiUsage, err := s.usage.Summary(ctx)
+		if err != nil {
+			logger.Warnf(ctx, "[DASHBOARD-SERVICE] ⚠️  AI usage unavailable: %v", err)
+		}
+		dashboard.AIUsage = aiUsage
+	}
+
+	logger.Infof(ctx, "[DASHBOARD-SERVICE] Admin dashboard fetched successfully")
 	return dashboard, nil
 }
 
 // GetUserDashboard - Get complete user dashboard data
 func (s *DashboardService) GetUserDashboard(ctx context.Context, userID string) (*model.UserDashboard, error) {
-	log.Printf("[DASHBOARD-SERVICE] Fetching user dashboard for: %s", userID)
+	logger.Infof(ctx, "[DASHBOARD-SERVICE] Fetching user dashboard for: %s", userID)
 
 	if userID == "" {
 		return nil, fmt.Errorf("user ID is required")
@@ -115,6 +125,6 @@ func (s *DashboardService) GetUserDashboard(ctx context.Context, userID string)
 	dashboard.RecentPosts = recentPosts
 	dashboard.RecentActivity = recentActivity
 
-	log.Printf("[DASHBOARD-SERVICE] User dashboard fetched successfully for: %s", userID)
+	logger.Infof(ctx, "[DASHBOARD-SERVICE] User dashboard fetched successfully for: %s", userID)
 	return dashboard, nil
 }
\ No newline at end of file
@@ -0,0 +1,76 @@
+// internal/services/embedding_gemini.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	generativelanguage "cloud.google.com/go/ai/generativelanguage/apiv1beta"
+	"cloud.google.com/go/ai/generativelanguage/apiv1beta/generativelanguagepb"
+	"google.golang.org/api/option"
+)
+
+const defaultGeminiEmbeddingModel = "models/text-embedding-004"
+
+// GeminiEmbedder - Gemini batchEmbedContents
+type GeminiEmbedder struct {
+	client *generativelanguage.GenerativeClient
+	model  string
+}
+
+func NewGeminiEmbedder(ctx context.Context, apiKey, model string) (*GeminiEmbedder, error) {
+	if apiKey == "" {
+		return nil, errors.New("GEMINI_API_KEY not set")
+	}
+
+	client, err := generativelanguage.NewGenerativeClient(ctx, option.WithAPIKey(apiKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
+	}
+
+	if model == "" {
+		model = defaultGeminiEmbeddingModel
+	}
+	return &GeminiEmbedder{client: client, model: geminiModelName(model)}, nil
+}
+
+func (e *GeminiEmbedder) Name() string  { return LLMProviderGemini }
+func (e *GeminiEmbedder) Model() string { return e.model }
+
+func (e *GeminiEmbedder) Close() error {
+	return e.client.Close()
+}
+
+// Embed - One batch call for all texts
+func (e *GeminiEmbedder) Embed(ctx context.Context, texts []string, task EmbeddingTask) ([][]float32, error) {
+	taskType := generativelanguagepb.TaskType_RETRIEVAL_DOCUMENT
+	if task == EmbeddingTaskQuery {
+		taskType = generativelanguagepb.TaskType_RETRIEVAL_QUERY
+	}
+
+	req := &generativelanguagepb.BatchEmbedContentsRequest{Model: e.model}
+	for _, text := range texts {
+		req.Requests = append(req.Requests, &generativelanguagepb.EmbedContentRequest{
+			Model: e.model,
+			Content: &generativelanguagepb.Content{
+				Parts: []*generativelanguagepb.Part{textPart(text)},
+			},
+			TaskType: &taskType,
+		})
+	}
+
+	resp, err := e.client.BatchEmbedContents(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed content: %w", err)
+	}
+	if len(resp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(resp.Embeddings))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for i, embedding := range resp.Embeddings {
+		vectors[i] = normalizeVector(embedding.GetValues())
+	}
+	return vectors, nil
+}
@@ -0,0 +1,70 @@
+// internal/services/embedding_local.go
+package services
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+const localEmbeddingDims = 256
+
+// LocalEmbedder - Deterministic feature-hashing embedder for development and tests.
+// Texts sharing words land close together; no network or API key needed.
+type LocalEmbedder struct{}
+
+func NewLocalEmbedder() *LocalEmbedder {
+	return &LocalEmbedder{}
+}
+
+func (e *LocalEmbedder) Name() string  { return EmbeddingProviderLocal }
+func (e *LocalEmbedder) Model() string { return "local-hash-256" }
+func (e *LocalEmbedder) Close() error  { return nil }
+
+// Embed - Hash words and word pairs into a fixed-size signed vector
+func (e *LocalEmbedder) Embed(ctx context.Context, texts []string, task EmbeddingTask) ([][]float32, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = localEmbedding(text)
+	}
+	return vectors, nil
+}
+
+func localEmbedding(text string) []float32 {
+	vector := make([]float32, localEmbeddingDims)
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	add := func(feature string, weight float32) {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		index := sum % localEmbeddingDims
+		if sum&(1<<63) != 0 {
+			weight = -weight
+		}
+		vector[index] += weight
+	}
+
+	previous := ""
+	for _, word := range words {
+		if len(word) < 3 {
+			previous = ""
+			continue
+		}
+		add(word, 1)
+		if previous != "" {
+			add(previous+" "+word, 0.5)
+		}
+		previous = word
+	}
+
+	return normalizeVector(vector)
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestLocalEmbedderIsDeterministic(t *testing.T) {
+	ctx := context.Background()
+	texts := []string{
+		"Baking sourdough bread at home",
+		"A beginner's guide to chess openings",
+		"",
+	}
+
+	first, err := NewLocalEmbedder().Embed(ctx, texts, EmbeddingTaskDocument)
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	second, err := NewLocalEmbedder().Embed(ctx, texts, EmbeddingTaskQuery)
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		t.Error("the same texts were embedded differently")
+	}
+	for i, vector := range first {
+		if len(vector) != localEmbeddingDims {
+			t.Errorf("text %d: %d dims, want %d", i, len(vector), localEmbeddingDims)
+		}
+	}
+}
+
+func TestLocalEmbedderNormalizes(t *testing.T) {
+	tests := []struct {
+		text string
+		norm float64
+	}{
+		{"Baking sourdough bread at home", 1},
+		{"word word word word word word", 1},
+		{"Go, go, Go to it!", 0}, // every word is too short to count
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		vector := localEmbedding(tt.text)
+		var sum float64
+		for _, v := range vector {
+			sum += float64(v) * float64(v)
+		}
+		if norm := math.Sqrt(sum); math.Abs(norm-tt.norm) > 1e-5 {
+			t.Errorf("%q: L2 norm = %f, want %v", tt.text, norm, tt.norm)
+		}
+	}
+}
+
+func TestLocalEmbedderRanksSharedWordsCloser(t *testing.T) {
+	dot := func(a, b []float32) float64 {
+		var sum float64
+		for i := range a {
+			sum += float64(a[i]) * float64(b[i])
+		}
+		return sum
+	}
+
+	query := localEmbedding("sourdough bread recipe")
+	related := localEmbedding("My favourite sourdough bread recipe for weekends")
+	unrelated := localEmbedding("Configuring load balancers for websocket traffic")
+
+	if dot(query, related) <= dot(query, unrelated) {
+		t.Errorf("similarity to related text %f is not above unrelated text %f", dot(query, related), dot(query, unrelated))
+	}
+}
@@ -0,0 +1,113 @@
+// internal/services/embedding_openai.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const defaultOpenAIEmbeddingModel = "text-embedding-3-small"
+
+// OpenAIEmbedder - Any OpenAI-compatible /embeddings API
+type OpenAIEmbedder struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+// NewOpenAIEmbedder - apiKey may be empty for local servers
+func NewOpenAIEmbedder(baseURL, apiKey, model string) (*OpenAIEmbedder, error) {
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	if model == "" {
+		model = defaultOpenAIEmbeddingModel
+	}
+
+	return &OpenAIEmbedder{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+func (e *OpenAIEmbedder) Name() string  { return LLMProviderOpenAI }
+func (e *OpenAIEmbedder) Model() string { return e.model }
+func (e *OpenAIEmbedder) Close() error  { return nil }
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Embed - One request for all texts; the API does not distinguish queries from documents
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string, task EmbeddingTask) ([][]float32, error) {
+	body, err := json.Marshal(map[string]any{
+		"model": e.model,
+		"input": texts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode embedding request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build embedding request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call embeddings: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 32<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedding response: %w", err)
+	}
+
+	var embResp openAIEmbeddingResponse
+	if err := json.Unmarshal(respBody, &embResp); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("embeddings failed: %w", &LLMStatusError{StatusCode: resp.StatusCode})
+		}
+		return nil, fmt.Errorf("failed to decode embedding response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		statusErr := &LLMStatusError{StatusCode: resp.StatusCode}
+		if embResp.Error != nil {
+			statusErr.Message = embResp.Error.Message
+		}
+		return nil, fmt.Errorf("embeddings failed: %w", statusErr)
+	}
+
+	if len(embResp.Data) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(embResp.Data))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, item := range embResp.Data {
+		if item.Index < 0 || item.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding index %d out of range", item.Index)
+		}
+		vectors[item.Index] = normalizeVector(item.Embedding)
+	}
+	return vectors, nil
+}
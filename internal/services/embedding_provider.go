@@ -0,0 +1,113 @@
+// internal/services/embedding_provider.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/pkg/logger"
+)
+
+// Embedding providers ("local" is a deterministic offline embedder)
+const (
+	EmbeddingProviderLocal = "local"
+	EmbeddingProviderNone  = "none"
+)
+
+// EmbeddingTask - Some providers embed search queries differently from documents
+type EmbeddingTask int
+
+const (
+	EmbeddingTaskDocument EmbeddingTask = iota
+	EmbeddingTaskQuery
+)
+
+// EmbeddingProvider - Turns text into vectors for similarity search
+type EmbeddingProvider interface {
+	Name() string
+	Model() string
+	Embed(ctx context.Context, texts []string, task EmbeddingTask) ([][]float32, error)
+	Close() error
+}
+
+// EmbeddingConfig - Which embedding backend to use
+type EmbeddingConfig struct {
+	Provider string
+	Model    string
+	APIKey   string
+	BaseURL  string
+}
+
+// LoadEmbeddingConfig - The embedding provider (defaults to the LLM provider, with fake mapped to local)
+// and model; credentials come from the LLM settings
+func LoadEmbeddingConfig(llm config.LLMConfig, app config.EmbeddingConfig) EmbeddingConfig {
+	cfg := EmbeddingConfig{
+		Provider: app.Provider,
+		Model:    strings.TrimSpace(app.Model),
+	}
+	if cfg.Provider == "" {
+		cfg.Provider = LoadLLMConfig(llm).Provider
+	}
+	if cfg.Provider == LLMProviderFake {
+		cfg.Provider = EmbeddingProviderLocal
+	}
+
+	switch cfg.Provider {
+	case LLMProviderGemini:
+		cfg.APIKey = llm.GeminiAPIKey
+	case LLMProviderOpenAI:
+		cfg.APIKey = llm.OpenAIAPIKey
+		cfg.BaseURL = llm.OpenAIBaseURL
+	}
+
+	return cfg
+}
+
+// NewEmbeddingProvider - Build the configured provider; nil without error when disabled
+func NewEmbeddingProvider(ctx context.Context, cfg EmbeddingConfig) (EmbeddingProvider, error) {
+	var (
+		provider EmbeddingProvider
+		err      error
+	)
+
+	switch cfg.Provider {
+	case EmbeddingProviderNone:
+		logger.Infof(ctx, "[EMBEDDING] Embeddings disabled (EMBEDDING_PROVIDER=none)")
+		return nil, nil
+	case LLMProviderGemini:
+		provider, err = NewGeminiEmbedder(ctx, cfg.APIKey, cfg.Model)
+	case LLMProviderOpenAI:
+		provider, err = NewOpenAIEmbedder(cfg.BaseURL, cfg.APIKey, cfg.Model)
+	case EmbeddingProviderLocal:
+		provider = NewLocalEmbedder()
+	default:
+		return nil, fmt.Errorf("unknown EMBEDDING_PROVIDER %q (expected %s, %s, %s or %s)", cfg.Provider, LLMProviderGemini, LLMProviderOpenAI, EmbeddingProviderLocal, EmbeddingProviderNone)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof(ctx, "[EMBEDDING] Provider: %s | Model: %s", provider.Name(), provider.Model())
+	return provider, nil
+}
+
+// normalizeVector - Scale to unit length so cosine similarity is a dot product
+func normalizeVector(vector []float32) []float32 {
+	var sum float64
+	for _, v := range vector {
+		sum += float64(v) * float64(v)
+	}
+	if sum == 0 {
+		return vector
+	}
+
+	norm := float32(math.Sqrt(sum))
+	out := make([]float32, len(vector))
+	for i, v := range vector {
+		out[i] = v / norm
+	}
+	return out
+}
@@ -0,0 +1,343 @@
+// internal/services/embedding_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Search modes
+const (
+	SearchModeKeyword  = "keyword"
+	SearchModeSemantic = "semantic"
+	relatedModeTags    = "tags"
+)
+
+const (
+	embeddingBatchSize    = 32
+	embeddingMaxChars     = 8000 // characters of a post sent to the embedder
+	embeddingInterval     = 5 * time.Minute
+	embeddingQueryTimeout = 15 * time.Second
+	maxSearchQuery        = 500
+)
+
+var (
+	ErrInvalidSearch             = errors.New("invalid search request")
+	ErrSemanticSearchUnavailable = errors.New("semantic search is not configured")
+)
+
+type SearchRepo interface {
+	DetectPgvector(ctx context.Context) (bool, error)
+	ListStale(ctx context.Context, embeddingModel string, limit int) ([]*model.EmbeddingSource, error)
+	CountStale(ctx context.Context, embeddingModel string) (int64, error)
+	UpsertEmbedding(ctx context.Context, embedding *model.PostEmbedding) error
+	FindEmbedding(ctx context.Context, postID string) (*model.PostEmbedding, error)
+	DeleteAllEmbeddings(ctx context.Context) (int64, error)
+	NearestPosts(ctx context.Context, vector []float32, embeddingModel, excludeID string, limit, offset int) ([]model.PostSearchHit, error)
+	KeywordSearch(ctx context.Context, text string, limit, offset int) ([]model.PostSearchHit, error)
+	RelatedByTags(ctx context.Context, postID string, tags []string, category *string, limit int) ([]model.PostSearchHit, error)
+}
+
+// EmbeddingService - Keeps post embeddings current and answers related/semantic queries
+type EmbeddingService struct {
+	repo     SearchRepo
+	posts    PostRepo
+	provider EmbeddingProvider // nil: related posts use tags, semantic search is off
+
+	mu        sync.Mutex
+	isRunning bool
+	wake      chan struct{}
+	stopChan  chan struct{}
+}
+
+func NewEmbeddingService(repo SearchRepo, posts PostRepo, provider EmbeddingProvider) *EmbeddingService {
+	return &EmbeddingService{
+		repo:     repo,
+		posts:    posts,
+		provider: provider,
+		wake:     make(chan struct{}, 1),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start - Background backfill; runs on start, when notified and every few minutes
+func (s *EmbeddingService) Start() {
+	if s.provider == nil {
+		logger.Infof(context.Background(), "[EMBEDDING] No embedding provider, related posts fall back to tags")
+		return
+	}
+
+	s.mu.Lock()
+	if s.isRunning {
+		s.mu.Unlock()
+		return
+	}
+	s.isRunning = true
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if pgvector, err := s.repo.DetectPgvector(ctx); err != nil {
+		logger.Warnf(ctx, "[EMBEDDING] ⚠️  %v", err)
+	} else if pgvector {
+		logger.Infof(ctx, "[EMBEDDING] Using pgvector for similarity")
+	} else {
+		logger.Infof(ctx, "[EMBEDDING] pgvector not installed, using float array similarity")
+	}
+	cancel()
+
+	ticker := time.NewTicker(embeddingInterval)
+	go func() {
+		s.backfillLogged()
+		for {
+			select {
+			case <-s.wake:
+				s.backfillLogged()
+			case <-ticker.C:
+				s.backfillLogged()
+			case <-s.stopChan:
+				ticker.Stop()
+				s.mu.Lock()
+				s.isRunning = false
+				s.mu.Unlock()
+				logger.Infof(ctx, "[EMBEDDING] ⏹️  Background worker stopped")
+				return
+			}
+		}
+	}()
+
+	logger.Infof(ctx, "[EMBEDDING] ✅ Background worker started (model: %s)", s.provider.Model())
+}
+
+// Stop - Stop the background worker
+func (s *EmbeddingService) Stop() {
+	s.mu.Lock()
+	running := s.isRunning
+	s.mu.Unlock()
+
+	if running {
+		s.stopChan <- struct{}{}
+	}
+}
+
+// Notify - A post was created or edited; embed it soon
+func (s *EmbeddingService) Notify() {
+	if s == nil || s.provider == nil {
+		return
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *EmbeddingService) backfillLogged() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	count, err := s.Backfill(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "[EMBEDDING] ❌ Backfill stopped after %d post(s): %v", count, err)
+		return
+	}
+	if count > 0 {
+		logger.Infof(ctx, "[EMBEDDING] Embedded %d post(s)", count)
+	}
+}
+
+// Backfill - Embed every post that is missing or out of date; returns how many were embedded
+func (s *EmbeddingService) Backfill(ctx context.Context) (int, error) {
+	if s.provider == nil {
+		return 0, ErrSemanticSearchUnavailable
+	}
+
+	embeddingModel := s.provider.Model()
+	total := 0
+	for {
+		sources, err := s.repo.ListStale(ctx, embeddingModel, embeddingBatchSize)
+		if err != nil {
+			return total, err
+		}
+		if len(sources) == 0 {
+			return total, nil
+		}
+
+		texts := make([]string, len(sources))
+		for i, source := range sources {
+			texts[i] = embeddingText(source)
+		}
+
+		vectors, err := s.provider.Embed(ctx, texts, EmbeddingTaskDocument)
+		if err != nil {
+			return total, fmt.Errorf("failed to embed posts: %w", err)
+		}
+
+		saved := 0
+		for i, source := range sources {
+			err := s.repo.UpsertEmbedding(ctx, &model.PostEmbedding{
+				PostID:      source.PostID,
+				Model:       embeddingModel,
+				Dims:        len(vectors[i]),
+				Embedding:   vectors[i],
+				ContentHash: source.ContentHash,
+			})
+			if err != nil {
+				logger.Warnf(ctx, "[EMBEDDING] ⚠️  Post %s: %v", source.PostID.String(), err)
+				continue
+			}
+			saved++
+		}
+		total += saved
+
+		// Every save in the batch failed; stop instead of retrying the same posts forever
+		if saved == 0 {
+			return total, errors.New("no embeddings could be saved")
+		}
+	}
+}
+
+// Reindex - Drop all embeddings and rebuild them now
+func (s *EmbeddingService) Reindex(ctx context.Context) (int, error) {
+	if s.provider == nil {
+		return 0, ErrSemanticSearchUnavailable
+	}
+	if _, err := s.repo.DetectPgvector(ctx); err != nil {
+		return 0, err
+	}
+	if _, err := s.repo.DeleteAllEmbeddings(ctx); err != nil {
+		return 0, err
+	}
+	return s.Backfill(ctx)
+}
+
+// Related - Posts similar to postID; by embedding when available, otherwise by shared tags
+func (s *EmbeddingService) Related(ctx context.Context, postID string, limit int) ([]model.PostSearchHit, string, error) {
+	var id pgtype.UUID
+	if err := id.Scan(postID); err != nil {
+		return nil, "", ErrPostNotFound
+	}
+
+	post, err := s.posts.FindByID(ctx, postID)
+	if err != nil {
+		return nil, "", err
+	}
+	if post == nil || heldForReview(post) {
+		return nil, "", ErrPostNotFound
+	}
+
+	if limit < 1 || limit > 20 {
+		limit = 5
+	}
+
+	if s.provider != nil {
+		embedding, err := s.repo.FindEmbedding(ctx, postID)
+		if err != nil {
+			return nil, "", err
+		}
+		if embedding != nil && embedding.Model == s.provider.Model() {
+			hits, err := s.repo.NearestPosts(ctx, embedding.Embedding, embedding.Model, postID, limit, 0)
+			if err != nil {
+				return nil, "", err
+			}
+			if len(hits) > 0 {
+				return hits, SearchModeSemantic, nil
+			}
+		}
+	}
+
+	tags := make([]string, 0, len(post.Tags))
+	for _, tag := range post.Tags {
+		tags = append(tags, strings.ToLower(tag))
+	}
+	hits, err := s.repo.RelatedByTags(ctx, postID, tags, post.Category, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return hits, relatedModeTags, nil
+}
+
+// Search - Keyword (default) or semantic search over public posts
+func (s *EmbeddingService) Search(ctx context.Context, query, mode string, page, limit int) (*model.PostSearchResponse, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: q is required", ErrInvalidSearch)
+	}
+	if utf8.RuneCountInString(query) > maxSearchQuery {
+		return nil, fmt.Errorf("%w: q must be at most %d characters", ErrInvalidSearch, maxSearchQuery)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	response := &model.PostSearchResponse{Query: query, Page: page, Limit: limit}
+
+	switch strings.ToLower(mode) {
+	case "", SearchModeKeyword:
+		response.Mode = SearchModeKeyword
+		hits, err := s.repo.KeywordSearch(ctx, query, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		response.Results = hits
+
+	case SearchModeSemantic:
+		if s.provider == nil {
+			return nil, ErrSemanticSearchUnavailable
+		}
+		response.Mode = SearchModeSemantic
+
+		embedCtx, cancel := context.WithTimeout(ctx, embeddingQueryTimeout)
+		vectors, err := s.provider.Embed(embedCtx, []string{query}, EmbeddingTaskQuery)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed query: %w", err)
+		}
+
+		hits, err := s.repo.NearestPosts(ctx, vectors[0], s.provider.Model(), "", limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		response.Results = hits
+
+	default:
+		return nil, fmt.Errorf("%w: mode must be %s or %s", ErrInvalidSearch, SearchModeKeyword, SearchModeSemantic)
+	}
+
+	return response, nil
+}
+
+// embeddingText - What gets embedded for a post: title, category, tags, then the start of the content
+func embeddingText(source *model.EmbeddingSource) string {
+	var b strings.Builder
+	b.WriteString(source.Title)
+	b.WriteString("\n")
+	if source.Category != nil && *source.Category != "" {
+		b.WriteString(*source.Category)
+		b.WriteString("\n")
+	}
+	if len(source.Tags) > 0 {
+		b.WriteString(strings.Join(source.Tags, ", "))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	content := source.Content
+	if utf8.RuneCountInString(content) > embeddingMaxChars {
+		content = string([]rune(content)[:embeddingMaxChars])
+	}
+	b.WriteString(content)
+	return b.String()
+}
@@ -0,0 +1,479 @@
+// internal/services/generation_job_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Generation job triggers and statuses
+const (
+	GenerationTriggerSchedule   = "schedule"
+	GenerationTriggerManual     = "manual"
+	GenerationTriggerRegenerate = "regenerate"
+
+	GenerationStatusRunning   = "running"
+	GenerationStatusSucceeded = "succeeded"
+	GenerationStatusFailed    = "failed"
+
+	generationErrorAPI        = "api"
+	generationErrorParse      = "parse"
+	generationErrorValidation = "validation"
+	generationErrorBudget     = "budget"
+
+	generationPurposeGenerate = "generate"
+	generationPurposeRepair   = "repair"
+)
+
+const (
+	defaultGenerationMaxAttempts = 3
+	generationBaseBackoff        = 2 * time.Second
+	generationMaxBackoff         = 30 * time.Second
+	generationAttemptTimeout     = 90 * time.Second
+)
+
+var (
+	ErrGenerationJobNotFound = errors.New("generation job not found")
+	ErrInvalidJobFilter      = errors.New("invalid generation job filter")
+)
+
+type GenerationJobRepo interface {
+	Create(ctx context.Context, job *model.GenerationJob) error
+	AddAttempt(ctx context.Context, attempt *model.GenerationAttempt) error
+	Finish(ctx context.Context, job *model.GenerationJob) error
+	FailStale(ctx context.Context) (int64, error)
+	FindByID(ctx context.Context, jobID string) (*model.GenerationJob, error)
+	List(ctx context.Context, filter model.GenerationJobFilter) ([]*model.GenerationJob, error)
+	Count(ctx context.Context, filter model.GenerationJobFilter) (int64, error)
+	ListAttempts(ctx context.Context, jobID string) ([]*model.GenerationAttempt, error)
+}
+
+// PublishFunc - Save a generated post and return its ID; jobID is empty if the job could not be recorded
+type PublishFunc func(ctx context.Context, jobID string, post *model.AIGeneratedPost) (string, error)
+
+type GenerationJobService struct {
+	repo        GenerationJobRepo
+	ai          *AIService
+	maxAttempts int
+	minWords    int
+	maxWords    int
+}
+
+type PaginatedGenerationJobsResponse struct {
+	TotalPages     int                           `json:"totalPages"`
+	TotalDocuments int64                         `json:"totalDocuments"`
+	Page           int                           `json:"page"`
+	Limit          int                           `json:"limit"`
+	Jobs           []model.GenerationJobResponse `json:"jobs"`
+}
+
+// NewGenerationJobService - cfg caps retries per job and bounds the length of generated posts
+func NewGenerationJobService(repo GenerationJobRepo, ai *AIService, cfg config.GenerationConfig) *GenerationJobService {
+	s := &GenerationJobService{
+		repo:        repo,
+		ai:          ai,
+		maxAttempts: cfg.MaxAttempts,
+		minWords:    cfg.PostMinWords,
+		maxWords:    cfg.PostMaxWords,
+	}
+	if s.maxAttempts < 1 {
+		s.maxAttempts = defaultGenerationMaxAttempts
+	}
+	if s.minWords < 1 {
+		s.minWords = DefaultPostMinWords
+	}
+	if s.maxWords < s.minWords {
+		s.maxWords = max(DefaultPostMaxWords, s.minWords)
+	}
+	return s
+}
+
+// PostSpec - What a generated post for the selection must look like; persona settings
+// override the configured length (persona may be nil)
+func (s *GenerationJobService) PostSpec(selection *model.TopicSelection, persona *model.BotPersona) *model.AIPostRequest {
+	spec := &model.AIPostRequest{
+		Topic:     selection.Topic,
+		Category:  selection.Category,
+		MinLength: s.minWords,
+		MaxLength: s.maxWords,
+	}
+	if persona != nil {
+		if persona.MinWords != nil {
+			spec.MinLength = *persona.MinWords
+		}
+		if persona.MaxWords != nil {
+			spec.MaxLength = *persona.MaxWords
+		}
+		if persona.Tone != nil {
+			spec.Tone = *persona.Tone
+		}
+	}
+	return NormalizePostRequest(spec)
+}
+
+// personaRequest - Model settings for every call of a job
+func personaRequest(persona *model.BotPersona) LLMRequest {
+	req := LLMRequest{JSON: true, Feature: UsageFeaturePostGeneration}
+	if persona != nil {
+		if persona.Model != nil {
+			req.Model = *persona.Model
+		}
+		req.Temperature = persona.Temperature
+		if persona.MaxTokens != nil {
+			req.MaxTokens = *persona.MaxTokens
+		}
+		if persona.UserID.Valid {
+			req.UserID = persona.UserID.String()
+		}
+	}
+	return req
+}
+
+// RecoverStale - Close jobs a previous process left running
+func (s *GenerationJobService) RecoverStale(ctx context.Context) {
+	count, err := s.repo.FailStale(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "[GENERATION-JOB] ❌ Failed to close stale jobs: %v", err)
+		return
+	}
+	if count > 0 {
+		logger.Infof(ctx, "[GENERATION-JOB] Marked %d interrupted job(s) as failed", count)
+	}
+}
+
+// Run - Generate a post for the selection as the persona (nil for the built-in prompt and settings)
+// with retries, publish it and record every attempt
+func (s *GenerationJobService) Run(ctx context.Context, trigger string, selection *model.TopicSelection, persona *model.BotPersona, publish PublishFunc) (string, error) {
+	spec := s.PostSpec(selection, persona)
+	prompt, err := PersonaPrompt(persona, spec)
+	if err != nil {
+		return "", err
+	}
+	settings := personaRequest(persona)
+
+	job := &model.GenerationJob{
+		Trigger:  trigger,
+		Topic:    selection.Topic,
+		Category: optionalString(selection.Category),
+		Prompt:   prompt,
+	}
+	if selection.TopicID != "" {
+		_ = job.TopicID.Scan(selection.TopicID)
+	}
+	if persona != nil {
+		job.PersonaID = persona.ID
+	}
+	if provider := s.ai.Provider(); provider != nil {
+		job.Provider = optionalString(provider.Name())
+		job.Model = optionalString(provider.Model())
+	}
+	if settings.Model != "" {
+		job.Model = optionalString(settings.Model)
+	}
+
+	if err := s.repo.Create(ctx, job); err != nil {
+		// Still generate; history is for debugging and must not stop posting
+		logger.Warnf(ctx, "[GENERATION-JOB] ⚠️  Failed to open job: %v", err)
+		job = nil
+	}
+
+	started := time.Now()
+	generated, raw, err := s.generate(ctx, job, spec, settings, prompt)
+
+	var postID string
+	if err == nil {
+		jobID := ""
+		if job != nil {
+			jobID = job.ID.String()
+		}
+		postID, err = publish(ctx, jobID, generated)
+		if err != nil {
+			err = fmt.Errorf("failed to save post: %w", err)
+		}
+	}
+
+	if job != nil {
+		job.RawResponse = raw
+		latency := time.Since(started).Milliseconds()
+		job.LatencyMs = &latency
+		if err != nil {
+			job.Status = GenerationStatusFailed
+			job.Error = optionalString(err.Error())
+		} else {
+			job.Status = GenerationStatusSucceeded
+			_ = job.PostID.Scan(postID)
+		}
+
+		// Record the outcome even if the generation context ran out
+		finishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		if finishErr := s.repo.Finish(finishCtx, job); finishErr != nil {
+			logger.Warnf(ctx, "[GENERATION-JOB] ⚠️  Failed to close job %s: %v", job.ID.String(), finishErr)
+		}
+		cancel()
+	}
+
+	return postID, err
+}
+
+// Selection - The topic and category a job wrote about, for regenerating its post
+func (s *GenerationJobService) Selection(ctx context.Context, jobID string) (*model.TopicSelection, error) {
+	job, err := s.repo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrGenerationJobNotFound
+	}
+
+	selection := &model.TopicSelection{Topic: job.Topic}
+	if job.TopicID.Valid {
+		selection.TopicID = job.TopicID.String()
+	}
+	if job.Category != nil {
+		selection.Category = *job.Category
+	}
+	return selection, nil
+}
+
+// generate - Call the model until it returns a valid post or retries run out.
+// An answer that is malformed or breaks the schema gets one repair round-trip before the next retry.
+func (s *GenerationJobService) generate(ctx context.Context, job *model.GenerationJob, spec *model.AIPostRequest, settings LLMRequest, prompt string) (*model.AIGeneratedPost, *string, error) {
+	var (
+		lastErr error
+		lastRaw *string
+		calls   int // every model call is numbered in the attempt log, repairs included
+	)
+
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		if attempt > 1 {
+			delay := generationBackoff(attempt - 1)
+			logger.Infof(ctx, "[GENERATION-JOB] 🔁 Retrying in %s (attempt %d/%d)", delay, attempt, s.maxAttempts)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, lastRaw, fmt.Errorf("gave up after %d attempt(s): %w", attempt-1, lastErr)
+			}
+		}
+
+		calls++
+		generated, resp, err := s.call(ctx, job, calls, generationPurposeGenerate, settings, prompt, spec)
+		if resp != nil {
+			lastRaw = optionalString(resp.Text)
+		}
+
+		if err != nil && errors.Is(err, ErrInvalidGeneratedPost) && ctx.Err() == nil {
+			logger.Infof(ctx, "[GENERATION-JOB] 🩹 Attempt %d/%d returned an invalid post, asking for a repair: %v", attempt, s.maxAttempts, err)
+			calls++
+			generated, resp, err = s.call(ctx, job, calls, generationPurposeRepair, settings, BlogPostRepairPrompt(spec, resp.Text, err), spec)
+			if resp != nil {
+				lastRaw = optionalString(resp.Text)
+			}
+		}
+
+		if err == nil {
+			return generated, lastRaw, nil
+		}
+		lastErr = err
+
+		logger.Errorf(ctx, "[GENERATION-JOB] ❌ Attempt %d/%d failed: %v", attempt, s.maxAttempts, err)
+		retryable := errors.Is(err, ErrInvalidGeneratedPost) || IsTransientLLMError(err)
+		if !retryable || ctx.Err() != nil {
+			return nil, lastRaw, err
+		}
+	}
+
+	return nil, lastRaw, fmt.Errorf("gave up after %d attempt(s): %w", s.maxAttempts, lastErr)
+}
+
+// call - One model call with the job's settings, parsed against the spec and recorded in the attempt log.
+// resp is nil when the provider itself failed.
+func (s *GenerationJobService) call(ctx context.Context, job *model.GenerationJob, number int, purpose string, settings LLMRequest, prompt string, spec *model.AIPostRequest) (*model.AIGeneratedPost, *LLMResponse, error) {
+	record := &model.GenerationAttempt{Attempt: number, Purpose: purpose}
+	if job != nil {
+		record.JobID = job.ID
+		record.Model = job.Model
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, generationAttemptTimeout)
+	started := time.Now()
+	req := settings
+	req.Prompt = prompt
+	resp, err := s.ai.Complete(attemptCtx, req)
+	cancel()
+	record.LatencyMs = time.Since(started).Milliseconds()
+
+	var generated *model.AIGeneratedPost
+	if errors.Is(err, ErrAIBudgetExceeded) {
+		record.ErrorKind = optionalString(generationErrorBudget)
+		resp = nil
+	} else if err != nil {
+		record.ErrorKind = optionalString(generationErrorAPI)
+		resp = nil
+	} else {
+		record.Model = optionalString(resp.Model)
+		record.RawResponse = optionalString(resp.Text)
+		record.InputTokens = resp.InputTokens
+		record.OutputTokens = resp.OutputTokens
+
+		generated, err = ParseBlogPost(resp.Text, spec)
+		var validationErr *PostValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			record.ErrorKind = optionalString(generationErrorValidation)
+		case err != nil:
+			record.ErrorKind = optionalString(generationErrorParse)
+		}
+	}
+	if err != nil {
+		record.Error = optionalString(err.Error())
+	}
+
+	if job != nil {
+		if recordErr := s.repo.AddAttempt(context.WithoutCancel(ctx), record); recordErr != nil {
+			logger.Warnf(ctx, "[GENERATION-JOB] ⚠️  Failed to record attempt %d: %v", number, recordErr)
+		}
+		if record.Model != nil {
+			job.Model = record.Model
+		}
+	}
+
+	return generated, resp, err
+}
+
+// generationBackoff - Exponential backoff (2s, 4s, 8s … capped at 30s) with up to 50% jitter
+func generationBackoff(retry int) time.Duration {
+	delay := time.Duration(float64(generationBaseBackoff) * math.Pow(2, float64(retry-1)))
+	if delay > generationMaxBackoff {
+		delay = generationMaxBackoff
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay)/2+1))
+}
+
+// ListJobs - Paginated job history, newest first
+func (s *GenerationJobService) ListJobs(ctx context.Context, status string, page, limit int) (*PaginatedGenerationJobsResponse, error) {
+	switch status {
+	case "", GenerationStatusRunning, GenerationStatusSucceeded, GenerationStatusFailed:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidJobFilter, status)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+	filter := model.GenerationJobFilter{
+		Status: status,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.GenerationJobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		response := toGenerationJobResponse(job)
+		// Keep the list light; prompts and raw output are on the detail endpoint
+		response.Prompt = ""
+		response.RawResponse = nil
+		responses = append(responses, response)
+	}
+
+	return &PaginatedGenerationJobsResponse{
+		TotalPages:     int(math.Ceil(float64(total) / float64(limit))),
+		TotalDocuments: total,
+		Page:           page,
+		Limit:          limit,
+		Jobs:           responses,
+	}, nil
+}
+
+// GetJob - One job with every attempt
+func (s *GenerationJobService) GetJob(ctx context.Context, jobID string) (*model.GenerationJobResponse, error) {
+	var id pgtype.UUID
+	if err := id.Scan(jobID); err != nil {
+		return nil, ErrGenerationJobNotFound
+	}
+
+	job, err := s.repo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrGenerationJobNotFound
+	}
+
+	attempts, err := s.repo.ListAttempts(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toGenerationJobResponse(job)
+	response.AttemptLog = make([]model.GenerationAttemptResponse, 0, len(attempts))
+	for _, attempt := range attempts {
+		response.AttemptLog = append(response.AttemptLog, model.GenerationAttemptResponse{
+			Attempt:      attempt.Attempt,
+			Purpose:      attempt.Purpose,
+			Model:        attempt.Model,
+			RawResponse:  attempt.RawResponse,
+			Error:        attempt.Error,
+			ErrorKind:    attempt.ErrorKind,
+			LatencyMs:    attempt.LatencyMs,
+			InputTokens:  attempt.InputTokens,
+			OutputTokens: attempt.OutputTokens,
+			CreatedAt:    attempt.CreatedAt,
+		})
+	}
+
+	return &response, nil
+}
+
+func toGenerationJobResponse(job *model.GenerationJob) model.GenerationJobResponse {
+	response := model.GenerationJobResponse{
+		ID:          job.ID.String(),
+		Trigger:     job.Trigger,
+		Status:      job.Status,
+		Topic:       job.Topic,
+		Category:    job.Category,
+		Provider:    job.Provider,
+		Model:       job.Model,
+		Prompt:      job.Prompt,
+		Attempts:    job.Attempts,
+		RawResponse: job.RawResponse,
+		Error:       job.Error,
+		LatencyMs:   job.LatencyMs,
+		CreatedAt:   job.CreatedAt,
+		FinishedAt:  job.FinishedAt,
+	}
+	if job.TopicID.Valid {
+		topicID := job.TopicID.String()
+		response.TopicID = &topicID
+	}
+	if job.PersonaID.Valid {
+		personaID := job.PersonaID.String()
+		response.PersonaID = &personaID
+	}
+	if job.PostID.Valid {
+		postID := job.PostID.String()
+		response.PostID = &postID
+	}
+	return response
+}
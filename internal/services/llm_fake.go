@@ -0,0 +1,138 @@
+// internal/services/llm_fake.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// FakeLLMProvider - Deterministic offline provider for development and tests.
+// The same request always produces the same response.
+type FakeLLMProvider struct {
+	// Respond overrides the built-in output when set
+	Respond func(req LLMRequest) (string, error)
+}
+
+func NewFakeLLMProvider() *FakeLLMProvider {
+	return &FakeLLMProvider{}
+}
+
+func (p *FakeLLMProvider) Name() string  { return LLMProviderFake }
+func (p *FakeLLMProvider) Model() string { return "fake-deterministic" }
+func (p *FakeLLMProvider) Close() error  { return nil }
+
+// Generate - A blog-post shaped JSON object in JSON mode, plain markdown otherwise
+func (p *FakeLLMProvider) Generate(ctx context.Context, req LLMRequest) (*LLMResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	model := p.Model()
+	if req.Model != "" {
+		model = req.Model
+	}
+
+	var (
+		text string
+		err  error
+	)
+	if p.Respond != nil {
+		text, err = p.Respond(req)
+	} else {
+		text, err = fakeCompletion(req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &LLMResponse{
+		Text:         text,
+		Model:        model,
+		InputTokens:  fakeTokenCount(req.System) + fakeTokenCount(req.Prompt) + estimatedImageTokens*len(req.Images),
+		OutputTokens: fakeTokenCount(text),
+	}, nil
+}
+
+var fakeWords = []string{
+	"practical", "simple", "curious", "reliable", "quiet", "bold", "steady", "clever",
+	"systems", "habits", "ideas", "stories", "patterns", "journeys", "lessons", "details",
+}
+
+func fakeCompletion(req LLMRequest) (string, error) {
+	h := fnv.New64a()
+	h.Write([]byte(req.System))
+	h.Write([]byte{0})
+	h.Write([]byte(req.Prompt))
+	for _, image := range req.Images {
+		h.Write(image.Data)
+	}
+	seed := h.Sum64()
+
+	word := func(i int) string {
+		return fakeWords[(seed>>(uint(i)%48)+uint64(i))%uint64(len(fakeWords))]
+	}
+
+	title := fmt.Sprintf("Notes on %s %s", word(1), word(2))
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "## Introduction\n\nThis is deterministic placeholder text about %s %s.\n\n", word(3), word(4))
+	body.WriteString("## Main points\n\n")
+	for i := 0; i < 5; i++ {
+		label := word(i + 5)
+		fmt.Fprintf(&body, "- **%s %s**: ", strings.ToUpper(label[:1])+label[1:], word(i+6))
+		for j := 0; j < 30; j++ {
+			body.WriteString(word(i*30 + j))
+			body.WriteString(" ")
+		}
+		body.WriteString("\n")
+	}
+	body.WriteString("\n## Conclusion\n\nWhich of these ideas will you try first?")
+
+	if !req.JSON {
+		return "# " + title + "\n\n" + body.String(), nil
+	}
+
+	// One object answers every JSON prompt: the post fields, the writing assistant's keys,
+	// moderation scores and image descriptions
+	payload, err := json.Marshal(map[string]any{
+		"title":    title,
+		"content":  body.String(),
+		"tags":     fakeTags(word(7), word(8), word(9)),
+		"titles":   []string{title, fmt.Sprintf("Why %s %s matter", word(10), word(11)), fmt.Sprintf("A guide to %s %s", word(12), word(13))},
+		"summary":  fmt.Sprintf("A short look at %s %s and %s %s.", word(3), word(4), word(5), word(6)),
+		"rewrite":  fmt.Sprintf("A %s rewrite about %s %s.", word(14), word(15), word(16)),
+		"changes":  []string{"tightened wording", "fixed punctuation"},
+		"toxicity": 0,
+		"spam":     0,
+		"reasons":  []string{},
+		"alt_text": fmt.Sprintf("A %s illustration of %s %s", word(17), word(18), word(19)),
+		"caption":  fmt.Sprintf("%s %s, pictured.", strings.ToUpper(word(20)[:1])+word(20)[1:], word(21)),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+// fakeTags - Distinct tags, always at least three
+func fakeTags(words ...string) []string {
+	tags := []string{"offline", "sample"}
+	for _, w := range words {
+		duplicate := false
+		for _, t := range tags {
+			duplicate = duplicate || t == w
+		}
+		if !duplicate {
+			tags = append(tags, w)
+		}
+	}
+	return tags
+}
+
+// fakeTokenCount - Rough estimate (about four characters per token)
+func fakeTokenCount(text string) int {
+	return (len(text) + 3) / 4
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/internal/model"
+)
+
+func TestFakeLLMProviderIsDeterministic(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakeLLMProvider()
+
+	tests := []struct {
+		name string
+		req  LLMRequest
+	}{
+		{"markdown", LLMRequest{Prompt: "Write about sourdough"}},
+		{"json", LLMRequest{Prompt: "Write about sourdough", JSON: true}},
+		{"with system prompt", LLMRequest{System: "You are terse.", Prompt: "Write about sourdough", JSON: true}},
+		{"with image", LLMRequest{Prompt: "Describe this", JSON: true, Images: []LLMImage{{MIMEType: "image/png", Data: []byte{1, 2, 3}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := provider.Generate(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+			second, err := NewFakeLLMProvider().Generate(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+			if !reflect.DeepEqual(first, second) {
+				t.Errorf("same request, different responses:\n%+v\n%+v", first, second)
+			}
+		})
+	}
+
+	a, _ := provider.Generate(ctx, LLMRequest{Prompt: "Write about sourdough", JSON: true})
+	b, _ := provider.Generate(ctx, LLMRequest{Prompt: "Write about chess openings", JSON: true})
+	if a.Text == b.Text {
+		t.Error("different prompts gave the same response")
+	}
+}
+
+func TestFakeLLMPostPassesValidation(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakeLLMProvider()
+
+	for _, topic := range []string{"Go testing", "Sourdough at home", "Learning the cello as an adult", "Why maps lie"} {
+		t.Run(topic, func(t *testing.T) {
+			spec := NormalizePostRequest(&model.AIPostRequest{Topic: topic, Category: "general"})
+			prompt, err := PersonaPrompt(nil, spec)
+			if err != nil {
+				t.Fatalf("PersonaPrompt: %v", err)
+			}
+
+			resp, err := provider.Generate(ctx, LLMRequest{Prompt: prompt, JSON: true})
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+			if _, err := ParseBlogPost(resp.Text, spec); err != nil {
+				t.Errorf("fake post rejected: %v", err)
+			}
+		})
+	}
+}
+
+func TestAutoPosterPublishesFakePost(t *testing.T) {
+	for _, requireReview := range []bool{false, true} {
+		jobs := &memoryGenerationJobRepo{}
+		posts := &memoryPostRepo{}
+		poster := &AutoPosterService{
+			jobs:          NewGenerationJobService(jobs, NewAIService(NewFakeLLMProvider(), nil), config.GenerationConfig{}),
+			postRepo:      posts,
+			requireReview: requireReview,
+		}
+		persona := &model.BotPersona{ID: newUUID(), UserID: newUUID()}
+		selection := &model.TopicSelection{Topic: "Go testing", Category: "tech"}
+
+		postID, err := poster.postSelection(context.Background(), persona, "manual", selection)
+		if err != nil {
+			t.Fatalf("requireReview=%v: postSelection: %v", requireReview, err)
+		}
+
+		if len(posts.posts) != 1 || posts.posts[0].ID.String() != postID {
+			t.Fatalf("requireReview=%v: post %s was not saved", requireReview, postID)
+		}
+		post := posts.posts[0]
+		if !post.AIGenerated || post.AuthorID != persona.UserID || post.IsPublished == requireReview {
+			t.Errorf("requireReview=%v: saved post = %+v", requireReview, post)
+		}
+
+		if len(jobs.jobs) != 1 || jobs.jobs[0].Status != GenerationStatusSucceeded {
+			t.Fatalf("requireReview=%v: job not recorded as succeeded: %+v", requireReview, jobs.jobs)
+		}
+		if len(jobs.attempts) != 1 || jobs.attempts[0].ErrorKind != nil {
+			t.Errorf("requireReview=%v: want one clean attempt, got %+v", requireReview, jobs.attempts)
+		}
+	}
+}
+
+type memoryGenerationJobRepo struct {
+	jobs     []*model.GenerationJob
+	attempts []*model.GenerationAttempt
+}
+
+func (r *memoryGenerationJobRepo) Create(_ context.Context, job *model.GenerationJob) error {
+	job.ID = newUUID()
+	job.Status = GenerationStatusRunning
+	r.jobs = append(r.jobs, job)
+	return nil
+}
+
+func (r *memoryGenerationJobRepo) AddAttempt(_ context.Context, attempt *model.GenerationAttempt) error {
+	attempt.ID = newUUID()
+	r.attempts = append(r.attempts, attempt)
+	return nil
+}
+
+func (r *memoryGenerationJobRepo) Finish(context.Context, *model.GenerationJob) error { return nil }
+
+func (r *memoryGenerationJobRepo) FailStale(context.Context) (int64, error) { return 0, nil }
+
+func (r *memoryGenerationJobRepo) FindByID(_ context.Context, jobID string) (*model.GenerationJob, error) {
+	for _, job := range r.jobs {
+		if job.ID.String() == jobID {
+			return job, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryGenerationJobRepo) List(context.Context, model.GenerationJobFilter) ([]*model.GenerationJob, error) {
+	return r.jobs, nil
+}
+
+func (r *memoryGenerationJobRepo) Count(context.Context, model.GenerationJobFilter) (int64, error) {
+	return int64(len(r.jobs)), nil
+}
+
+func (r *memoryGenerationJobRepo) ListAttempts(context.Context, string) ([]*model.GenerationAttempt, error) {
+	return r.attempts, nil
+}
+
+type memoryPostRepo struct {
+	posts []*model.Post
+}
+
+func (r *memoryPostRepo) Create(_ context.Context, post *model.Post) error {
+	post.ID = newUUID()
+	r.posts = append(r.posts, post)
+	return nil
+}
+
+func (r *memoryPostRepo) GetAllPost(context.Context, int, int, string) ([]*model.Post, error) {
+	return r.posts, nil
+}
+
+func (r *memoryPostRepo) CountPosts(context.Context, string) (int64, error) {
+	return int64(len(r.posts)), nil
+}
+
+func (r *memoryPostRepo) FindByID(_ context.Context, postID string) (*model.Post, error) {
+	for _, post := range r.posts {
+		if post.ID.String() == postID {
+			return post, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryPostRepo) FindByAuthorID(context.Context, string) ([]*model.Post, error) {
+	return nil, nil
+}
+
+func (r *memoryPostRepo) Update(context.Context, *model.Post) error { return nil }
+
+func (r *memoryPostRepo) Delete(context.Context, string) error { return nil }
+
+func (r *memoryPostRepo) IncrementViewCount(context.Context, string) error { return nil }
@@ -0,0 +1,131 @@
+// internal/services/llm_gemini.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	generativelanguage "cloud.google.com/go/ai/generativelanguage/apiv1beta"
+	"cloud.google.com/go/ai/generativelanguage/apiv1beta/generativelanguagepb"
+	"google.golang.org/api/option"
+)
+
+const defaultGeminiModel = "models/gemini-flash-latest" // Free tier model
+
+// GeminiProvider - Google Gemini through the generativelanguage SDK
+type GeminiProvider struct {
+	client *generativelanguage.GenerativeClient
+	model  string
+}
+
+func NewGeminiProvider(ctx context.Context, apiKey, model string) (*GeminiProvider, error) {
+	if apiKey == "" {
+		return nil, errors.New("GEMINI_API_KEY not set")
+	}
+
+	client, err := generativelanguage.NewGenerativeClient(ctx, option.WithAPIKey(apiKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
+	}
+
+	return &GeminiProvider{client: client, model: geminiModelName(model)}, nil
+}
+
+func (p *GeminiProvider) Name() string  { return LLMProviderGemini }
+func (p *GeminiProvider) Model() string { return p.model }
+
+// Close - Clean up the client
+func (p *GeminiProvider) Close() error {
+	if p.client != nil {
+		return p.client.Close()
+	}
+	return nil
+}
+
+// Generate - Single-turn generateContent call; images are sent inline
+func (p *GeminiProvider) Generate(ctx context.Context, req LLMRequest) (*LLMResponse, error) {
+	model := p.model
+	if req.Model != "" {
+		model = geminiModelName(req.Model)
+	}
+
+	parts := []*generativelanguagepb.Part{textPart(req.Prompt)}
+	for _, image := range req.Images {
+		parts = append(parts, &generativelanguagepb.Part{
+			Data: &generativelanguagepb.Part_InlineData{
+				InlineData: &generativelanguagepb.Blob{MimeType: image.MIMEType, Data: image.Data},
+			},
+		})
+	}
+
+	genReq := &generativelanguagepb.GenerateContentRequest{
+		Model: model,
+		Contents: []*generativelanguagepb.Content{
+			{
+				Role:  "user",
+				Parts: parts,
+			},
+		},
+		GenerationConfig: &generativelanguagepb.GenerationConfig{},
+	}
+
+	if req.System != "" {
+		genReq.SystemInstruction = &generativelanguagepb.Content{
+			Parts: []*generativelanguagepb.Part{textPart(req.System)},
+		}
+	}
+	if req.Temperature != nil {
+		temperature := float32(*req.Temperature)
+		genReq.GenerationConfig.Temperature = &temperature
+	}
+	if req.MaxTokens > 0 {
+		maxTokens := int32(req.MaxTokens)
+		genReq.GenerationConfig.MaxOutputTokens = &maxTokens
+	}
+	if req.JSON {
+		genReq.GenerationConfig.ResponseMimeType = "application/json"
+	}
+
+	resp, err := p.client.GenerateContent(ctx, genReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil || len(resp.Candidates[0].Content.Parts) == 0 {
+		return nil, ErrLLMEmptyResponse
+	}
+
+	var text strings.Builder
+	for _, part := range resp.Candidates[0].Content.Parts {
+		text.WriteString(part.GetText())
+	}
+
+	result := &LLMResponse{
+		Text:  text.String(),
+		Model: model,
+	}
+	if usage := resp.UsageMetadata; usage != nil {
+		result.InputTokens = int(usage.PromptTokenCount)
+		result.OutputTokens = int(usage.CandidatesTokenCount)
+	}
+	return result, nil
+}
+
+func textPart(text string) *generativelanguagepb.Part {
+	return &generativelanguagepb.Part{
+		Data: &generativelanguagepb.Part_Text{Text: text},
+	}
+}
+
+// geminiModelName - Accept "gemini-1.5-pro" as well as "models/gemini-1.5-pro"
+func geminiModelName(model string) string {
+	if model == "" {
+		return defaultGeminiModel
+	}
+	if !strings.HasPrefix(model, "models/") {
+		return "models/" + model
+	}
+	return model
+}
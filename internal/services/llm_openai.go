@@ -0,0 +1,178 @@
+// internal/services/llm_openai.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "gpt-4o-mini"
+)
+
+// OpenAIProvider - Any OpenAI-compatible chat completions API (OpenAI, Ollama, llama.cpp, vLLM, ...)
+type OpenAIProvider struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+// NewOpenAIProvider - apiKey may be empty for local servers
+func NewOpenAIProvider(baseURL, apiKey, model string) (*OpenAIProvider, error) {
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	if model == "" {
+		model = defaultOpenAIModel
+	}
+
+	return &OpenAIProvider{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: &http.Client{Timeout: 2 * time.Minute},
+	}, nil
+}
+
+func (p *OpenAIProvider) Name() string  { return LLMProviderOpenAI }
+func (p *OpenAIProvider) Model() string { return p.model }
+func (p *OpenAIProvider) Close() error  { return nil }
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// openAIRequestMessage - Content is a string, or a list of parts when images are attached
+type openAIRequestMessage struct {
+	Role    string `json:"role"`
+	Content any    `json:"content"`
+}
+
+type openAIContentPart struct {
+	Type     string          `json:"type"` // text | image_url
+	Text     string          `json:"text,omitempty"`
+	ImageURL *openAIImageURL `json:"image_url,omitempty"`
+}
+
+type openAIImageURL struct {
+	URL string `json:"url"`
+}
+
+type openAIChatRequest struct {
+	Model          string                 `json:"model"`
+	Messages       []openAIRequestMessage `json:"messages"`
+	Temperature    *float64               `json:"temperature,omitempty"`
+	MaxTokens      int                    `json:"max_tokens,omitempty"`
+	ResponseFormat map[string]string      `json:"response_format,omitempty"`
+}
+
+type openAIChatResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Generate - POST {baseURL}/chat/completions
+func (p *OpenAIProvider) Generate(ctx context.Context, req LLMRequest) (*LLMResponse, error) {
+	chatReq := openAIChatRequest{
+		Model:       p.model,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+	}
+	if req.Model != "" {
+		chatReq.Model = req.Model
+	}
+	if req.System != "" {
+		chatReq.Messages = append(chatReq.Messages, openAIRequestMessage{Role: "system", Content: req.System})
+	}
+	if len(req.Images) == 0 {
+		chatReq.Messages = append(chatReq.Messages, openAIRequestMessage{Role: "user", Content: req.Prompt})
+	} else {
+		// Images go inline as data URLs so local servers need no internet access
+		parts := []openAIContentPart{{Type: "text", Text: req.Prompt}}
+		for _, image := range req.Images {
+			parts = append(parts, openAIContentPart{
+				Type:     "image_url",
+				ImageURL: &openAIImageURL{URL: "data:" + image.MIMEType + ";base64," + base64.StdEncoding.EncodeToString(image.Data)},
+			})
+		}
+		chatReq.Messages = append(chatReq.Messages, openAIRequestMessage{Role: "user", Content: parts})
+	}
+	if req.JSON {
+		chatReq.ResponseFormat = map[string]string{"type": "json_object"}
+	}
+
+	body, err := json.Marshal(chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode chat request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build chat request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call chat completions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 8<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chat response: %w", err)
+	}
+
+	var chatResp openAIChatResponse
+	if err := json.Unmarshal(respBody, &chatResp); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("chat completions failed: %w", &LLMStatusError{StatusCode: resp.StatusCode})
+		}
+		return nil, fmt.Errorf("failed to decode chat response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		statusErr := &LLMStatusError{StatusCode: resp.StatusCode}
+		if chatResp.Error != nil {
+			statusErr.Message = chatResp.Error.Message
+		}
+		return nil, fmt.Errorf("chat completions failed: %w", statusErr)
+	}
+
+	if len(chatResp.Choices) == 0 || chatResp.Choices[0].Message.Content == "" {
+		return nil, ErrLLMEmptyResponse
+	}
+
+	model := chatResp.Model
+	if model == "" {
+		model = chatReq.Model
+	}
+
+	return &LLMResponse{
+		Text:         chatResp.Choices[0].Message.Content,
+		Model:        model,
+		InputTokens:  chatResp.Usage.PromptTokens,
+		OutputTokens: chatResp.Usage.CompletionTokens,
+	}, nil
+}
@@ -0,0 +1,170 @@
+// internal/services/llm_provider.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LLM providers
+const (
+	LLMProviderGemini = "gemini"
+	LLMProviderOpenAI = "openai"
+	LLMProviderFake   = "fake"
+)
+
+var ErrLLMEmptyResponse = errors.New("empty response from language model")
+
+// LLMStatusError - An HTTP provider answered with a non-200 status
+type LLMStatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *LLMStatusError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("language model returned %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("language model returned %d", e.StatusCode)
+}
+
+// LLMProvider - A text generation backend used by AIService
+type LLMProvider interface {
+	Name() string
+	Model() string
+	Generate(ctx context.Context, req LLMRequest) (*LLMResponse, error)
+	Close() error
+}
+
+// LLMRequest - One prompt/response exchange
+type LLMRequest struct {
+	System      string
+	Prompt      string
+	Model       string     // overrides the provider's default model when set
+	Temperature *float64   // provider default when nil
+	MaxTokens   int        // provider default when 0
+	JSON        bool       // ask the model for a single JSON object
+	Feature     string     // usage accounting and budgets (UsageFeature*)
+	UserID      string     // user the call is made for, empty for system calls
+	Images      []LLMImage // sent after the prompt; the model must accept images
+}
+
+// LLMImage - An image attached to a request
+type LLMImage struct {
+	MIMEType string // e.g. image/jpeg
+	Data     []byte
+}
+
+// LLMResponse - Generated text plus token usage when the provider reports it
+type LLMResponse struct {
+	Text         string
+	Model        string
+	InputTokens  int
+	OutputTokens int
+}
+
+// LLMConfig - Which provider to use and how to reach it
+type LLMConfig struct {
+	Provider string
+	Model    string
+	APIKey   string
+	BaseURL  string
+}
+
+// LoadLLMConfig - The provider, model and the provider's credentials from the app config
+func LoadLLMConfig(app config.LLMConfig) LLMConfig {
+	cfg := LLMConfig{
+		Provider: app.Provider,
+		Model:    app.Model,
+	}
+	if cfg.Provider == "" {
+		cfg.Provider = LLMProviderGemini
+	}
+
+	switch cfg.Provider {
+	case LLMProviderGemini:
+		cfg.APIKey = app.GeminiAPIKey
+	case LLMProviderOpenAI:
+		cfg.APIKey = app.OpenAIAPIKey
+		cfg.BaseURL = app.OpenAIBaseURL
+	}
+
+	return cfg
+}
+
+// NewLLMProvider - Build the configured provider
+func NewLLMProvider(ctx context.Context, cfg LLMConfig) (LLMProvider, error) {
+	var (
+		provider LLMProvider
+		err      error
+	)
+
+	switch cfg.Provider {
+	case LLMProviderGemini:
+		provider, err = NewGeminiProvider(ctx, cfg.APIKey, cfg.Model)
+	case LLMProviderOpenAI:
+		provider, err = NewOpenAIProvider(cfg.BaseURL, cfg.APIKey, cfg.Model)
+	case LLMProviderFake:
+		provider = NewFakeLLMProvider()
+	default:
+		return nil, fmt.Errorf("unknown LLM_PROVIDER %q (expected %s, %s or %s)", cfg.Provider, LLMProviderGemini, LLMProviderOpenAI, LLMProviderFake)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof(ctx, "[AI-SERVICE] LLM provider: %s | Model: %s", provider.Name(), provider.Model())
+	return provider, nil
+}
+
+// cleanJSONResponse - Strip markdown code fences models like to wrap JSON in, and any chatter around the object
+func cleanJSONResponse(text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, "```json")
+	text = strings.TrimPrefix(text, "```")
+	text = strings.TrimSuffix(text, "```")
+	text = strings.TrimSpace(text)
+
+	if !strings.HasPrefix(text, "{") || !strings.HasSuffix(text, "}") {
+		start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+		if start >= 0 && end > start {
+			text = text[start : end+1]
+		}
+	}
+	return text
+}
+
+// IsTransientLLMError - Rate limits, server errors, timeouts and empty answers are worth retrying
+func IsTransientLLMError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrLLMEmptyResponse) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var statusErr *LLMStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	// Gemini errors carry a gRPC status
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded, codes.Internal, codes.Aborted:
+			return true
+		}
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
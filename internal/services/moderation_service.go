@@ -0,0 +1,441 @@
+// internal/services/moderation_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Comment moderation states and verdict sources
+const (
+	ModerationStatusApproved = "approved"
+	ModerationStatusPending  = "pending"
+	ModerationStatusRejected = "rejected"
+
+	moderationSourceRules   = "rules"
+	moderationSourceLLM     = "llm"
+	moderationSourceAIReply = "ai_reply" // bot replies held for approval
+)
+
+const moderationLLMTimeout = 8 * time.Second
+
+var (
+	ErrCommentNotPending      = errors.New("comment is not waiting for moderation")
+	ErrInvalidModerationState = errors.New("invalid moderation status")
+)
+
+type CommentModerationRepo interface {
+	FindByID(ctx context.Context, commentID string) (*model.Comment, error)
+	ListForModeration(ctx context.Context, status string, limit, offset int) ([]*model.Comment, error)
+	CountForModeration(ctx context.Context, status string) (int64, error)
+	UpdateModeration(ctx context.Context, comment *model.Comment) error
+}
+
+// ModerationConfig - How strict comment moderation is
+type ModerationConfig struct {
+	Threshold float64  // hold a comment when toxicity or spam reaches this score
+	UseLLM    bool     // ask the AI provider when the rules are not sure
+	Blocklist []string // extra words or phrases that always hold a comment
+}
+
+// LoadModerationConfig - The moderation settings from the app config, with the blocklist normalised
+func LoadModerationConfig(app config.ModerationConfig) ModerationConfig {
+	cfg := ModerationConfig{
+		Threshold: app.Threshold,
+		UseLLM:    app.UseLLM,
+	}
+
+	for _, term := range app.Blocklist {
+		if term = normalizeModerationText(term); term != "" {
+			cfg.Blocklist = append(cfg.Blocklist, term)
+		}
+	}
+
+	return cfg
+}
+
+// ModerationService - Scores new comments and handles the moderator queue
+type ModerationService struct {
+	repo    CommentModerationRepo
+	ai      *AIService
+	audit   *AuditService
+	replies *BotReplyService
+	cfg     ModerationConfig
+}
+
+func NewModerationService(repo CommentModerationRepo, ai *AIService, audit *AuditService, replies *BotReplyService, cfg ModerationConfig) *ModerationService {
+	if cfg.UseLLM && (ai == nil || ai.Provider() == nil) {
+		logger.Warnf(context.Background(), "[MODERATION] ⚠️  COMMENT_MODERATION_LLM is set but no LLM provider is configured, using rules only")
+		cfg.UseLLM = false
+	}
+	return &ModerationService{repo: repo, ai: ai, audit: audit, replies: replies, cfg: cfg}
+}
+
+type PaginatedModerationResponse struct {
+	TotalPages     int                               `json:"totalPages"`
+	TotalDocuments int64                             `json:"totalDocuments"`
+	Page           int                               `json:"page"`
+	Limit          int                               `json:"limit"`
+	Comments       []model.ModerationCommentResponse `json:"comments"`
+}
+
+// Check - Score a comment with the local rules, then the LLM classifier if enabled and the rules did not already hold it
+func (s *ModerationService) Check(ctx context.Context, text string) model.ModerationResult {
+	result := checkModerationRules(text, s.cfg.Blocklist)
+
+	if s.cfg.UseLLM && !s.exceeds(result) {
+		llmCtx, cancel := context.WithTimeout(ctx, moderationLLMTimeout)
+		verdict, err := s.classify(llmCtx, text)
+		cancel()
+		if err != nil {
+			// Fail open: an unavailable classifier must not block commenting
+			logger.Warnf(ctx, "[MODERATION] ⚠️  LLM classifier failed, using rules only: %v", err)
+		} else {
+			result.Source = moderationSourceLLM
+			result.Toxicity = math.Max(result.Toxicity, verdict.Toxicity)
+			result.Spam = math.Max(result.Spam, verdict.Spam)
+			result.Reasons = append(result.Reasons, verdict.Reasons...)
+		}
+	}
+
+	result.Status = ModerationStatusApproved
+	if s.exceeds(result) {
+		result.Status = ModerationStatusPending
+	}
+	return result
+}
+
+func (s *ModerationService) exceeds(result model.ModerationResult) bool {
+	return result.Toxicity >= s.cfg.Threshold || result.Spam >= s.cfg.Threshold
+}
+
+type llmModerationVerdict struct {
+	Toxicity float64  `json:"toxicity"`
+	Spam     float64  `json:"spam"`
+	Reasons  []string `json:"reasons"`
+}
+
+// classify - Ask the model for toxicity and spam scores between 0 and 1
+func (s *ModerationService) classify(ctx context.Context, text string) (*llmModerationVerdict, error) {
+	prompt := fmt.Sprintf(`You moderate comments on a blog. Rate the comment below.
+
+Return ONLY a JSON object:
+{
+  "toxicity": number from 0 to 1 (insults, harassment, hate, threats),
+  "spam": number from 0 to 1 (advertising, scams, link dropping, off-topic promotion),
+  "reasons": ["short reason", ...] (empty when the comment is fine)
+}
+
+Disagreement, criticism and strong opinions are not toxic on their own.
+
+Comment:
+"""
+%s
+"""`, text)
+
+	temperature := 0.0
+	resp, err := s.ai.Complete(ctx, LLMRequest{Prompt: prompt, JSON: true, Temperature: &temperature, Feature: UsageFeatureModeration})
+	if err != nil {
+		return nil, err
+	}
+
+	var verdict llmModerationVerdict
+	if err := json.Unmarshal([]byte(cleanJSONResponse(resp.Text)), &verdict); err != nil {
+		return nil, fmt.Errorf("unusable classifier response: %w", err)
+	}
+
+	verdict.Toxicity = clampScore(verdict.Toxicity)
+	verdict.Spam = clampScore(verdict.Spam)
+	for i, reason := range verdict.Reasons {
+		verdict.Reasons[i] = "llm: " + strings.TrimSpace(reason)
+	}
+	return &verdict, nil
+}
+
+// ListQueue - Comments in a moderation state (pending by default), oldest first
+func (s *ModerationService) ListQueue(ctx context.Context, status string, page, limit int) (*PaginatedModerationResponse, error) {
+	if status == "" {
+		status = ModerationStatusPending
+	}
+	switch status {
+	case ModerationStatusPending, ModerationStatusApproved, ModerationStatusRejected:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidModerationState, status)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	total, err := s.repo.CountForModeration(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repo.ListForModeration(ctx, status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.ModerationCommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		responses = append(responses, toModerationCommentResponse(comment))
+	}
+
+	return &PaginatedModerationResponse{
+		TotalPages:     int(math.Ceil(float64(total) / float64(limit))),
+		TotalDocuments: total,
+		Page:           page,
+		Limit:          limit,
+		Comments:       responses,
+	}, nil
+}
+
+// ApproveComment - Publish a held comment
+func (s *ModerationService) ApproveComment(ctx context.Context, commentID, moderatorID string) (*model.ModerationCommentResponse, error) {
+	return s.decide(ctx, commentID, moderatorID, ModerationStatusApproved, "")
+}
+
+// RejectComment - Keep a held comment hidden for good
+func (s *ModerationService) RejectComment(ctx context.Context, commentID, moderatorID, reason string) (*model.ModerationCommentResponse, error) {
+	return s.decide(ctx, commentID, moderatorID, ModerationStatusRejected, strings.TrimSpace(reason))
+}
+
+func (s *ModerationService) decide(ctx context.Context, commentID, moderatorID, status, reason string) (*model.ModerationCommentResponse, error) {
+	var id pgtype.UUID
+	if err := id.Scan(commentID); err != nil {
+		return nil, ErrCommentNotFound
+	}
+
+	comment, err := s.repo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, ErrCommentNotFound
+	}
+	if comment.ModerationStatus != ModerationStatusPending {
+		return nil, ErrCommentNotPending
+	}
+
+	now := time.Now()
+	comment.ModerationStatus = status
+	comment.ModerationNote = optionalString(reason)
+	comment.ModeratedAt = &now
+	comment.ModeratedBy = pgtype.UUID{}
+	_ = comment.ModeratedBy.Scan(moderatorID)
+
+	if err := s.repo.UpdateModeration(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	action := AuditActionCommentApprove
+	if status == ModerationStatusRejected {
+		action = AuditActionCommentReject
+	}
+	s.audit.Record(ctx, AuditEvent{
+		Action:     action,
+		TargetType: "comment",
+		TargetID:   commentID,
+		After:      map[string]string{"status": status, "reason": reason},
+	})
+
+	logger.Infof(ctx, "[MODERATION] Comment %s %s", commentID, status)
+
+	// A held comment on a persona's post gets its reply once it is published
+	if status == ModerationStatusApproved {
+		s.replies.Notify(comment)
+	}
+
+	response := toModerationCommentResponse(comment)
+	return &response, nil
+}
+
+func toModerationCommentResponse(comment *model.Comment) model.ModerationCommentResponse {
+	response := model.ModerationCommentResponse{
+		ID:                comment.ID.String(),
+		PostID:            comment.PostID.String(),
+		AuthorID:          comment.AuthorID.String(),
+		Text:              comment.Text,
+		IsAIReply:         comment.IsAIReply,
+		ModerationStatus:  comment.ModerationStatus,
+		ToxicityScore:     comment.ToxicityScore,
+		SpamScore:         comment.SpamScore,
+		ModerationReasons: comment.ModerationReasons,
+		ModerationSource:  comment.ModerationSource,
+		ModerationNote:    comment.ModerationNote,
+		ModeratedAt:       comment.ModeratedAt,
+		CreatedAt:         comment.CreatedAt,
+	}
+	if response.ModerationReasons == nil {
+		response.ModerationReasons = []string{}
+	}
+	if comment.ParentID.Valid {
+		parentID := comment.ParentID.String()
+		response.ParentID = &parentID
+	}
+	if comment.ModeratedBy.Valid {
+		moderatedBy := comment.ModeratedBy.String()
+		response.ModeratedBy = &moderatedBy
+	}
+	return response
+}
+
+// Local rules: cheap checks that run on every comment
+
+var moderationURLPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+|\b[a-z0-9-]+\.(com|net|org|io|xyz|top|ru|info|biz)\b`)
+
+// Phrases that nearly always mean the comment is abuse
+var moderationSevereTerms = []string{"kill yourself", "kys", "go die", "hope you die"}
+
+// Insults; one alone is not enough to hold a comment
+var moderationInsultTerms = []string{"idiot", "stupid", "moron", "loser", "dumb", "shut up", "pathetic", "trash", "garbage", "clown"}
+
+var moderationSpamTerms = []string{
+	"buy now", "click here", "free money", "limited offer", "work from home", "earn money",
+	"make money fast", "casino", "viagra", "crypto giveaway", "investment opportunity",
+	"dm me", "whatsapp", "telegram", "check out my", "subscribe to my", "promo code",
+}
+
+func checkModerationRules(text string, blocklist []string) model.ModerationResult {
+	result := model.ModerationResult{Source: moderationSourceRules, Reasons: []string{}}
+	normalized := " " + normalizeModerationText(text) + " "
+
+	for _, term := range blocklist {
+		if strings.Contains(normalized, " "+term+" ") {
+			result.Toxicity = 1
+			result.Reasons = append(result.Reasons, "blocked term")
+			break
+		}
+	}
+
+	for _, term := range moderationSevereTerms {
+		if strings.Contains(normalized, " "+term+" ") {
+			result.Toxicity = 1
+			result.Reasons = append(result.Reasons, "threat or self-harm abuse")
+			break
+		}
+	}
+
+	insults := 0
+	for _, term := range moderationInsultTerms {
+		if strings.Contains(normalized, " "+term+" ") {
+			insults++
+		}
+	}
+	if insults > 0 {
+		result.Toxicity = math.Max(result.Toxicity, math.Min(0.4*float64(insults), 0.9))
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%d insult(s)", insults))
+	}
+
+	if shouting(text) {
+		result.Toxicity = math.Min(result.Toxicity+0.3, 1)
+		result.Reasons = append(result.Reasons, "mostly capital letters")
+	}
+
+	switch links := len(moderationURLPattern.FindAllString(text, -1)); {
+	case links >= 3:
+		result.Spam = 0.9
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%d links", links))
+	case links > 0:
+		result.Spam = 0.3 * float64(links)
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%d link(s)", links))
+	}
+
+	spamTerms := 0
+	for _, term := range moderationSpamTerms {
+		if strings.Contains(normalized, " "+term+" ") {
+			spamTerms++
+		}
+	}
+	if spamTerms > 0 {
+		result.Spam = math.Min(result.Spam+0.4*float64(spamTerms), 1)
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%d promotional phrase(s)", spamTerms))
+	}
+
+	if longRun(text, 10) || repetitive(normalized) {
+		result.Spam = math.Min(result.Spam+0.4, 1)
+		result.Reasons = append(result.Reasons, "repeated characters or words")
+	}
+
+	return result
+}
+
+// normalizeModerationText - Lower-case words separated by single spaces
+func normalizeModerationText(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\''
+	})
+	return strings.Join(words, " ")
+}
+
+// shouting - Long enough to matter and mostly upper case
+func shouting(text string) bool {
+	letters, upper := 0, 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters >= 20 && float64(upper)/float64(letters) > 0.7
+}
+
+// longRun - The same character n or more times in a row ("!!!!!!!!!!", "heyyyyyyyyyy")
+func longRun(text string, n int) bool {
+	var (
+		last  rune
+		count int
+	)
+	for _, r := range text {
+		if r == last {
+			count++
+		} else {
+			last, count = r, 1
+		}
+		if count >= n && !unicode.IsSpace(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// repetitive - One word makes up more than half of a comment of at least six words
+func repetitive(normalized string) bool {
+	words := strings.Fields(normalized)
+	if len(words) < 6 {
+		return false
+	}
+	counts := make(map[string]int, len(words))
+	for _, word := range words {
+		counts[word]++
+		if counts[word]*2 > len(words) {
+			return true
+		}
+	}
+	return false
+}
+
+func clampScore(v float64) float64 {
+	if math.IsNaN(v) {
+		return 0
+	}
+	return math.Max(0, math.Min(v, 1))
+}
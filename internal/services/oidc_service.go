@@ -0,0 +1,592 @@
+// internal/services/oidc_service.go
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrUnknownProvider       = errors.New("unknown identity provider")
+	ErrInvalidOAuthState     = errors.New("invalid or expired oauth state")
+	ErrIdentityAlreadyLinked = errors.New("identity already linked to another account")
+	ErrIdentityNotFound      = errors.New("identity not found")
+	ErrLastIdentity          = errors.New("cannot unlink the only sign-in method of an external account")
+	ErrEmailNotVerified      = errors.New("provider did not verify the email address")
+)
+
+// oauthStateTTL - How long a user has to finish the provider round-trip
+const oauthStateTTL = 10 * time.Minute
+
+type IdentityRepo interface {
+	Create(ctx context.Context, identity *model.UserIdentity) error
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*model.UserIdentity, error)
+	FindByUserID(ctx context.Context, userID string) ([]*model.UserIdentity, error)
+	TouchLogin(ctx context.Context, identityID string, email *string) error
+	Delete(ctx context.Context, identityID, userID string) error
+	SaveState(ctx context.Context, state *model.OAuthState) error
+	ConsumeState(ctx context.Context, state string) (*model.OAuthState, error)
+	DeleteExpiredStates(ctx context.Context) (int64, error)
+}
+
+// OIDCProvider - A pluggable OpenID Connect identity provider
+type OIDCProvider interface {
+	Name() string
+	DisplayName() string
+	// AuthCodeURL builds the authorization URL with state, nonce and a S256 PKCE challenge
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	// Exchange redeems the code with the PKCE verifier and returns the verified ID token claims
+	Exchange(ctx context.Context, code, nonce, codeVerifier string) (*model.ExternalProfile, error)
+}
+
+// OIDCProviderConfig - Settings for one OIDC provider
+type OIDCProviderConfig struct {
+	Name         string
+	DisplayName  string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// LoadOIDCProviderConfigs - The configured providers, with the display name, redirect URL
+// and scopes defaulted where the config leaves them empty
+func LoadOIDCProviderConfigs(app config.OIDCConfig) []OIDCProviderConfig {
+	var configs []OIDCProviderConfig
+	for _, provider := range app.Providers {
+		cfg := OIDCProviderConfig{
+			Name:         provider.Name,
+			DisplayName:  provider.DisplayName,
+			IssuerURL:    provider.IssuerURL,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURL:  provider.RedirectURL,
+			Scopes:       provider.Scopes,
+		}
+
+		if cfg.DisplayName == "" {
+			cfg.DisplayName = cfg.Name
+		}
+		if cfg.RedirectURL == "" {
+			cfg.RedirectURL = fmt.Sprintf("http://localhost:8080/api/auth/oidc/%s/callback", cfg.Name)
+		}
+		if len(cfg.Scopes) == 0 {
+			cfg.Scopes = []string{"openid", "email", "profile"}
+		}
+
+		configs = append(configs, cfg)
+	}
+
+	return configs
+}
+
+// genericOIDCProvider - Standard OIDC provider using discovery (Google, Keycloak, Auth0, mock servers...)
+type genericOIDCProvider struct {
+	cfg OIDCProviderConfig
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+// NewGenericOIDCProvider - Create a provider; discovery runs lazily on first use
+func NewGenericOIDCProvider(cfg OIDCProviderConfig) OIDCProvider {
+	return &genericOIDCProvider{cfg: cfg}
+}
+
+func (p *genericOIDCProvider) Name() string        { return p.cfg.Name }
+func (p *genericOIDCProvider) DisplayName() string { return p.cfg.DisplayName }
+
+// discover - Fetch the provider metadata once and cache it
+func (p *genericOIDCProvider) discover(ctx context.Context) (*oidc.Provider, *oauth2.Config, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.provider == nil {
+		provider, err := oidc.NewProvider(ctx, p.cfg.IssuerURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to discover %s: %w", p.cfg.Name, err)
+		}
+		p.provider = provider
+	}
+
+	oauthCfg := &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     p.provider.Endpoint(),
+		Scopes:       p.cfg.Scopes,
+	}
+
+	return p.provider, oauthCfg, nil
+}
+
+func (p *genericOIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	_, oauthCfg, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return oauthCfg.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+func (p *genericOIDCProvider) Exchange(ctx context.Context, code, nonce, codeVerifier string) (*model.ExternalProfile, error) {
+	provider, oauthCfg, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oauthCfg.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("token response did not include an id_token")
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: p.cfg.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id_token: %w", err)
+	}
+
+	if idToken.Nonce != nonce {
+		return nil, errors.New("id_token nonce mismatch")
+	}
+
+	var claims struct {
+		Email             string `json:"email"`
+		EmailVerified     *bool  `json:"email_verified"`
+		Name              string `json:"name"`
+		PreferredUsername string `json:"preferred_username"`
+		Picture           string `json:"picture"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to parse id_token claims: %w", err)
+	}
+
+	return &model.ExternalProfile{
+		Provider:          p.cfg.Name,
+		Subject:           idToken.Subject,
+		Email:             strings.ToLower(strings.TrimSpace(claims.Email)),
+		EmailVerified:     claims.EmailVerified != nil && *claims.EmailVerified,
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+		Picture:           claims.Picture,
+	}, nil
+}
+
+type OIDCService struct {
+	userRepo     UserRepo
+	identityRepo IdentityRepo
+	sessions     *SessionService
+	audit        *AuditService
+	policy       RegistrationPolicy
+	providers    map[string]OIDCProvider
+	order        []string
+}
+
+func NewOIDCService(userRepo UserRepo, identityRepo IdentityRepo, sessions *SessionService, audit *AuditService, policy RegistrationPolicy, providers ...OIDCProvider) *OIDCService {
+	s := &OIDCService{
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		sessions:     sessions,
+		audit:        audit,
+		policy:       policy,
+		providers:    make(map[string]OIDCProvider),
+	}
+
+	for _, p := range providers {
+		if _, exists := s.providers[p.Name()]; exists {
+			logger.Warnf(context.Background(), "[OIDC-SERVICE] ⚠️  Duplicate provider %q ignored", p.Name())
+			continue
+		}
+		s.providers[p.Name()] = p
+		s.order = append(s.order, p.Name())
+	}
+
+	logger.Infof(context.Background(), "[OIDC-SERVICE] Configured %d identity provider(s): %v", len(s.order), s.order)
+	return s
+}
+
+// Providers - List configured providers for the login page
+func (s *OIDCService) Providers() []model.OIDCProviderInfo {
+	infos := make([]model.OIDCProviderInfo, 0, len(s.order))
+	for _, name := range s.order {
+		infos = append(infos, model.OIDCProviderInfo{
+			Name:        name,
+			DisplayName: s.providers[name].DisplayName(),
+			LoginURL:    fmt.Sprintf("/api/auth/oidc/%s/login", name),
+		})
+	}
+	return infos
+}
+
+// BeginLogin - Start an authorization code + PKCE flow; linkUserID is set when linking to a signed-in account
+func (s *OIDCService) BeginLogin(ctx context.Context, providerName, linkUserID string) (string, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", ErrUnknownProvider
+	}
+
+	// Opportunistically drop abandoned flows
+	if removed, err := s.identityRepo.DeleteExpiredStates(ctx); err != nil {
+		logger.Errorf(ctx, "[OIDC-SERVICE] Failed to clean expired states: %v", err)
+	} else if removed > 0 {
+		logger.Infof(ctx, "[OIDC-SERVICE] Removed %d expired oauth states", removed)
+	}
+
+	state, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	pending := &model.OAuthState{
+		State:        state,
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		ExpiresAt:    time.Now().Add(oauthStateTTL),
+	}
+
+	if linkUserID != "" {
+		if err := pending.LinkUserID.Scan(linkUserID); err != nil {
+			return "", fmt.Errorf("invalid user ID format: %w", err)
+		}
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, pending.State, pending.Nonce, pending.CodeVerifier)
+	if err != nil {
+		return "", fmt.Errorf("failed to build authorization URL: %w", err)
+	}
+
+	if err := s.identityRepo.SaveState(ctx, pending); err != nil {
+		return "", err
+	}
+
+	return authURL, nil
+}
+
+// CompleteLogin - Finish the flow, resolving (or creating) the local user and issuing a JWT
+func (s *OIDCService) CompleteLogin(ctx context.Context, providerName, state, code string, meta model.SessionMeta) (*model.User, string, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, "", ErrUnknownProvider
+	}
+
+	if state == "" || code == "" {
+		return nil, "", ErrInvalidOAuthState
+	}
+
+	pending, err := s.identityRepo.ConsumeState(ctx, state)
+	if err != nil {
+		return nil, "", err
+	}
+	if pending == nil || pending.Provider != providerName || time.Now().After(pending.ExpiresAt) {
+		return nil, "", ErrInvalidOAuthState
+	}
+
+	profile, err := provider.Exchange(ctx, code, pending.Nonce, pending.CodeVerifier)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var user *model.User
+	if pending.LinkUserID.Valid {
+		user, err = s.linkToUser(ctx, pending.LinkUserID.String(), profile)
+	} else {
+		user, err = s.resolveUser(ctx, profile)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := s.sessions.IssueToken(ctx, user, meta)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to start session: %w", err)
+	}
+
+	user.Password = ""
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionLoginSucceeded,
+		TargetType: "user",
+		TargetID:   user.ID.String(),
+		ActorID:    user.ID.String(),
+		After:      map[string]string{"provider": providerName},
+	})
+
+	logger.Infof(ctx, "[OIDC-SERVICE] User %s signed in with %s", user.ID.String(), providerName)
+	return user, token, nil
+}
+
+// resolveUser - Find the user for an identity, linking by verified email or creating a new account
+func (s *OIDCService) resolveUser(ctx context.Context, profile *model.ExternalProfile) (*model.User, error) {
+	// 1. Known identity
+	identity, err := s.identityRepo.FindByProviderSubject(ctx, profile.Provider, profile.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		if err := s.identityRepo.TouchLogin(ctx, identity.ID.String(), optionalString(profile.Email)); err != nil {
+			logger.Errorf(ctx, "[OIDC-SERVICE] Failed to record identity login: %v", err)
+		}
+		return s.mustFindUser(ctx, identity.UserID.String())
+	}
+
+	// 2. Existing account with the same email - only trust provider-verified emails
+	if profile.Email != "" {
+		existing, err := s.userRepo.FindByEmail(ctx, profile.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check email: %w", err)
+		}
+		if existing != nil {
+			if !profile.EmailVerified {
+				return nil, ErrEmailNotVerified
+			}
+			if err := s.createIdentity(ctx, existing.ID, profile); err != nil {
+				return nil, err
+			}
+			logger.Infof(ctx, "[OIDC-SERVICE] Linked %s identity to existing account by email", profile.Provider)
+			return s.mustFindUser(ctx, existing.ID.String())
+		}
+	}
+
+	// 3. New account - external sign-up has no invite code, so it only works where none is needed
+	if profile.Email == "" {
+		return nil, errors.New("provider did not return an email address")
+	}
+	// An unverified address could belong to someone else, and the domain allowlist
+	// below would be judging an address the user never proved they own
+	if !profile.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+	if needsInvite, policyErr := s.policy.RequiresInvite(profile.Email); needsInvite {
+		return nil, policyErr
+	}
+
+	user, err := s.createUser(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.createIdentity(ctx, user.ID, profile); err != nil {
+		return nil, err
+	}
+
+	logger.Infof(ctx, "[OIDC-SERVICE] Created new account %s from %s identity", user.ID.String(), profile.Provider)
+	return user, nil
+}
+
+// linkToUser - Attach an identity to an already signed-in user
+func (s *OIDCService) linkToUser(ctx context.Context, userID string, profile *model.ExternalProfile) (*model.User, error) {
+	identity, err := s.identityRepo.FindByProviderSubject(ctx, profile.Provider, profile.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if identity != nil {
+		if identity.UserID.String() != userID {
+			return nil, ErrIdentityAlreadyLinked
+		}
+		return s.mustFindUser(ctx, userID)
+	}
+
+	var userUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	if err := s.createIdentity(ctx, userUUID, profile); err != nil {
+		return nil, err
+	}
+
+	return s.mustFindUser(ctx, userID)
+}
+
+// ListIdentities - Identities linked to a user
+func (s *OIDCService) ListIdentities(ctx context.Context, userID string) ([]model.IdentityResponse, error) {
+	identities, err := s.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.IdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		responses = append(responses, model.IdentityResponse{
+			ID:          identity.ID.String(),
+			Provider:    identity.Provider,
+			Email:       identity.Email,
+			LastLoginAt: identity.LastLoginAt,
+			CreatedAt:   identity.CreatedAt,
+		})
+	}
+
+	return responses, nil
+}
+
+// UnlinkIdentity - Remove a linked identity, keeping at least one for accounts without a password
+func (s *OIDCService) UnlinkIdentity(ctx context.Context, userID, identityID string) error {
+	identities, err := s.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, identity := range identities {
+		if identity.ID.String() == identityID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrIdentityNotFound
+	}
+
+	// Accounts created through OIDC have no password, so the last identity is their only way in
+	if len(identities) == 1 {
+		user, err := s.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user == nil || !user.PasswordSet {
+			return ErrLastIdentity
+		}
+	}
+
+	if err := s.identityRepo.Delete(ctx, identityID, userID); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, AuditEvent{Action: AuditActionIdentityUnlink, TargetType: "identity", TargetID: identityID})
+	return nil
+}
+
+func (s *OIDCService) createIdentity(ctx context.Context, userID pgtype.UUID, profile *model.ExternalProfile) error {
+	identity := &model.UserIdentity{
+		UserID:   userID,
+		Provider: profile.Provider,
+		Subject:  profile.Subject,
+		Email:    optionalString(profile.Email),
+	}
+	if err := s.identityRepo.Create(ctx, identity); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionIdentityLink,
+		TargetType: "identity",
+		TargetID:   identity.ID.String(),
+		ActorID:    userID.String(),
+		After:      map[string]string{"provider": identity.Provider, "subject": identity.Subject},
+	})
+	return nil
+}
+
+func (s *OIDCService) createUser(ctx context.Context, profile *model.ExternalProfile) (*model.User, error) {
+	username, err := s.availableUsername(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(profile.Name)
+	if name == "" {
+		name = username
+	}
+
+	// No password: the account has none that works, and sign-in happens through the identity
+	user := &model.User{
+		Name:     name,
+		Username: username,
+		Email:    profile.Email,
+		Role:     "user",
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
+var usernameSanitizer = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// availableUsername - Derive a unique username from the profile
+func (s *OIDCService) availableUsername(ctx context.Context, profile *model.ExternalProfile) (string, error) {
+	base := profile.PreferredUsername
+	if base == "" {
+		base = strings.SplitN(profile.Email, "@", 2)[0]
+	}
+
+	base = usernameSanitizer.ReplaceAllString(strings.ToLower(base), "_")
+	base = strings.Trim(base, "_")
+	if len(base) < 3 {
+		base = "user_" + base
+	}
+	if len(base) > 40 {
+		base = base[:40]
+	}
+
+	candidate := base
+	for i := 0; i < 5; i++ {
+		existing, err := s.userRepo.FindByUsername(ctx, candidate)
+		if err != nil {
+			return "", fmt.Errorf("failed to check username: %w", err)
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+
+		suffix, err := randomToken(3)
+		if err != nil {
+			return "", err
+		}
+		candidate = base + "_" + strings.ToLower(usernameSanitizer.ReplaceAllString(suffix, ""))
+	}
+
+	return "", ErrUsernameTaken
+}
+
+func (s *OIDCService) mustFindUser(ctx context.Context, userID string) (*model.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("linked user no longer exists")
+	}
+	return user, nil
+}
+
+// randomToken - URL-safe random string from n random bytes
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,562 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	mockProvider = "mock"
+	mockClientID = "quillhub-test"
+)
+
+// mockIssuer - An OIDC provider serving discovery, JWKS and a token endpoint that checks
+// the PKCE verifier and answers with an RS256-signed ID token
+type mockIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]mockGrant
+}
+
+type mockGrant struct {
+	challenge string
+	nonce     string
+	claims    map[string]any
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	m := &mockIssuer{t: t, key: key, codes: make(map[string]mockGrant)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"issuer":                                m.server.URL,
+			"authorization_endpoint":                m.server.URL + "/authorize",
+			"token_endpoint":                        m.server.URL + "/token",
+			"jwks_uri":                              m.server.URL + "/jwks",
+			"response_types_supported":              []string{"code"},
+			"subject_types_supported":               []string{"public"},
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "test",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", m.token)
+
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+// authorize - Stands in for the user approving the request at authURL; returns the code
+// the provider would redirect back with
+func (m *mockIssuer) authorize(authURL string, claims map[string]any) string {
+	m.t.Helper()
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		m.t.Fatalf("parse auth URL: %v", err)
+	}
+	query := parsed.Query()
+	if got := query.Get("code_challenge_method"); got != "S256" {
+		m.t.Fatalf("code_challenge_method = %q, want S256", got)
+	}
+	if query.Get("state") == "" || query.Get("nonce") == "" || query.Get("code_challenge") == "" {
+		m.t.Fatalf("auth URL is missing state, nonce or code_challenge: %s", authURL)
+	}
+
+	code := "code-" + query.Get("state")
+	m.mu.Lock()
+	m.codes[code] = mockGrant{challenge: query.Get("code_challenge"), nonce: query.Get("nonce"), claims: claims}
+	m.mu.Unlock()
+	return code
+}
+
+func (m *mockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	m.mu.Lock()
+	grant, ok := m.codes[r.PostForm.Get("code")]
+	delete(m.codes, r.PostForm.Get("code"))
+	m.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != grant.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := map[string]any{
+		"iss":   m.server.URL,
+		"aud":   mockClientID,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": grant.nonce,
+	}
+	for k, v := range grant.claims {
+		claims[k] = v
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     m.sign(claims),
+	})
+}
+
+func (m *mockIssuer) sign(claims map[string]any) string {
+	m.t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": "test"})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		m.t.Fatalf("marshal claims: %v", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, m.key, crypto.SHA256, digest[:])
+	if err != nil {
+		m.t.Fatalf("sign id_token: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// In-memory repositories
+
+func newUUID() pgtype.UUID {
+	var id pgtype.UUID
+	_, _ = rand.Read(id.Bytes[:])
+	id.Valid = true
+	return id
+}
+
+type memoryUserRepo struct {
+	users map[string]*model.User
+}
+
+func newMemoryUserRepo(users ...*model.User) *memoryUserRepo {
+	r := &memoryUserRepo{users: make(map[string]*model.User)}
+	for _, u := range users {
+		r.users[u.ID.String()] = u
+	}
+	return r
+}
+
+func (r *memoryUserRepo) Create(_ context.Context, user *model.User) error {
+	user.ID = newUUID()
+	user.PasswordSet = user.Password != ""
+	r.users[user.ID.String()] = user
+	return nil
+}
+
+func (r *memoryUserRepo) FindByID(_ context.Context, userID string) (*model.User, error) {
+	return r.users[userID], nil
+}
+
+func (r *memoryUserRepo) FindByEmail(_ context.Context, email string) (*model.User, error) {
+	for _, u := range r.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryUserRepo) FindByUsername(_ context.Context, username string) (*model.User, error) {
+	for _, u := range r.users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryUserRepo) UpdateRole(_ context.Context, userID, role string) error {
+	r.users[userID].Role = role
+	return nil
+}
+
+type memoryIdentityRepo struct {
+	identities []*model.UserIdentity
+	states     map[string]*model.OAuthState
+}
+
+func newMemoryIdentityRepo() *memoryIdentityRepo {
+	return &memoryIdentityRepo{states: make(map[string]*model.OAuthState)}
+}
+
+func (r *memoryIdentityRepo) Create(_ context.Context, identity *model.UserIdentity) error {
+	for _, existing := range r.identities {
+		if existing.Provider == identity.Provider && existing.Subject == identity.Subject {
+			return ErrIdentityAlreadyLinked
+		}
+	}
+	identity.ID = newUUID()
+	r.identities = append(r.identities, identity)
+	return nil
+}
+
+func (r *memoryIdentityRepo) FindByProviderSubject(_ context.Context, provider, subject string) (*model.UserIdentity, error) {
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryIdentityRepo) FindByUserID(_ context.Context, userID string) ([]*model.UserIdentity, error) {
+	var found []*model.UserIdentity
+	for _, identity := range r.identities {
+		if identity.UserID.String() == userID {
+			found = append(found, identity)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryIdentityRepo) TouchLogin(context.Context, string, *string) error { return nil }
+
+func (r *memoryIdentityRepo) Delete(_ context.Context, identityID, userID string) error {
+	for i, identity := range r.identities {
+		if identity.ID.String() == identityID && identity.UserID.String() == userID {
+			r.identities = append(r.identities[:i], r.identities[i+1:]...)
+			return nil
+		}
+	}
+	return ErrIdentityNotFound
+}
+
+func (r *memoryIdentityRepo) SaveState(_ context.Context, state *model.OAuthState) error {
+	r.states[state.State] = state
+	return nil
+}
+
+func (r *memoryIdentityRepo) ConsumeState(_ context.Context, state string) (*model.OAuthState, error) {
+	pending := r.states[state]
+	delete(r.states, state)
+	return pending, nil
+}
+
+func (r *memoryIdentityRepo) DeleteExpiredStates(context.Context) (int64, error) { return 0, nil }
+
+type memorySessionRepo struct {
+	sessions map[string]*model.Session
+}
+
+func (r *memorySessionRepo) Create(_ context.Context, session *model.Session) error {
+	session.ID = newUUID()
+	r.sessions[session.ID.String()] = session
+	return nil
+}
+
+func (r *memorySessionRepo) FindByID(_ context.Context, sessionID string) (*model.Session, error) {
+	return r.sessions[sessionID], nil
+}
+
+func (r *memorySessionRepo) FindActiveByUserID(context.Context, string) ([]*model.Session, error) {
+	return nil, nil
+}
+
+func (r *memorySessionRepo) Touch(context.Context, string) error { return nil }
+
+func (r *memorySessionRepo) Revoke(context.Context, string, string) error { return nil }
+
+func (r *memorySessionRepo) RevokeAllByUserID(_ context.Context, userID string) (int64, error) {
+	now := time.Now()
+	var revoked int64
+	for _, session := range r.sessions {
+		if session.UserID.String() == userID && session.RevokedAt == nil {
+			session.RevokedAt = &now
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+type oidcFixture struct {
+	issuer     *mockIssuer
+	users      *memoryUserRepo
+	identities *memoryIdentityRepo
+	service    *OIDCService
+}
+
+func newOIDCFixture(t *testing.T, policy RegistrationPolicy, users ...*model.User) *oidcFixture {
+	t.Helper()
+
+	issuer := newMockIssuer(t)
+	f := &oidcFixture{
+		issuer:     issuer,
+		users:      newMemoryUserRepo(users...),
+		identities: newMemoryIdentityRepo(),
+	}
+
+	sessions := NewSessionService(&memorySessionRepo{sessions: make(map[string]*model.Session)}, nil,
+		utils.NewTokenSigner("test-secret-that-is-long-enough-for-hs256", time.Hour))
+	provider := NewGenericOIDCProvider(OIDCProviderConfig{
+		Name:        mockProvider,
+		DisplayName: "Mock",
+		IssuerURL:   issuer.server.URL,
+		ClientID:    mockClientID,
+		RedirectURL: "http://localhost:8080/api/auth/oidc/mock/callback",
+		Scopes:      []string{"openid", "email", "profile"},
+	})
+	f.service = NewOIDCService(f.users, f.identities, sessions, nil, policy, provider)
+	return f
+}
+
+// login - Runs the whole flow for a provider account with the given claims
+func (f *oidcFixture) login(t *testing.T, claims map[string]any) (*model.User, string, error) {
+	t.Helper()
+
+	ctx := context.Background()
+	authURL, err := f.service.BeginLogin(ctx, mockProvider, "")
+	if err != nil {
+		t.Fatalf("BeginLogin: %v", err)
+	}
+	code := f.issuer.authorize(authURL, claims)
+	state, _ := url.Parse(authURL)
+	return f.service.CompleteLogin(ctx, mockProvider, state.Query().Get("state"), code, model.SessionMeta{})
+}
+
+func openPolicy() RegistrationPolicy {
+	return RegistrationPolicy{Mode: RegistrationModeOpen}
+}
+
+func TestOIDCLoginCreatesAccount(t *testing.T) {
+	f := newOIDCFixture(t, openPolicy())
+
+	user, token, err := f.login(t, map[string]any{
+		"sub":            "subject-1",
+		"email":          "Ada@Example.com",
+		"email_verified": true,
+		"name":           "Ada Lovelace",
+	})
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if token == "" {
+		t.Error("no token issued")
+	}
+	if user.Email != "ada@example.com" || user.Name != "Ada Lovelace" {
+		t.Errorf("user = %q <%s>, want Ada Lovelace <ada@example.com>", user.Name, user.Email)
+	}
+	if len(f.identities.identities) != 1 || f.identities.identities[0].UserID != user.ID {
+		t.Errorf("identity was not linked to the new account: %+v", f.identities.identities)
+	}
+
+	// Signing in again finds the account through the identity
+	again, _, err := f.login(t, map[string]any{"sub": "subject-1", "email": "ada@example.com", "email_verified": true})
+	if err != nil {
+		t.Fatalf("second CompleteLogin: %v", err)
+	}
+	if again.ID != user.ID || len(f.users.users) != 1 {
+		t.Errorf("second sign-in created another account")
+	}
+}
+
+func TestOIDCLoginLinksExistingAccount(t *testing.T) {
+	existing := &model.User{ID: newUUID(), Name: "Grace", Username: "grace", Email: "grace@example.com", Role: "user"}
+	f := newOIDCFixture(t, openPolicy(), existing)
+
+	user, _, err := f.login(t, map[string]any{"sub": "subject-2", "email": "grace@example.com", "email_verified": true})
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if user.ID != existing.ID {
+		t.Errorf("signed in as %s, want the existing account %s", user.ID.String(), existing.ID.String())
+	}
+	if len(f.users.users) != 1 {
+		t.Errorf("%d accounts, want the existing one only", len(f.users.users))
+	}
+	identities, _ := f.identities.FindByUserID(context.Background(), existing.ID.String())
+	if len(identities) != 1 || identities[0].Subject != "subject-2" {
+		t.Errorf("identity not linked to the existing account: %+v", identities)
+	}
+}
+
+func TestOIDCUnlinkIdentity(t *testing.T) {
+	ctx := context.Background()
+	withPassword := &model.User{ID: newUUID(), Name: "Grace", Username: "grace", Email: "grace@example.com", Role: "user", PasswordSet: true}
+	f := newOIDCFixture(t, openPolicy(), withPassword)
+
+	created, _, err := f.login(t, map[string]any{"sub": "subject-1", "email": "ada@example.com", "email_verified": true})
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if created.PasswordSet {
+		t.Error("an account created through the provider has a password")
+	}
+	if _, _, err := f.login(t, map[string]any{"sub": "subject-2", "email": "grace@example.com", "email_verified": true}); err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+
+	identityOf := func(user *model.User) string {
+		t.Helper()
+		identities, _ := f.identities.FindByUserID(ctx, user.ID.String())
+		if len(identities) != 1 {
+			t.Fatalf("%s has %d identities, want 1", user.Email, len(identities))
+		}
+		return identities[0].ID.String()
+	}
+
+	if err := f.service.UnlinkIdentity(ctx, created.ID.String(), identityOf(created)); !errors.Is(err, ErrLastIdentity) {
+		t.Errorf("unlinking the only sign-in method = %v, want %v", err, ErrLastIdentity)
+	}
+	if err := f.service.UnlinkIdentity(ctx, withPassword.ID.String(), identityOf(withPassword)); err != nil {
+		t.Errorf("password account unlinking its only identity: %v", err)
+	}
+	if err := f.service.UnlinkIdentity(ctx, withPassword.ID.String(), identityOf(created)); !errors.Is(err, ErrIdentityNotFound) {
+		t.Errorf("unlinking another user's identity = %v, want %v", err, ErrIdentityNotFound)
+	}
+}
+
+func TestOIDCLoginRejectsUnverifiedEmail(t *testing.T) {
+	existing := &model.User{ID: newUUID(), Name: "Grace", Username: "grace", Email: "grace@example.com", Role: "user"}
+
+	tests := []struct {
+		name   string
+		policy RegistrationPolicy
+		email  string
+	}{
+		{"existing account", openPolicy(), "grace@example.com"},
+		{"new account", openPolicy(), "new@example.com"},
+		{"allowed domain", RegistrationPolicy{Mode: RegistrationModeDomainRestricted, AllowedDomains: []string{"corp.example"}}, "someone@corp.example"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newOIDCFixture(t, tt.policy, existing)
+
+			_, _, err := f.login(t, map[string]any{"sub": "subject-3", "email": tt.email, "email_verified": false})
+			if !errors.Is(err, ErrEmailNotVerified) {
+				t.Fatalf("err = %v, want ErrEmailNotVerified", err)
+			}
+			if len(f.users.users) != 1 || len(f.identities.identities) != 0 {
+				t.Errorf("an account or identity was created for an unverified email")
+			}
+		})
+	}
+}
+
+func TestOIDCCompleteLoginChecksState(t *testing.T) {
+	ctx := context.Background()
+	claims := map[string]any{"sub": "subject-4", "email": "state@example.com", "email_verified": true}
+
+	t.Run("unknown state", func(t *testing.T) {
+		f := newOIDCFixture(t, openPolicy())
+		_, _, err := f.service.CompleteLogin(ctx, mockProvider, "made-up", "code", model.SessionMeta{})
+		if !errors.Is(err, ErrInvalidOAuthState) {
+			t.Errorf("err = %v, want ErrInvalidOAuthState", err)
+		}
+	})
+
+	t.Run("state used twice", func(t *testing.T) {
+		f := newOIDCFixture(t, openPolicy())
+		authURL, _ := f.service.BeginLogin(ctx, mockProvider, "")
+		code := f.issuer.authorize(authURL, claims)
+		parsed, _ := url.Parse(authURL)
+		state := parsed.Query().Get("state")
+
+		if _, _, err := f.service.CompleteLogin(ctx, mockProvider, state, code, model.SessionMeta{}); err != nil {
+			t.Fatalf("first CompleteLogin: %v", err)
+		}
+		if _, _, err := f.service.CompleteLogin(ctx, mockProvider, state, code, model.SessionMeta{}); !errors.Is(err, ErrInvalidOAuthState) {
+			t.Errorf("replayed state: err = %v, want ErrInvalidOAuthState", err)
+		}
+	})
+
+	t.Run("expired state", func(t *testing.T) {
+		f := newOIDCFixture(t, openPolicy())
+		authURL, _ := f.service.BeginLogin(ctx, mockProvider, "")
+		code := f.issuer.authorize(authURL, claims)
+		parsed, _ := url.Parse(authURL)
+		state := parsed.Query().Get("state")
+		f.identities.states[state].ExpiresAt = time.Now().Add(-time.Second)
+
+		if _, _, err := f.service.CompleteLogin(ctx, mockProvider, state, code, model.SessionMeta{}); !errors.Is(err, ErrInvalidOAuthState) {
+			t.Errorf("err = %v, want ErrInvalidOAuthState", err)
+		}
+	})
+
+	t.Run("state from another provider", func(t *testing.T) {
+		f := newOIDCFixture(t, openPolicy())
+		authURL, _ := f.service.BeginLogin(ctx, mockProvider, "")
+		code := f.issuer.authorize(authURL, claims)
+		parsed, _ := url.Parse(authURL)
+		state := parsed.Query().Get("state")
+		f.identities.states[state].Provider = "other"
+
+		if _, _, err := f.service.CompleteLogin(ctx, mockProvider, state, code, model.SessionMeta{}); !errors.Is(err, ErrInvalidOAuthState) {
+			t.Errorf("err = %v, want ErrInvalidOAuthState", err)
+		}
+	})
+}
+
+func TestOIDCCompleteLoginChecksPKCE(t *testing.T) {
+	ctx := context.Background()
+	f := newOIDCFixture(t, openPolicy())
+
+	authURL, err := f.service.BeginLogin(ctx, mockProvider, "")
+	if err != nil {
+		t.Fatalf("BeginLogin: %v", err)
+	}
+	code := f.issuer.authorize(authURL, map[string]any{"sub": "subject-5", "email": "pkce@example.com", "email_verified": true})
+	parsed, _ := url.Parse(authURL)
+	state := parsed.Query().Get("state")
+
+	// A stolen code is useless without the verifier that only the server holds
+	f.identities.states[state].CodeVerifier = "not-the-verifier-that-made-the-challenge-0000"
+
+	if _, _, err := f.service.CompleteLogin(ctx, mockProvider, state, code, model.SessionMeta{}); err == nil {
+		t.Fatal("code redeemed with the wrong PKCE verifier")
+	}
+	if len(f.users.users) != 0 {
+		t.Error("an account was created after a failed exchange")
+	}
+}
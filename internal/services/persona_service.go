@@ -0,0 +1,429 @@
+// internal/services/persona_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// defaultPersonaName - The persona of the original QuillHub AI bot user
+const defaultPersonaName = "QuillHub AI"
+
+var (
+	ErrPersonaNotFound      = errors.New("persona not found")
+	ErrPersonaExists        = errors.New("a persona with this name already exists")
+	ErrPersonaUsernameTaken = errors.New("username is already taken")
+	ErrInvalidPersona       = errors.New("invalid persona")
+	ErrDefaultPersonaDelete = errors.New("the default persona cannot be deleted")
+)
+
+var personaUsernamePattern = regexp.MustCompile(`^[a-z0-9_]{3,50}$`)
+
+type PersonaRepo interface {
+	FindByID(ctx context.Context, personaID string) (*model.BotPersona, error)
+	FindByName(ctx context.Context, name string) (*model.BotPersona, error)
+	FindByUserID(ctx context.Context, userID string) (*model.BotPersona, error)
+	FindDefault(ctx context.Context) (*model.BotPersona, error)
+	List(ctx context.Context, enabledOnly bool) ([]*model.BotPersona, error)
+	Create(ctx context.Context, persona *model.BotPersona) error
+	Update(ctx context.Context, persona *model.BotPersona) error
+	Delete(ctx context.Context, personaID string) error
+}
+
+// PersonaUserRepo - Bot user accounts for personas
+type PersonaUserRepo interface {
+	Create(ctx context.Context, user *model.User) error
+	FindByUsername(ctx context.Context, username string) (*model.User, error)
+}
+
+type PersonaService struct {
+	repo    PersonaRepo
+	users   PersonaUserRepo
+	audit   *AuditService
+	changed chan struct{}
+}
+
+func NewPersonaService(repo PersonaRepo, users PersonaUserRepo, audit *AuditService) *PersonaService {
+	return &PersonaService{
+		repo:    repo,
+		users:   users,
+		audit:   audit,
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// Changes - Signalled after personas are created, updated or deleted, so schedules can be reloaded
+func (s *PersonaService) Changes() <-chan struct{} {
+	return s.changed
+}
+
+func (s *PersonaService) notify() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// EnsureDefault - Create the default persona for the existing bot user on first start
+func (s *PersonaService) EnsureDefault(ctx context.Context, botUserID string) (*model.BotPersona, error) {
+	persona, err := s.repo.FindDefault(ctx)
+	if err != nil || persona != nil {
+		return persona, err
+	}
+
+	persona = &model.BotPersona{Name: defaultPersonaName, Enabled: true, IsDefault: true}
+	if err := persona.UserID.Scan(botUserID); err != nil {
+		return nil, fmt.Errorf("invalid bot user ID: %w", err)
+	}
+	if err := s.repo.Create(ctx, persona); err != nil {
+		return nil, err
+	}
+
+	logger.Infof(ctx, "[PERSONA-SERVICE] ✅ Created default persona %q", persona.Name)
+	return s.repo.FindByID(ctx, persona.ID.String())
+}
+
+// Default - The default persona, used when no persona is named
+func (s *PersonaService) Default(ctx context.Context) (*model.BotPersona, error) {
+	persona, err := s.repo.FindDefault(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if persona == nil {
+		return nil, ErrPersonaNotFound
+	}
+	return persona, nil
+}
+
+// Get - One persona for the auto-poster
+func (s *PersonaService) Get(ctx context.Context, personaID string) (*model.BotPersona, error) {
+	return s.findPersona(ctx, personaID)
+}
+
+// ForUser - The persona writing as a user; nil if the user is not a persona
+func (s *PersonaService) ForUser(ctx context.Context, userID string) (*model.BotPersona, error) {
+	return s.repo.FindByUserID(ctx, userID)
+}
+
+// ListEnabled - Personas the auto-poster should schedule
+func (s *PersonaService) ListEnabled(ctx context.Context) ([]*model.BotPersona, error) {
+	return s.repo.List(ctx, true)
+}
+
+// ListPersonas - All personas, default first
+func (s *PersonaService) ListPersonas(ctx context.Context) ([]model.PersonaResponse, error) {
+	personas, err := s.repo.List(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.PersonaResponse, 0, len(personas))
+	for _, persona := range personas {
+		responses = append(responses, toPersonaResponse(persona))
+	}
+	return responses, nil
+}
+
+// GetPersona - One persona
+func (s *PersonaService) GetPersona(ctx context.Context, personaID string) (*model.PersonaResponse, error) {
+	persona, err := s.findPersona(ctx, personaID)
+	if err != nil {
+		return nil, err
+	}
+	response := toPersonaResponse(persona)
+	return &response, nil
+}
+
+// CreatePersona - Add a persona together with its bot user account
+func (s *PersonaService) CreatePersona(ctx context.Context, req *model.CreatePersonaRequest) (*model.PersonaResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	username := strings.ToLower(strings.TrimSpace(req.Username))
+	if name == "" {
+		return nil, fmt.Errorf("%w: name must not be empty", ErrInvalidPersona)
+	}
+	if !personaUsernamePattern.MatchString(username) {
+		return nil, fmt.Errorf("%w: username must be 3-50 lowercase letters, digits or underscores", ErrInvalidPersona)
+	}
+
+	existing, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrPersonaExists
+	}
+	taken, err := s.users.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if taken != nil {
+		return nil, ErrPersonaUsernameTaken
+	}
+
+	persona := &model.BotPersona{
+		Name:             name,
+		Description:      optionalTrimmed(req.Description),
+		PromptTemplate:   optionalTrimmed(req.PromptTemplate),
+		Tone:             optionalTrimmed(req.Tone),
+		Model:            optionalTrimmed(req.Model),
+		Temperature:      req.Temperature,
+		MaxTokens:        req.MaxTokens,
+		MinWords:         req.MinWords,
+		MaxWords:         req.MaxWords,
+		ScheduleInterval: optionalTrimmed(req.ScheduleInterval),
+		ScheduleCron:     optionalTrimmed(req.ScheduleCron),
+		Enabled:          true,
+	}
+	if req.Enabled != nil {
+		persona.Enabled = *req.Enabled
+	}
+	if err := validatePersona(persona); err != nil {
+		return nil, err
+	}
+
+	// Bot accounts never log in; the password only satisfies the column
+	password, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	user := &model.User{
+		Name:     name,
+		Username: username,
+		Email:    username + "@bots.quillhub.com",
+		Password: password,
+		Role:     "bot",
+	}
+	if err := s.users.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	persona.UserID = user.ID
+	persona.Username = user.Username
+
+	if err := s.repo.Create(ctx, persona); err != nil {
+		return nil, err
+	}
+	s.notify()
+
+	response := toPersonaResponse(persona)
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAIPersonaCreate,
+		TargetType: "ai_persona",
+		TargetID:   response.ID,
+		After:      response,
+	})
+	logger.Infof(ctx, "[PERSONA-SERVICE] ✅ Created persona %q (@%s)", persona.Name, persona.Username)
+	return &response, nil
+}
+
+// UpdatePersona - Change a persona's prompt, model settings, schedule or state
+func (s *PersonaService) UpdatePersona(ctx context.Context, personaID string, req *model.UpdatePersonaRequest) (*model.PersonaResponse, error) {
+	persona, err := s.findPersona(ctx, personaID)
+	if err != nil {
+		return nil, err
+	}
+	before := toPersonaResponse(persona)
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name must not be empty", ErrInvalidPersona)
+		}
+		if !strings.EqualFold(name, persona.Name) {
+			existing, err := s.repo.FindByName(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				return nil, ErrPersonaExists
+			}
+		}
+		persona.Name = name
+	}
+	if req.Description != nil {
+		persona.Description = optionalTrimmed(req.Description)
+	}
+	if req.PromptTemplate != nil {
+		persona.PromptTemplate = optionalTrimmed(req.PromptTemplate)
+	}
+	if req.Tone != nil {
+		persona.Tone = optionalTrimmed(req.Tone)
+	}
+	if req.Model != nil {
+		persona.Model = optionalTrimmed(req.Model)
+	}
+	if req.Temperature != nil {
+		persona.Temperature = req.Temperature
+	}
+	if req.MaxTokens != nil {
+		persona.MaxTokens = optionalPositive(*req.MaxTokens)
+	}
+	if req.MinWords != nil {
+		persona.MinWords = optionalPositive(*req.MinWords)
+	}
+	if req.MaxWords != nil {
+		persona.MaxWords = optionalPositive(*req.MaxWords)
+	}
+	// Setting one schedule kind replaces the other
+	if req.ScheduleInterval != nil {
+		persona.ScheduleInterval = optionalTrimmed(req.ScheduleInterval)
+		if persona.ScheduleInterval != nil && req.ScheduleCron == nil {
+			persona.ScheduleCron = nil
+		}
+	}
+	if req.ScheduleCron != nil {
+		persona.ScheduleCron = optionalTrimmed(req.ScheduleCron)
+		if persona.ScheduleCron != nil && req.ScheduleInterval == nil {
+			persona.ScheduleInterval = nil
+		}
+	}
+	if req.Enabled != nil {
+		persona.Enabled = *req.Enabled
+	}
+
+	if err := validatePersona(persona); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, persona); err != nil {
+		return nil, err
+	}
+	s.notify()
+
+	response := toPersonaResponse(persona)
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAIPersonaUpdate,
+		TargetType: "ai_persona",
+		TargetID:   response.ID,
+		Before:     before,
+		After:      response,
+	})
+	return &response, nil
+}
+
+// DeletePersona - Remove a persona; its bot user and posts are kept
+func (s *PersonaService) DeletePersona(ctx context.Context, personaID string) error {
+	persona, err := s.findPersona(ctx, personaID)
+	if err != nil {
+		return err
+	}
+	if persona.IsDefault {
+		return ErrDefaultPersonaDelete
+	}
+
+	if err := s.repo.Delete(ctx, personaID); err != nil {
+		return err
+	}
+	s.notify()
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAIPersonaDelete,
+		TargetType: "ai_persona",
+		TargetID:   personaID,
+		Before:     toPersonaResponse(persona),
+	})
+	return nil
+}
+
+// PreviewPrompt - The prompt a persona would send for a topic, without calling the model
+func (s *PersonaService) PreviewPrompt(ctx context.Context, personaID string, req *model.PreviewPersonaPromptRequest) (string, error) {
+	persona, err := s.findPersona(ctx, personaID)
+	if err != nil {
+		return "", err
+	}
+
+	spec := &model.AIPostRequest{
+		Topic:    strings.TrimSpace(req.Topic),
+		Category: strings.TrimSpace(req.Category),
+	}
+	if persona.MinWords != nil {
+		spec.MinLength = *persona.MinWords
+	}
+	if persona.MaxWords != nil {
+		spec.MaxLength = *persona.MaxWords
+	}
+	if persona.Tone != nil {
+		spec.Tone = *persona.Tone
+	}
+	return PersonaPrompt(persona, spec)
+}
+
+func (s *PersonaService) findPersona(ctx context.Context, personaID string) (*model.BotPersona, error) {
+	var personaUUID pgtype.UUID
+	if err := personaUUID.Scan(personaID); err != nil {
+		return nil, ErrPersonaNotFound
+	}
+
+	persona, err := s.repo.FindByID(ctx, personaID)
+	if err != nil {
+		return nil, err
+	}
+	if persona == nil {
+		return nil, ErrPersonaNotFound
+	}
+	return persona, nil
+}
+
+// validatePersona - Template, schedule and length settings must be usable before they are saved
+func validatePersona(persona *model.BotPersona) error {
+	if persona.PromptTemplate != nil {
+		if _, err := ParsePromptTemplate(*persona.PromptTemplate); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPersona, err)
+		}
+	}
+
+	if persona.ScheduleInterval != nil || persona.ScheduleCron != nil {
+		if _, err := parsePosterSchedule(derefString(persona.ScheduleInterval), derefString(persona.ScheduleCron)); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPersona, err)
+		}
+	}
+
+	if persona.MinWords != nil && persona.MaxWords != nil && *persona.MaxWords < *persona.MinWords {
+		return fmt.Errorf("%w: max_words must not be below min_words", ErrInvalidPersona)
+	}
+	return nil
+}
+
+func toPersonaResponse(persona *model.BotPersona) model.PersonaResponse {
+	return model.PersonaResponse{
+		ID:               persona.ID.String(),
+		Name:             persona.Name,
+		Description:      persona.Description,
+		UserID:           persona.UserID.String(),
+		Username:         persona.Username,
+		PromptTemplate:   persona.PromptTemplate,
+		Tone:             persona.Tone,
+		Model:            persona.Model,
+		Temperature:      persona.Temperature,
+		MaxTokens:        persona.MaxTokens,
+		MinWords:         persona.MinWords,
+		MaxWords:         persona.MaxWords,
+		ScheduleInterval: persona.ScheduleInterval,
+		ScheduleCron:     persona.ScheduleCron,
+		Enabled:          persona.Enabled,
+		IsDefault:        persona.IsDefault,
+		CreatedAt:        persona.CreatedAt,
+		UpdatedAt:        persona.UpdatedAt,
+	}
+}
+
+// optionalTrimmed - nil for a missing or blank value
+func optionalTrimmed(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	return optionalString(strings.TrimSpace(*value))
+}
+
+// optionalPositive - nil for zero, which clears a setting
+func optionalPositive(value int) *int {
+	if value <= 0 {
+		return nil
+	}
+	return &value
+}
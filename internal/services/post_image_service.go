@@ -0,0 +1,522 @@
+// internal/services/post_image_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Where the alt text or caption shown to readers came from
+const (
+	ImageTextSourceAI     = "ai"
+	ImageTextSourceAuthor = "author"
+)
+
+// States of an image's AI description
+const (
+	ImageStatusPending = "pending"
+	ImageStatusReady   = "ready"
+	ImageStatusFailed  = "failed"
+)
+
+const (
+	imageBatchSize       = 8
+	imageInterval        = 5 * time.Minute
+	imageCallTimeout     = time.Minute
+	imageFetchTimeout    = 30 * time.Second
+	imageRetryAfter      = 30 * time.Minute
+	maxImageAttempts     = 3
+	maxImageBytes        = 10 << 20
+	maxAltTextLen        = 250
+	maxCaptionLen        = 500
+	imageResizeTransform = "c_limit,w_1024,h_1024,f_jpg" // Cloudinary: small JPEGs are cheap to send and every model reads them
+)
+
+var (
+	ErrImageNotFound    = errors.New("image not found")
+	ErrInvalidImageText = errors.New("invalid image text")
+)
+
+type PostImageRepo interface {
+	SyncPost(ctx context.Context, postID string, urls []string) error
+	SyncAll(ctx context.Context) (int64, error)
+	ListPending(ctx context.Context, maxAttempts int, retryAfter time.Duration, limit int) ([]*model.ImageDescriptionSource, error)
+	SaveDescription(ctx context.Context, imageID, altText, caption, modelName string) error
+	MarkFailed(ctx context.Context, imageID, reason string) error
+	Requeue(ctx context.Context, imageID string) error
+	FindByID(ctx context.Context, imageID string) (*model.PostImage, error)
+	ListByPosts(ctx context.Context, postIDs []string) ([]*model.PostImage, error)
+	UpdateAuthorText(ctx context.Context, image *model.PostImage) error
+}
+
+type PostImagePostRepo interface {
+	FindByID(ctx context.Context, postID string) (*model.Post, error)
+}
+
+// PostImageConfig - Whether and with which model images are described
+type PostImageConfig struct {
+	Enabled bool
+	Model   string // a model that accepts images; empty uses LLM_MODEL
+}
+
+// LoadPostImageConfig - The image description settings from the app config
+func LoadPostImageConfig(app config.ImageConfig) PostImageConfig {
+	return PostImageConfig{
+		Enabled: app.Enabled,
+		Model:   strings.TrimSpace(app.Model),
+	}
+}
+
+// PostImageService - Alt text and captions for post images, written by the AI in the background
+// and overridable by the author
+type PostImageService struct {
+	repo       PostImageRepo
+	posts      PostImagePostRepo
+	ai         *AIService
+	cfg        PostImageConfig
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	isRunning bool
+	wake      chan struct{}
+	stopChan  chan struct{}
+}
+
+func NewPostImageService(repo PostImageRepo, posts PostImagePostRepo, ai *AIService, cfg PostImageConfig) *PostImageService {
+	return &PostImageService{
+		repo:       repo,
+		posts:      posts,
+		ai:         ai,
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: imageFetchTimeout},
+		wake:       make(chan struct{}, 1),
+		stopChan:   make(chan struct{}),
+	}
+}
+
+func (s *PostImageService) enabled() bool {
+	return s.cfg.Enabled && s.ai != nil && s.ai.Provider() != nil
+}
+
+// Start - Background describer; runs on start, when notified and every few minutes
+func (s *PostImageService) Start() {
+	if !s.enabled() {
+		logger.Infof(context.Background(), "[POST-IMAGES] Image descriptions are off (IMAGE_DESCRIPTIONS=false or no LLM provider)")
+		return
+	}
+
+	s.mu.Lock()
+	if s.isRunning {
+		s.mu.Unlock()
+		return
+	}
+	s.isRunning = true
+	s.mu.Unlock()
+
+	ticker := time.NewTicker(imageInterval)
+	go func() {
+		s.sweepLogged(true)
+		for {
+			select {
+			case <-s.wake:
+				s.sweepLogged(false)
+			case <-ticker.C:
+				s.sweepLogged(true)
+			case <-s.stopChan:
+				ticker.Stop()
+				s.mu.Lock()
+				s.isRunning = false
+				s.mu.Unlock()
+				logger.Infof(context.Background(), "[POST-IMAGES] ⏹️  Background worker stopped")
+				return
+			}
+		}
+	}()
+
+	logger.Infof(context.Background(), "[POST-IMAGES] ✅ Background worker started")
+}
+
+// Stop - Stop the background worker
+func (s *PostImageService) Stop() {
+	s.mu.Lock()
+	running := s.isRunning
+	s.mu.Unlock()
+
+	if running {
+		s.stopChan <- struct{}{}
+	}
+}
+
+// Notify - Images were uploaded; describe them soon
+func (s *PostImageService) Notify() {
+	if s == nil || !s.enabled() {
+		return
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Track - Keep a post's image rows in step with its image_url after a save
+func (s *PostImageService) Track(ctx context.Context, post *model.Post) error {
+	urls := post.ImageURL
+	if urls == nil {
+		urls = []string{}
+	}
+	if err := s.repo.SyncPost(ctx, post.ID.String(), urls); err != nil {
+		return err
+	}
+	if len(urls) > 0 {
+		s.Notify()
+	}
+	return nil
+}
+
+// Attach - Fill in post.Images from image_url, with alt text and captions where they exist
+func (s *PostImageService) Attach(ctx context.Context, posts []*model.Post) error {
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		if len(post.ImageURL) > 0 {
+			ids = append(ids, post.ID.String())
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := s.repo.ListByPosts(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	byURL := make(map[string]*model.PostImage, len(rows))
+	for _, row := range rows {
+		byURL[row.PostID.String()+" "+row.URL] = row
+	}
+
+	for _, post := range posts {
+		if len(post.ImageURL) == 0 {
+			continue
+		}
+		post.Images = make([]model.PostImageResponse, 0, len(post.ImageURL))
+		for _, url := range post.ImageURL {
+			if row := byURL[post.ID.String()+" "+url]; row != nil {
+				post.Images = append(post.Images, toPostImageResponse(row))
+			} else {
+				// Saved but not picked up by the worker yet
+				post.Images = append(post.Images, model.PostImageResponse{URL: url, Status: ImageStatusPending})
+			}
+		}
+	}
+	return nil
+}
+
+// UpdateImage - The author's alt text and caption; an empty string goes back to the AI text
+func (s *PostImageService) UpdateImage(ctx context.Context, postID, imageID, userID string, req *model.UpdatePostImageRequest) (*model.PostImageResponse, error) {
+	image, err := s.findAuthorImage(ctx, postID, imageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AltText != nil {
+		altText := strings.TrimSpace(*req.AltText)
+		if utf8.RuneCountInString(altText) > maxAltTextLen {
+			return nil, fmt.Errorf("%w: alt text must not exceed %d characters", ErrInvalidImageText, maxAltTextLen)
+		}
+		image.AuthorAltText = optionalString(altText)
+	}
+	if req.Caption != nil {
+		caption := strings.TrimSpace(*req.Caption)
+		if utf8.RuneCountInString(caption) > maxCaptionLen {
+			return nil, fmt.Errorf("%w: caption must not exceed %d characters", ErrInvalidImageText, maxCaptionLen)
+		}
+		image.AuthorCaption = optionalString(caption)
+	}
+
+	if err := s.repo.UpdateAuthorText(ctx, image); err != nil {
+		return nil, err
+	}
+
+	response := toPostImageResponse(image)
+	return &response, nil
+}
+
+// Redescribe - Ask the AI for a fresh description of an image (author only); overrides stay
+func (s *PostImageService) Redescribe(ctx context.Context, postID, imageID, userID string) (*model.PostImageResponse, error) {
+	if !s.enabled() {
+		return nil, ErrNoLLMProvider
+	}
+
+	image, err := s.findAuthorImage(ctx, postID, imageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Requeue(ctx, imageID); err != nil {
+		return nil, err
+	}
+	s.Notify()
+
+	image.Status = ImageStatusPending
+	image.Error = nil
+	response := toPostImageResponse(image)
+	return &response, nil
+}
+
+func (s *PostImageService) sweepLogged(syncAll bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	if syncAll {
+		if added, err := s.repo.SyncAll(ctx); err != nil {
+			logger.Warnf(ctx, "[POST-IMAGES] ⚠️  %v", err)
+		} else if added > 0 {
+			logger.Infof(ctx, "[POST-IMAGES] Found %d image(s) without descriptions", added)
+		}
+	}
+
+	count, err := s.DescribePending(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "[POST-IMAGES] ❌ Stopped after %d image(s): %v", count, err)
+		return
+	}
+	if count > 0 {
+		logger.Infof(ctx, "[POST-IMAGES] Described %d image(s)", count)
+	}
+}
+
+// DescribePending - Describe every image waiting for text; returns how many were described
+func (s *PostImageService) DescribePending(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		sources, err := s.repo.ListPending(ctx, maxImageAttempts, imageRetryAfter, imageBatchSize)
+		if err != nil {
+			return total, err
+		}
+		if len(sources) == 0 {
+			return total, nil
+		}
+
+		described := 0
+		for _, source := range sources {
+			if err := s.describe(ctx, source); err != nil {
+				// Out of budget: leave the rest pending for next month instead of failing them
+				if errors.Is(err, ErrAIBudgetExceeded) || ctx.Err() != nil {
+					return total, err
+				}
+				logger.Warnf(ctx, "[POST-IMAGES] ⚠️  Image %s of post %s: %v", source.ImageID.String(), source.PostID.String(), err)
+				if markErr := s.repo.MarkFailed(ctx, source.ImageID.String(), imageFailureReason(err)); markErr != nil {
+					return total, markErr
+				}
+				continue
+			}
+			described++
+		}
+		total += described
+
+		// Nothing in this batch worked; wait for the next sweep instead of spinning on failures
+		if described == 0 {
+			return total, nil
+		}
+	}
+}
+
+// describe - Fetch one image, ask the model for alt text and a caption and save them
+func (s *PostImageService) describe(ctx context.Context, source *model.ImageDescriptionSource) error {
+	image, err := s.fetchImage(ctx, source.URL)
+	if err != nil {
+		return err
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, imageCallTimeout)
+	defer cancel()
+
+	temperature := 0.2
+	resp, err := s.ai.Complete(callCtx, LLMRequest{
+		Prompt:      imageDescriptionPrompt(source),
+		Model:       s.cfg.Model,
+		Temperature: &temperature,
+		JSON:        true,
+		Feature:     UsageFeatureImageDescription,
+		Images:      []LLMImage{*image},
+	})
+	if err != nil {
+		return err
+	}
+
+	altText, caption, err := parseImageDescription(resp.Text)
+	if err != nil {
+		logger.Infof(ctx, "[POST-IMAGES] Unusable answer for image %s. Raw response: %s", source.ImageID.String(), resp.Text)
+		return err
+	}
+
+	return s.repo.SaveDescription(ctx, source.ImageID.String(), altText, caption, resp.Model)
+}
+
+// fetchImage - Download an image, resized first when it is on Cloudinary
+func (s *PostImageService) fetchImage(ctx context.Context, url string) (*LLMImage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resizedImageURL(url), nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image URL: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download image: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(data) > maxImageBytes {
+		return nil, fmt.Errorf("image is larger than %d MB", maxImageBytes>>20)
+	}
+
+	mimeType := strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	if !strings.HasPrefix(mimeType, "image/") {
+		mimeType = http.DetectContentType(data)
+	}
+	if !strings.HasPrefix(mimeType, "image/") {
+		return nil, fmt.Errorf("not an image (%s)", mimeType)
+	}
+
+	return &LLMImage{MIMEType: mimeType, Data: data}, nil
+}
+
+// resizedImageURL - Ask Cloudinary for a smaller JPEG; other URLs are used as they are
+func resizedImageURL(url string) string {
+	if !strings.Contains(url, "res.cloudinary.com/") {
+		return url
+	}
+	before, after, ok := strings.Cut(url, "/upload/")
+	if !ok {
+		return url
+	}
+	return before + "/upload/" + imageResizeTransform + "/" + after
+}
+
+func imageDescriptionPrompt(source *model.ImageDescriptionSource) string {
+	return fmt.Sprintf(`Describe the attached image for readers of a blog post titled %q.
+Write in %s.
+
+- alt_text: what the image shows, for people using screen readers. One sentence, at most 125 characters.
+  Do not start with "image of" or "picture of". Transcribe short text that appears in the image.
+- caption: a short caption to print under the image, at most 200 characters. It may use the post title for context.
+
+Return ONLY JSON: {"alt_text": "...", "caption": "..."}`, source.PostTitle, languageName(source.Language))
+}
+
+// parseImageDescription - Decode the model's answer; alt text is required, the caption optional
+func parseImageDescription(text string) (string, string, error) {
+	var out struct {
+		AltText string `json:"alt_text"`
+		Caption string `json:"caption"`
+	}
+	if err := json.Unmarshal([]byte(cleanJSONResponse(text)), &out); err != nil {
+		return "", "", errors.New("the AI answer was not valid JSON")
+	}
+
+	altText := truncateRunes(cleanGeneratedTitle(out.AltText), maxAltTextLen)
+	caption := truncateRunes(cleanGeneratedTitle(out.Caption), maxCaptionLen)
+	if altText == "" {
+		return "", "", errors.New("the AI answer has no alt text")
+	}
+	return altText, caption, nil
+}
+
+// imageFailureReason - What the author sees on a failed image; provider details stay in the logs
+func imageFailureReason(err error) string {
+	var statusErr *LLMStatusError
+	switch {
+	case errors.As(err, &statusErr), IsTransientLLMError(err):
+		return "the AI provider returned an error"
+	default:
+		return err.Error()
+	}
+}
+
+func truncateRunes(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	return strings.TrimSpace(string([]rune(text)[:limit]))
+}
+
+// findAuthorImage - An image of the caller's own post
+func (s *PostImageService) findAuthorImage(ctx context.Context, postID, imageID, userID string) (*model.PostImage, error) {
+	var id pgtype.UUID
+	if err := id.Scan(imageID); err != nil {
+		return nil, ErrImageNotFound
+	}
+	if err := id.Scan(postID); err != nil {
+		return nil, ErrPostNotFound
+	}
+
+	post, err := s.posts.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post == nil {
+		return nil, ErrPostNotFound
+	}
+	if post.AuthorID.String() != userID {
+		return nil, ErrUnauthorizedPost
+	}
+
+	image, err := s.repo.FindByID(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil || image.PostID != post.ID {
+		return nil, ErrImageNotFound
+	}
+	return image, nil
+}
+
+func toPostImageResponse(image *model.PostImage) model.PostImageResponse {
+	response := model.PostImageResponse{
+		ID:     image.ID.String(),
+		URL:    image.URL,
+		Status: image.Status,
+	}
+
+	switch {
+	case image.AuthorAltText != nil:
+		response.AltText = image.AuthorAltText
+		response.AltTextSource = ImageTextSourceAuthor
+	case image.AltText != nil:
+		response.AltText = image.AltText
+		response.AltTextSource = ImageTextSourceAI
+	}
+
+	switch {
+	case image.AuthorCaption != nil:
+		response.Caption = image.AuthorCaption
+		response.CaptionSource = ImageTextSourceAuthor
+	case image.Caption != nil:
+		response.Caption = image.Caption
+		response.CaptionSource = ImageTextSourceAI
+	}
+
+	return response
+}
@@ -0,0 +1,373 @@
+// internal/services/post_review_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Review states of AI-generated posts
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+var (
+	ErrPostNotPending         = errors.New("post is not waiting for review")
+	ErrRejectReasonRequired   = errors.New("a rejection reason is required")
+	ErrRegenerationInProgress = errors.New("post is already being regenerated")
+	ErrInvalidReviewStatus    = errors.New("invalid review status")
+	ErrInvalidReviewEdit      = errors.New("invalid post edit")
+)
+
+type PostReviewRepo interface {
+	FindByID(ctx context.Context, postID string) (*model.Post, error)
+	Update(ctx context.Context, post *model.Post) error
+	UpdateReview(ctx context.Context, post *model.Post) error
+	ListForReview(ctx context.Context, status string, limit, offset int) ([]*model.Post, error)
+	CountForReview(ctx context.Context, status string) (int64, error)
+}
+
+type PostReviewService struct {
+	repo     PostReviewRepo
+	jobs     *GenerationJobService
+	personas *PersonaService
+	audit    *AuditService
+
+	mu           sync.Mutex
+	regenerating map[string]bool // post IDs with a regeneration in flight
+}
+
+func NewPostReviewService(repo PostReviewRepo, jobs *GenerationJobService, personas *PersonaService, audit *AuditService) *PostReviewService {
+	return &PostReviewService{
+		repo:         repo,
+		jobs:         jobs,
+		personas:     personas,
+		audit:        audit,
+		regenerating: make(map[string]bool),
+	}
+}
+
+type PaginatedReviewPostsResponse struct {
+	TotalPages     int                        `json:"totalPages"`
+	TotalDocuments int64                      `json:"totalDocuments"`
+	Page           int                        `json:"page"`
+	Limit          int                        `json:"limit"`
+	Posts          []model.ReviewPostResponse `json:"posts"`
+}
+
+// ListQueue - AI posts in a review state (pending by default), oldest first
+func (s *PostReviewService) ListQueue(ctx context.Context, status string, page, limit int) (*PaginatedReviewPostsResponse, error) {
+	if status == "" {
+		status = ReviewStatusPending
+	}
+	switch status {
+	case ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidReviewStatus, status)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	total, err := s.repo.CountForReview(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.repo.ListForReview(ctx, status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.ReviewPostResponse, 0, len(posts))
+	for _, post := range posts {
+		responses = append(responses, s.toReviewPostResponse(post))
+	}
+
+	return &PaginatedReviewPostsResponse{
+		TotalPages:     int(math.Ceil(float64(total) / float64(limit))),
+		TotalDocuments: total,
+		Page:           page,
+		Limit:          limit,
+		Posts:          responses,
+	}, nil
+}
+
+// GetPost - One post from the review queue
+func (s *PostReviewService) GetPost(ctx context.Context, postID string) (*model.ReviewPostResponse, error) {
+	post, err := s.findReviewPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toReviewPostResponse(post)
+	return &response, nil
+}
+
+// EditPost - Fix up a pending post before approving it
+func (s *PostReviewService) EditPost(ctx context.Context, postID string, req *model.ReviewPostRequest) (*model.ReviewPostResponse, error) {
+	post, err := s.findReviewPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if *post.ReviewStatus != ReviewStatusPending {
+		return nil, ErrPostNotPending
+	}
+	if s.isRegenerating(postID) {
+		return nil, ErrRegenerationInProgress
+	}
+
+	before := s.toReviewPostResponse(post)
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if len(title) < 3 {
+			return nil, fmt.Errorf("%w: title must be at least 3 characters long", ErrInvalidReviewEdit)
+		}
+		if len(title) > 200 {
+			return nil, fmt.Errorf("%w: title must not exceed 200 characters", ErrInvalidReviewEdit)
+		}
+		post.Title = title
+	}
+
+	if req.Content != nil {
+		content := strings.TrimSpace(*req.Content)
+		if len(content) < 10 {
+			return nil, fmt.Errorf("%w: content must be at least 10 characters long", ErrInvalidReviewEdit)
+		}
+		post.Content = content
+	}
+
+	if req.Category != nil {
+		post.Category = req.Category
+	}
+
+	if req.Tags != nil {
+		var processedTags []string
+		for _, tag := range req.Tags {
+			if trimmed := strings.TrimSpace(tag); trimmed != "" {
+				processedTags = append(processedTags, strings.ToLower(trimmed))
+			}
+		}
+		post.Tags = processedTags
+	}
+
+	if err := s.repo.Update(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to update post: %w", err)
+	}
+
+	after := s.toReviewPostResponse(post)
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionPostReviewEdit,
+		TargetType: "post",
+		TargetID:   postID,
+		Before:     before,
+		After:      after,
+	})
+
+	return &after, nil
+}
+
+// ApprovePost - Publish a pending post
+func (s *PostReviewService) ApprovePost(ctx context.Context, postID, reviewerID string) (*model.ReviewPostResponse, error) {
+	return s.decide(ctx, postID, reviewerID, ReviewStatusApproved, "")
+}
+
+// RejectPost - Keep a pending post off the site and record why
+func (s *PostReviewService) RejectPost(ctx context.Context, postID, reviewerID, reason string) (*model.ReviewPostResponse, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrRejectReasonRequired
+	}
+	return s.decide(ctx, postID, reviewerID, ReviewStatusRejected, reason)
+}
+
+func (s *PostReviewService) decide(ctx context.Context, postID, reviewerID, status, reason string) (*model.ReviewPostResponse, error) {
+	post, err := s.findReviewPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if *post.ReviewStatus != ReviewStatusPending {
+		return nil, ErrPostNotPending
+	}
+	if s.isRegenerating(postID) {
+		return nil, ErrRegenerationInProgress
+	}
+
+	now := time.Now()
+	post.ReviewStatus = &status
+	post.ReviewReason = optionalString(reason)
+	post.ReviewedAt = &now
+	post.ReviewedBy = pgtype.UUID{}
+	_ = post.ReviewedBy.Scan(reviewerID)
+	post.IsPublished = status == ReviewStatusApproved
+
+	if err := s.repo.UpdateReview(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to save review: %w", err)
+	}
+
+	action := AuditActionPostReviewApprove
+	if status == ReviewStatusRejected {
+		action = AuditActionPostReviewReject
+	}
+	s.audit.Record(ctx, AuditEvent{
+		Action:     action,
+		TargetType: "post",
+		TargetID:   postID,
+		After:      map[string]string{"status": status, "reason": reason},
+	})
+
+	logger.Infof(ctx, "[POST-REVIEW] Post %s %s", postID, status)
+
+	response := s.toReviewPostResponse(post)
+	return &response, nil
+}
+
+// RegeneratePost - Rewrite a pending or rejected post on the same topic in the background; it returns to pending
+func (s *PostReviewService) RegeneratePost(ctx context.Context, postID string) error {
+	post, err := s.findReviewPost(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if *post.ReviewStatus == ReviewStatusApproved {
+		return ErrPostNotPending
+	}
+
+	selection := &model.TopicSelection{Topic: post.Title}
+	if post.Category != nil {
+		selection.Category = *post.Category
+	}
+	if post.GenerationJobID.Valid {
+		if original, err := s.jobs.Selection(ctx, post.GenerationJobID.String()); err == nil {
+			selection = original
+		}
+	}
+
+	// Rewrite in the voice of the persona that wrote the post
+	persona, err := s.personas.ForUser(ctx, post.AuthorID.String())
+	if err != nil {
+		logger.Warnf(ctx, "[POST-REVIEW] ⚠️  Could not load persona for post %s: %v", postID, err)
+	}
+
+	s.mu.Lock()
+	if s.regenerating[postID] {
+		s.mu.Unlock()
+		return ErrRegenerationInProgress
+	}
+	s.regenerating[postID] = true
+	s.mu.Unlock()
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionPostReviewRegenerate,
+		TargetType: "post",
+		TargetID:   postID,
+		Before:     s.toReviewPostResponse(post),
+	})
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.regenerating, postID)
+			s.mu.Unlock()
+		}()
+
+		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		_, err := s.jobs.Run(bgCtx, GenerationTriggerRegenerate, selection, persona, func(ctx context.Context, jobID string, generated *model.AIGeneratedPost) (string, error) {
+			post.Title = generated.Title
+			post.Content = generated.Content
+			post.Tags = generated.Tags
+			if err := s.repo.Update(ctx, post); err != nil {
+				return "", err
+			}
+
+			pending := ReviewStatusPending
+			post.ReviewStatus = &pending
+			post.ReviewReason = nil
+			post.ReviewedBy = pgtype.UUID{}
+			post.ReviewedAt = nil
+			post.IsPublished = false
+			post.GenerationJobID = pgtype.UUID{}
+			if jobID != "" {
+				_ = post.GenerationJobID.Scan(jobID)
+			}
+			if err := s.repo.UpdateReview(ctx, post); err != nil {
+				return "", err
+			}
+			return postID, nil
+		})
+		if err != nil {
+			logger.Errorf(ctx, "[POST-REVIEW] ❌ Failed to regenerate post %s: %v", postID, err)
+			return
+		}
+		logger.Infof(ctx, "[POST-REVIEW] 🔁 Regenerated post %s: '%s'", postID, post.Title)
+	}()
+
+	return nil
+}
+
+// findReviewPost - Only AI posts that entered the review queue are visible here
+func (s *PostReviewService) findReviewPost(ctx context.Context, postID string) (*model.Post, error) {
+	var id pgtype.UUID
+	if err := id.Scan(postID); err != nil {
+		return nil, ErrPostNotFound
+	}
+
+	post, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post == nil || post.ReviewStatus == nil {
+		return nil, ErrPostNotFound
+	}
+	return post, nil
+}
+
+func (s *PostReviewService) isRegenerating(postID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.regenerating[postID]
+}
+
+func (s *PostReviewService) toReviewPostResponse(post *model.Post) model.ReviewPostResponse {
+	response := model.ReviewPostResponse{
+		ID:           post.ID.String(),
+		Title:        post.Title,
+		Content:      post.Content,
+		Tags:         post.Tags,
+		Category:     post.Category,
+		ReviewReason: post.ReviewReason,
+		ReviewedAt:   post.ReviewedAt,
+		Regenerating: s.isRegenerating(post.ID.String()),
+		CreatedAt:    post.CreatedAt,
+		UpdatedAt:    post.UpdatedAt,
+	}
+	if post.ReviewStatus != nil {
+		response.ReviewStatus = *post.ReviewStatus
+	}
+	if post.ReviewedBy.Valid {
+		reviewedBy := post.ReviewedBy.String()
+		response.ReviewedBy = &reviewedBy
+	}
+	if post.GenerationJobID.Valid {
+		jobID := post.GenerationJobID.String()
+		response.GenerationJobID = &jobID
+	}
+	return response
+}
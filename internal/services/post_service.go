@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"math"
 	"mime/multipart"
 	"path/filepath"
@@ -13,6 +12,8 @@ import (
 	// "time"
 
 	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/britinogn/quillhub/pkg/utils"
 	"github.com/cloudinary/cloudinary-go/v2"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -25,8 +26,8 @@ var (
 
 type PostRepo interface{
 	Create(ctx context.Context, post *model.Post) error 
-	GetAllPost(ctx context.Context, limit, offset int) ([]*model.Post, error)
-	CountPosts(ctx context.Context) (int64, error)
+	GetAllPost(ctx context.Context, limit, offset int, language string) ([]*model.Post, error)
+	CountPosts(ctx context.Context, language string) (int64, error)
 	FindByID(ctx context.Context, postID string) (*model.Post, error)
 	FindByAuthorID(ctx context.Context, authorID string) ([]*model.Post, error)
 	Update(ctx context.Context, post *model.Post) error
@@ -37,12 +38,20 @@ type PostRepo interface{
 type PostService struct {
 	repo PostRepo
 	cld *cloudinary.Cloudinary
+	audit *AuditService
+	embeddings *EmbeddingService
+	translations *TranslationService
+	images *PostImageService
 }
 
-func NewPostService(repo PostRepo, cld *cloudinary.Cloudinary) *PostService {
+func NewPostService(repo PostRepo, cld *cloudinary.Cloudinary, audit *AuditService, embeddings *EmbeddingService, translations *TranslationService, images *PostImageService) *PostService {
 	return  &PostService{
 		repo: repo,
 		cld:  cld,
+		audit: audit,
+		embeddings: embeddings,
+		translations: translations,
+		images: images,
 	}
 }
 
@@ -59,10 +68,11 @@ type PaginatedPostsResponse struct {
 //Create POSTS -  Business logic for creating a new post
 func (s *PostService) CreatePost(ctx context.Context, req *model.CreatePostRequest, authorID string, fileHeaders []*multipart.FileHeader ) (*model.Post, error) {
 	//ValidATE ALL Required fields
-	if strings.TrimSpace(req.Title) == "" ||
-		strings.TrimSpace(req.Content) == "" ||
-		strings.TrimSpace(authorID) == ""{
-		return nil , errors.New("all required fields (tittle , content) must be provided")
+	if strings.TrimSpace(authorID) == "" {
+		return nil, errors.New("author ID is required")
+	}
+	if err := requireFields("title", strings.TrimSpace(req.Title), "content", strings.TrimSpace(req.Content)); err != nil {
+		return nil, err
 	}
 
 	// if strings.TrimSpace(req.Category) == "" {
@@ -72,16 +82,26 @@ func (s *PostService) CreatePost(ctx context.Context, req *model.CreatePostReque
 
 	//Validate title length
 	if len(req.Title) < 3 {
-		return nil, errors.New("title must be at least 3 characters long")
+		return nil, utils.Invalid("title", "title must be at least 3 characters long")
 	}
 
 	if len(req.Title) > 200 {
-		return nil, errors.New("title must not exceed 200 characters")
+		return nil, utils.Invalid("title", "title must not exceed 200 characters")
 	}
 
 	// Validate content length
 	if len(req.Content) < 10 {
-		return nil, errors.New("content must be at least 10 characters long")
+		return nil, utils.Invalid("content", "content must be at least 10 characters long")
+	}
+
+	// Posts are written in English unless the author says otherwise
+	language := DefaultPostLanguage
+	if strings.TrimSpace(req.Language) != "" {
+		normalized, err := NormalizeLanguage(req.Language)
+		if err != nil {
+			return nil, err
+		}
+		language = normalized
 	}
 
 	//normalize data
@@ -136,6 +156,8 @@ func (s *PostService) CreatePost(ctx context.Context, req *model.CreatePostReque
 		ImageURL: imageURLs,
 		Tags: processedTags,
 		Category: &req.Category,
+		IsPublished: true,
+		Language: language,
 	}
 
 	// Save to database
@@ -143,10 +165,23 @@ func (s *PostService) CreatePost(ctx context.Context, req *model.CreatePostReque
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
 
+	// Embed the new post and describe its images in the background
+	s.embeddings.Notify()
+	s.trackImages(ctx, post)
+
 	return post, nil
 }
 
-func (s *PostService) GetPosts(ctx context.Context, page, limit int)(*PaginatedPostsResponse, error){
+// GetPosts - A page of the public feed; with a language only posts readable in it, shown translated
+func (s *PostService) GetPosts(ctx context.Context, page, limit int, language string)(*PaginatedPostsResponse, error){
+	if language != "" {
+		normalized, err := NormalizeLanguage(language)
+		if err != nil {
+			return nil, err
+		}
+		language = normalized
+	}
+
 	// set default
 	if page < 1{
 		page = 1
@@ -158,18 +193,26 @@ func (s *PostService) GetPosts(ctx context.Context, page, limit int)(*PaginatedP
 
 	offset := (page - 1) * limit
 
-	totalDocuments, err := s.repo.CountPosts(ctx)
+	totalDocuments, err := s.repo.CountPosts(ctx, language)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count posts: %w", err)
 	}
 
 	totalPages := int(math.Ceil(float64(totalDocuments) / float64(limit)))
 
-	posts, err := s.repo.GetAllPost(ctx, limit , offset)
+	posts, err := s.repo.GetAllPost(ctx, limit , offset, language)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve posts: %w", err)
 	}
 
+	if err := s.translations.LocalizeList(ctx, posts, language); err != nil {
+		return nil, fmt.Errorf("failed to translate posts: %w", err)
+	}
+
+	if err := s.images.Attach(ctx, posts); err != nil {
+		return nil, fmt.Errorf("failed to load post images: %w", err)
+	}
+
 	
 	return &PaginatedPostsResponse{
 		TotalPages: totalPages,
@@ -181,10 +224,11 @@ func (s *PostService) GetPosts(ctx context.Context, page, limit int)(*PaginatedP
 
 }
 
-func (s *PostService) GetPostByID(ctx context.Context, postID string) (*model.Post, error) {
+// GetPostByID - A public post in the best language for an Accept-Language style preference ("" for the original)
+func (s *PostService) GetPostByID(ctx context.Context, postID string, languagePreference string) (*model.Post, error) {
 	// Validate input
 	if strings.TrimSpace(postID)  == "" {
-		return nil, errors.New("post Id is required")
+		return nil, utils.Invalid("post_id", "post ID is required")
 	}
 	
 	
@@ -194,11 +238,19 @@ func (s *PostService) GetPostByID(ctx context.Context, postID string) (*model.Po
 
 	}
 
-	// Check if post exists
-	if post == nil {
+	// Check if post exists; AI posts waiting for (or failing) review are not public
+	if post == nil || heldForReview(post) {
 		return nil, ErrPostNotFound
 	}
 
+	if err := s.translations.Localize(ctx, post, languagePreference); err != nil {
+		return nil, fmt.Errorf("failed to translate post: %w", err)
+	}
+
+	if err := s.images.Attach(ctx, []*model.Post{post}); err != nil {
+		return nil, fmt.Errorf("failed to load post images: %w", err)
+	}
+
 	// Increment view count
 	// _ = s.repo.IncrementViewCount(ctx, postID)
 	// Increment view count (async, don't fail if this errors)
@@ -207,17 +259,25 @@ func (s *PostService) GetPostByID(ctx context.Context, postID string) (*model.Po
 		bgCtx := context.Background()
 		if err := s.repo.IncrementViewCount(bgCtx, postID); err != nil {
 			// Log error but don't fail the request
-			log.Printf("Failed to increment view count for post %s: %v", postID, err)
+			logger.Errorf(ctx, "Failed to increment view count for post %s: %v", postID, err)
 		}
 	}()
 
 	return post, nil
 }
 
-//GetPostsByAuthorID - Get all posts by author
-func (s *PostService) GetPostsByAuthorID(ctx context.Context, authorID string) ([]*model.Post, error) {
+//GetPostsByAuthorID - Get all posts by author; with a language only those readable in it, shown translated
+func (s *PostService) GetPostsByAuthorID(ctx context.Context, authorID string, language string) ([]*model.Post, error) {
 	if strings.TrimSpace(authorID) == "" {
-		return nil, errors.New("author ID is required")
+		return nil, utils.Invalid("author_id", "author ID is required")
+	}
+
+	if language != "" {
+		normalized, err := NormalizeLanguage(language)
+		if err != nil {
+			return nil, err
+		}
+		language = normalized
 	}
 
 	posts, err := s.repo.FindByAuthorID(ctx, authorID)
@@ -225,7 +285,32 @@ func (s *PostService) GetPostsByAuthorID(ctx context.Context, authorID string) (
 		return nil, fmt.Errorf("failed to get posts by author: %w", err)
 	}
 
-	return posts, nil
+	visible := make([]*model.Post, 0, len(posts))
+	for _, post := range posts {
+		if !heldForReview(post) {
+			visible = append(visible, post)
+		}
+	}
+
+	if language != "" {
+		if err := s.translations.LocalizeList(ctx, visible, language); err != nil {
+			return nil, fmt.Errorf("failed to translate posts: %w", err)
+		}
+
+		inLanguage := make([]*model.Post, 0, len(visible))
+		for _, post := range visible {
+			if post.Language == language {
+				inLanguage = append(inLanguage, post)
+			}
+		}
+		visible = inLanguage
+	}
+
+	if err := s.images.Attach(ctx, visible); err != nil {
+		return nil, fmt.Errorf("failed to load post images: %w", err)
+	}
+
+	return visible, nil
 }
 
 //update 
@@ -249,13 +334,13 @@ func (s *PostService) UpdatePost(ctx context.Context, req *model.UpdatePostReque
 		// Validate title
 		title := strings.TrimSpace(*req.Title)
 		if title == "" {
-			return nil, errors.New("title cannot be empty")
+			return nil, utils.Invalid("title", "title cannot be empty")
 		}
 		if len(title) < 3 {
-			return nil, errors.New("title must be at least 3 characters long")
+			return nil, utils.Invalid("title", "title must be at least 3 characters long")
 		}
 		if len(title) > 200 {
-			return nil, errors.New("title must not exceed 200 characters")
+			return nil, utils.Invalid("title", "title must not exceed 200 characters")
 		}
 		existing.Title = title
 	}
@@ -264,10 +349,10 @@ func (s *PostService) UpdatePost(ctx context.Context, req *model.UpdatePostReque
 		// Validate content
 		content := strings.TrimSpace(*req.Content)
 		if content == "" {
-			return nil, errors.New("content cannot be empty")
+			return nil, utils.Invalid("content", "content cannot be empty")
 		}
 		if len(content) < 10 {
-			return nil, errors.New("content must be at least 10 characters long")
+			return nil, utils.Invalid("content", "content must be at least 10 characters long")
 		}
 		existing.Content = content
 	}
@@ -280,6 +365,14 @@ func (s *PostService) UpdatePost(ctx context.Context, req *model.UpdatePostReque
 		existing.IsPublished = *req.IsPublished
 	}
 
+	if req.Language != nil {
+		language, err := NormalizeLanguage(*req.Language)
+		if err != nil {
+			return nil, err
+		}
+		existing.Language = language
+	}
+
 	// Process tags if provided
 	if req.Tags != nil {
 		var processedTags []string
@@ -322,6 +415,9 @@ func (s *PostService) UpdatePost(ctx context.Context, req *model.UpdatePostReque
 		return nil, fmt.Errorf("failed to update post: %w", err)
 	}
 
+	s.embeddings.Notify()
+	s.trackImages(ctx, existing)
+
 	return existing, nil
 }
 
@@ -354,7 +450,35 @@ func (s *PostService) DeletePost(ctx context.Context, postID, userID string) err
 	}
 
 	// Delete from database
-	return s.repo.Delete(ctx, postID)
+	if err := s.repo.Delete(ctx, postID); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionPostDelete,
+		TargetType: "post",
+		TargetID:   postID,
+		Before:     existing,
+	})
+
+	return nil
+}
+
+// trackImages - Queue a saved post's images for alt text and captions and attach what is known.
+// The post is already saved, so a failure here is only logged; the background sweep catches up.
+func (s *PostService) trackImages(ctx context.Context, post *model.Post) {
+	if err := s.images.Track(ctx, post); err != nil {
+		logger.Errorf(ctx, "Failed to track images for post %s: %v", post.ID.String(), err)
+		return
+	}
+	if err := s.images.Attach(ctx, []*model.Post{post}); err != nil {
+		logger.Errorf(ctx, "Failed to load images for post %s: %v", post.ID.String(), err)
+	}
+}
+
+// heldForReview - Pending or rejected AI posts stay out of public reads
+func heldForReview(post *model.Post) bool {
+	return post.ReviewStatus != nil && *post.ReviewStatus != ReviewStatusApproved
 }
 
 // Helper function to extract public_id from Cloudinary URL
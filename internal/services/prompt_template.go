@@ -0,0 +1,143 @@
+// internal/services/prompt_template.go
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/britinogn/quillhub/internal/model"
+)
+
+const maxPromptTemplateSize = 20000
+
+var ErrInvalidPromptTemplate = errors.New("invalid prompt template")
+
+// DefaultPostPromptTemplate - Used by personas without a template of their own.
+// The JSON answer format is always appended after the rendered template.
+const DefaultPostPromptTemplate = `You are {{if .Persona}}{{.Persona}}, {{end}}a skilled blogger{{if not .Tone}} who adapts tone to the topic{{end}}.
+{{- if .Description}}
+{{.Description}}
+{{- end}}
+
+Topic: "{{.Topic}}"
+{{- if .Category}}
+Category: {{printf "%q" .Category}}
+{{- end}}
+{{- if .Tags}}
+Suggested tags: {{join .Tags ", "}}
+{{- end}}
+
+Rules:
+- Create a catchy, engaging title of at most {{.MaxTitleLength}} characters
+- Write {{.MinWords}}–{{.MaxWords}} words of well-structured content
+{{- if .Tone}}
+- Tone: {{.Tone}}
+{{- else}}
+- If the topic is technical/programming → use professional tone, clear explanations, code examples in markdown blocks
+- If the topic is fun/travel/jokes/psychology/countries/health/life → use light, relatable, humorous tone
+{{- end}}
+- Structure: short intro, main body (sections or bullets), quick conclusion
+- End with a question or call-to-action to engage readers
+- Use markdown for formatting (headings, bold, code blocks, lists)
+- No first person ("I", "we") — neutral voice
+- Give {{.MinTags}} to {{.MaxTags}} short, lowercase tags`
+
+// PostPromptData - Variables available to prompt templates, e.g. {{.Topic}} or {{.MinWords}}
+type PostPromptData struct {
+	Topic          string
+	Category       string
+	Tags           []string
+	MinWords       int
+	MaxWords       int
+	Tone           string
+	Persona        string
+	Description    string
+	MaxTitleLength int
+	MinTags        int
+	MaxTags        int
+}
+
+var promptTemplateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+var defaultPostPrompt = template.Must(ParsePromptTemplate(DefaultPostPromptTemplate))
+
+// ParsePromptTemplate - Parse a post prompt template and check it renders with sample data
+func ParsePromptTemplate(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("%w: template is empty", ErrInvalidPromptTemplate)
+	}
+	if len(text) > maxPromptTemplateSize {
+		return nil, fmt.Errorf("%w: template is longer than %d characters", ErrInvalidPromptTemplate, maxPromptTemplateSize)
+	}
+
+	tmpl, err := template.New("post_prompt").Funcs(promptTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPromptTemplate, err)
+	}
+
+	sample := newPostPromptData(&model.AIPostRequest{
+		Topic:    "Sample topic",
+		Category: "Sample category",
+		Tags:     []string{"sample"},
+		Tone:     "friendly",
+	}, nil)
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, sample); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPromptTemplate, err)
+	}
+	if !strings.Contains(out.String(), sample.Topic) {
+		return nil, fmt.Errorf("%w: template must use {{.Topic}}", ErrInvalidPromptTemplate)
+	}
+
+	return tmpl, nil
+}
+
+// PersonaPrompt - The full generation prompt for a persona (nil for the built-in one)
+func PersonaPrompt(persona *model.BotPersona, req *model.AIPostRequest) (string, error) {
+	tmpl := defaultPostPrompt
+	if persona != nil && persona.PromptTemplate != nil {
+		parsed, err := ParsePromptTemplate(*persona.PromptTemplate)
+		if err != nil {
+			return "", err
+		}
+		tmpl = parsed
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, newPostPromptData(req, persona)); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidPromptTemplate, err)
+	}
+
+	// The answer format is fixed so templates cannot break parsing
+	return strings.TrimSpace(out.String()) + "\n\n" + blogPostFormat, nil
+}
+
+func newPostPromptData(req *model.AIPostRequest, persona *model.BotPersona) PostPromptData {
+	spec := NormalizePostRequest(req)
+	data := PostPromptData{
+		Topic:          spec.Topic,
+		Category:       spec.Category,
+		Tags:           spec.Tags,
+		MinWords:       spec.MinLength,
+		MaxWords:       spec.MaxLength,
+		Tone:           spec.Tone,
+		MaxTitleLength: maxGeneratedTitleLen,
+		MinTags:        minGeneratedTags,
+		MaxTags:        maxGeneratedTags,
+	}
+	if persona != nil {
+		data.Persona = persona.Name
+		if persona.Description != nil {
+			data.Description = *persona.Description
+		}
+	}
+	return data
+}
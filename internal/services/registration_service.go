@@ -0,0 +1,247 @@
+// internal/services/registration_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/britinogn/quillhub/config"
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Registration modes
+const (
+	RegistrationModeOpen             = "open"
+	RegistrationModeInviteOnly       = "invite_only"
+	RegistrationModeDomainRestricted = "domain_restricted"
+)
+
+var (
+	ErrInviteRequired        = errors.New("an invite code is required to register")
+	ErrInvalidInvite         = errors.New("invite code is invalid, expired or already used")
+	ErrInviteNotFound        = errors.New("invite not found")
+	ErrEmailDomainNotAllowed = errors.New("email domain is not allowed to register")
+	ErrInvalidRole           = errors.New("invalid role")
+)
+
+// assignableRoles - Roles an admin may grant through invites or role changes ("bot" is reserved)
+var assignableRoles = map[string]bool{
+	"user":      true,
+	"moderator": true,
+	"admin":     true,
+}
+
+type InviteRepo interface {
+	Create(ctx context.Context, invite *model.InviteCode) error
+	List(ctx context.Context, limit, offset int) ([]*model.InviteCode, error)
+	FindByID(ctx context.Context, inviteID string) (*model.InviteCode, error)
+	Claim(ctx context.Context, code string) (*model.InviteCode, error)
+	Release(ctx context.Context, inviteID string) error
+	MarkUsedBy(ctx context.Context, inviteID, userID string) error
+	Delete(ctx context.Context, inviteID string) error
+}
+
+// RegistrationPolicy - Who may create an account
+type RegistrationPolicy struct {
+	Mode           string
+	AllowedDomains []string
+}
+
+// LoadRegistrationPolicy - The registration mode and allowed domains from the app config
+func LoadRegistrationPolicy(app config.RegistrationConfig) RegistrationPolicy {
+	policy := RegistrationPolicy{
+		Mode:           app.Mode,
+		AllowedDomains: app.AllowedDomains,
+	}
+
+	switch policy.Mode {
+	case "":
+		policy.Mode = RegistrationModeOpen
+	case RegistrationModeOpen, RegistrationModeInviteOnly, RegistrationModeDomainRestricted:
+	default:
+		// Fail closed on typos rather than silently opening registration
+		logger.Warnf(context.Background(), "[REGISTRATION] ⚠️  Unknown REGISTRATION_MODE %q, falling back to %s", policy.Mode, RegistrationModeInviteOnly)
+		policy.Mode = RegistrationModeInviteOnly
+	}
+
+	if policy.Mode == RegistrationModeDomainRestricted && len(policy.AllowedDomains) == 0 {
+		logger.Warnf(context.Background(), "[REGISTRATION] ⚠️  %s mode without REGISTRATION_ALLOWED_DOMAINS: only invites can register", policy.Mode)
+	}
+
+	logger.Infof(context.Background(), "[REGISTRATION] Mode: %s | Allowed domains: %v", policy.Mode, policy.AllowedDomains)
+	return policy
+}
+
+// Info - Public description for the signup form
+func (p RegistrationPolicy) Info() model.RegistrationInfo {
+	info := model.RegistrationInfo{
+		Mode:           p.Mode,
+		InviteRequired: p.Mode == RegistrationModeInviteOnly,
+	}
+	if p.Mode == RegistrationModeDomainRestricted {
+		info.AllowedDomains = p.AllowedDomains
+	}
+	return info
+}
+
+// RequiresInvite - Whether this email can only register with an invite code
+func (p RegistrationPolicy) RequiresInvite(email string) (bool, error) {
+	switch p.Mode {
+	case RegistrationModeInviteOnly:
+		return true, ErrInviteRequired
+	case RegistrationModeDomainRestricted:
+		if !p.domainAllowed(email) {
+			return true, ErrEmailDomainNotAllowed
+		}
+	}
+	return false, nil
+}
+
+func (p RegistrationPolicy) domainAllowed(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	domain := strings.ToLower(email[at+1:])
+	for _, allowed := range p.AllowedDomains {
+		if domain == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+type InviteService struct {
+	repo  InviteRepo
+	audit *AuditService
+}
+
+func NewInviteService(repo InviteRepo, audit *AuditService) *InviteService {
+	return &InviteService{repo: repo, audit: audit}
+}
+
+// CreateInvite - Issue a single-use invite code (admin only)
+func (s *InviteService) CreateInvite(ctx context.Context, req *model.CreateInviteRequest, creatorID string) (*model.InviteResponse, error) {
+	role := strings.ToLower(strings.TrimSpace(req.Role))
+	if role == "" {
+		role = "user"
+	}
+	if !assignableRoles[role] {
+		return nil, ErrInvalidRole
+	}
+
+	code, err := randomToken(12)
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &model.InviteCode{
+		Code: code,
+		Role: role,
+	}
+
+	if req.Email != nil && strings.TrimSpace(*req.Email) != "" {
+		email := strings.ToLower(strings.TrimSpace(*req.Email))
+		invite.Email = &email
+	}
+
+	if req.ExpiresInHours > 0 {
+		expiresAt := time.Now().Add(time.Duration(req.ExpiresInHours) * time.Hour)
+		invite.ExpiresAt = &expiresAt
+	}
+
+	if err := invite.CreatedBy.Scan(creatorID); err != nil {
+		return nil, fmt.Errorf("invalid creator ID format: %w", err)
+	}
+
+	if err := s.repo.Create(ctx, invite); err != nil {
+		return nil, err
+	}
+
+	response := toInviteResponse(invite)
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionInviteCreate,
+		TargetType: "invite",
+		TargetID:   response.ID,
+		After:      map[string]any{"email": invite.Email, "role": invite.Role, "expires_at": invite.ExpiresAt},
+	})
+
+	logger.Infof(ctx, "[INVITE-SERVICE] Invite %s created by %s (role: %s)", response.ID, creatorID, role)
+	return &response, nil
+}
+
+// ListInvites - Most recent invites first
+func (s *InviteService) ListInvites(ctx context.Context, page, limit int) ([]model.InviteResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	invites, err := s.repo.List(ctx, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.InviteResponse, 0, len(invites))
+	for _, invite := range invites {
+		responses = append(responses, toInviteResponse(invite))
+	}
+	return responses, nil
+}
+
+// RevokeInvite - Delete an unused invite
+func (s *InviteService) RevokeInvite(ctx context.Context, inviteID string) error {
+	var inviteUUID pgtype.UUID
+	if err := inviteUUID.Scan(inviteID); err != nil {
+		return ErrInviteNotFound
+	}
+
+	invite, err := s.repo.FindByID(ctx, inviteID)
+	if err != nil {
+		return err
+	}
+	if invite == nil || invite.UsedAt != nil {
+		return ErrInviteNotFound
+	}
+
+	if err := s.repo.Delete(ctx, inviteID); err != nil {
+		return fmt.Errorf("failed to revoke invite: %w", err)
+	}
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionInviteRevoke,
+		TargetType: "invite",
+		TargetID:   inviteID,
+		Before:     toInviteResponse(invite),
+	})
+	return nil
+}
+
+func toInviteResponse(invite *model.InviteCode) model.InviteResponse {
+	response := model.InviteResponse{
+		ID:        invite.ID.String(),
+		Code:      invite.Code,
+		Email:     invite.Email,
+		Role:      invite.Role,
+		UsedAt:    invite.UsedAt,
+		ExpiresAt: invite.ExpiresAt,
+		CreatedAt: invite.CreatedAt,
+	}
+	if invite.CreatedBy.Valid {
+		createdBy := invite.CreatedBy.String()
+		response.CreatedBy = &createdBy
+	}
+	if invite.UsedBy.Valid {
+		usedBy := invite.UsedBy.String()
+		response.UsedBy = &usedBy
+	}
+	return response
+}
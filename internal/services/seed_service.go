@@ -0,0 +1,237 @@
+// internal/services/seed_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/britinogn/quillhub/pkg/utils"
+)
+
+// seedEmailDomain - Demo accounts use a reserved domain so they can never receive mail
+const seedEmailDomain = "demo.quillhub.invalid"
+
+var ErrAlreadySeeded = errors.New("demo data already exists")
+
+type SeedRepo interface {
+	CountUsersByEmail(ctx context.Context, emails []string) (int64, error)
+	Insert(ctx context.Context, data *model.SeedData, passwordHash string, now time.Time) (*model.SeedResult, error)
+}
+
+// SeedService - Fills an empty development database with believable users, posts,
+// comments and likes
+type SeedService struct {
+	repo SeedRepo
+}
+
+func NewSeedService(repo SeedRepo) *SeedService {
+	return &SeedService{repo: repo}
+}
+
+// Seed - Write the demo data once; every demo account gets the same password
+func (s *SeedService) Seed(ctx context.Context, password string) (*model.SeedResult, error) {
+	if len(password) < 8 {
+		return nil, errors.New("password must be at least 8 characters")
+	}
+
+	data := demoSeedData()
+
+	emails := make([]string, 0, len(data.Users))
+	for _, user := range data.Users {
+		emails = append(emails, user.Email)
+	}
+	existing, err := s.repo.CountUsersByEmail(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+	if existing > 0 {
+		return nil, ErrAlreadySeeded
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	result, err := s.repo.Insert(ctx, data, hash, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result.Emails = emails
+	logger.Infof(ctx, "[SEED-SERVICE] ✅ Created %d users, %d posts, %d comments and %d likes",
+		result.Users, result.Posts, result.Comments, result.Likes)
+	return result, nil
+}
+
+func demoSeedData() *model.SeedData {
+	const day = 24 * time.Hour
+
+	user := func(name, username, bio string) model.SeedUser {
+		return model.SeedUser{Name: name, Username: username, Email: username + "@" + seedEmailDomain, Bio: bio}
+	}
+
+	const (
+		amara = iota
+		daniel
+		lena
+		tomas
+		priya
+	)
+
+	return &model.SeedData{
+		Users: []model.SeedUser{
+			amara:  user("Amara Okafor", "amara", "Backend engineer. Writes about databases, queues and the bugs in between."),
+			daniel: user("Daniel Reyes", "dreyes", "Weekend cyclist, weekday product designer."),
+			lena:   user("Lena Fischer", "lenaf", "Home cook documenting one regional recipe at a time."),
+			tomas:  user("Tomás Silva", "tomas", "Freelance photographer based in Lisbon."),
+			priya:  user("Priya Nair", "priyan", "Reads too much, sleeps too little. Book notes and the odd essay."),
+		},
+		Posts: []model.SeedPost{
+			{
+				Author:   amara,
+				Title:    "What I learned running Postgres migrations on a live system",
+				Category: "Technology",
+				Tags:     []string{"postgres", "databases", "devops"},
+				Content: `Our first zero-downtime migration took three attempts. The first locked the orders table for forty seconds; the second rolled back halfway because of a long-running report.
+
+The version that worked split the change into steps that are each safe on their own: add the column as nullable, backfill it in small batches, then add the constraint as NOT VALID and validate it separately. Every step could run while traffic was flowing, and every step could be reverted without touching data.
+
+The biggest lesson was not technical. Writing the down migration before the up migration forced us to think about what "undo" means for each change, and twice it showed us the plan was wrong before anything reached production.`,
+				ViewCount: 1240,
+				Age:       38 * day,
+				LikedBy:   []int{daniel, priya, tomas},
+				Comments: []model.SeedComment{
+					{Author: daniel, Text: "The NOT VALID trick is new to me. Does validating later still take a heavy lock?", After: 5 * time.Hour},
+					{Author: amara, Text: "It takes a lighter lock that still allows reads and writes, which is the whole point. It can take a while on a big table though.", After: 7 * time.Hour},
+					{Author: priya, Text: "Writing the down migration first is such a good habit. Stealing it.", After: 2 * day},
+				},
+			},
+			{
+				Author:   daniel,
+				Title:    "Designing forms people actually finish",
+				Category: "Design",
+				Tags:     []string{"ux", "forms", "accessibility"},
+				Content: `We cut our signup form from eleven fields to four and completion went up by a third. None of the removed fields were useless; they were just asked at the wrong time.
+
+Ask for what you need now, and ask for the rest when the answer helps the person. A shipping address makes sense at checkout, not at signup. A profile photo makes sense once someone has something to share.
+
+Error messages matter as much as fields. "Invalid input" tells nobody anything. "The postcode should have five digits" tells them exactly what to change, and keeps what they already typed.`,
+				ViewCount: 860,
+				Age:       31 * day,
+				LikedBy:   []int{amara, lena},
+				Comments: []model.SeedComment{
+					{Author: lena, Text: "Please tell every recipe site about keeping what I already typed.", After: 3 * time.Hour},
+					{Author: tomas, Text: "Did you measure drop-off per field before cutting them?", After: day},
+				},
+			},
+			{
+				Author:   lena,
+				Title:    "A weeknight ragù that does not take all Sunday",
+				Category: "Food",
+				Tags:     []string{"recipes", "italian", "cooking"},
+				Content: `A proper ragù wants hours, but most of those hours are about breaking down tough meat and concentrating flavour. With minced beef and pork, and a pressure cooker or a wide pan, you can get most of the way in forty-five minutes.
+
+Brown the meat hard and in batches; the colour is where the flavour comes from. Soften onion, carrot and celery slowly, add a spoon of tomato paste and let it darken, then a glass of milk before the wine. The milk sounds strange and makes the sauce silky.
+
+Finish with a little butter and a handful of parmesan, and toss it with wide pasta in the pan rather than spooning it on top.`,
+				ViewCount: 2105,
+				Age:       24 * day,
+				LikedBy:   []int{amara, daniel, tomas, priya},
+				Comments: []model.SeedComment{
+					{Author: priya, Text: "Made this on Tuesday. The milk step is magic.", After: 2 * day},
+					{Author: amara, Text: "Any tips for a vegetarian version? Lentils?", After: 3 * day},
+					{Author: lena, Text: "Brown lentils plus chopped mushrooms browned hard work really well. Add a little soy sauce for depth.", After: 3*day + 4*time.Hour},
+				},
+			},
+			{
+				Author:   tomas,
+				Title:    "Shooting in harsh midday light",
+				Category: "Photography",
+				Tags:     []string{"photography", "light", "travel"},
+				Content: `Every guide says to shoot at golden hour. On a trip, though, you are usually somewhere interesting at noon.
+
+Midday light is hard and comes from above, so stop fighting it. Look for open shade under arches and awnings where faces get soft light, and use the bright street behind them as a backdrop. Hard light is also great for graphic images: strong shadows, bold colours, geometry on white walls.
+
+Expose for the highlights and let the shadows go dark. A slightly underexposed frame with detail in the sky almost always beats a bright one with a white hole where the clouds were.`,
+				ViewCount: 640,
+				Age:       17 * day,
+				LikedBy:   []int{daniel, lena},
+				Comments: []model.SeedComment{
+					{Author: daniel, Text: "The open shade tip changed my holiday photos completely.", After: 6 * time.Hour},
+				},
+			},
+			{
+				Author:   priya,
+				Title:    "Five books that changed how I think about time",
+				Category: "Books",
+				Tags:     []string{"books", "reading", "productivity"},
+				Content: `I went looking for productivity advice and found philosophy instead. These five books share one idea: our lives are short and finite, and pretending otherwise is what makes us anxious.
+
+Four Thousand Weeks is the most direct about it. The Order of Time explains why physics has no single "now". Stolen Focus looks at why it feels harder to pay attention than it used to. Deep Work is the practical one, and Essentialism is the one I reread every January.
+
+None of them gave me a system. Together they made me much more comfortable leaving things undone.`,
+				ViewCount: 1530,
+				Age:       12 * day,
+				LikedBy:   []int{amara, daniel, lena, tomas},
+				Comments: []model.SeedComment{
+					{Author: tomas, Text: "Four Thousand Weeks is on my nightstand right now.", After: 4 * time.Hour},
+					{Author: amara, Text: "Adding The Order of Time to my list, thanks!", After: day},
+				},
+			},
+			{
+				Author:   amara,
+				Title:    "Queues are not a fix for slow code",
+				Category: "Technology",
+				Tags:     []string{"architecture", "queues", "performance"},
+				Content: `Moving work to a background queue makes a request fast, but it does not make the work fast. If jobs arrive faster than workers finish them, the queue grows until something falls over, usually at the worst possible moment.
+
+Before adding a queue, measure how long the work takes and how often it arrives. If the answer is "usually fine, occasionally spiky", a queue smooths the spikes beautifully. If the answer is "always slower than arrivals", you need faster work or more workers, and the queue only hides the problem for a while.
+
+And always put a limit on the queue. Rejecting work early with a clear error is kinder than accepting it and never doing it.`,
+				ViewCount: 980,
+				Age:       8 * day,
+				LikedBy:   []int{priya},
+				Comments: []model.SeedComment{
+					{Author: daniel, Text: "The same is true of design review backlogs, honestly.", After: 9 * time.Hour},
+				},
+			},
+			{
+				Author:   daniel,
+				Title:    "A beginner's first century ride",
+				Category: "Sports",
+				Tags:     []string{"cycling", "fitness", "outdoors"},
+				Content: `A hundred kilometres felt impossible in March. In September I rode it with two friends, a lot of snacks and one flat tyre.
+
+The training was less dramatic than I expected: three rides a week, one of them a little longer each weekend. The real lessons were about eating before you are hungry, drinking before you are thirsty, and starting slower than feels necessary.
+
+The last twenty kilometres were hard, and also the best part of the day.`,
+				ViewCount: 410,
+				Age:       5 * day,
+				LikedBy:   []int{tomas, lena, amara},
+				Comments: []model.SeedComment{
+					{Author: lena, Text: "Congratulations! What did you eat on the way?", After: 2 * time.Hour},
+					{Author: daniel, Text: "Bananas, salted potatoes and far too many gummy bears.", After: 3 * time.Hour},
+				},
+			},
+			{
+				Author:   lena,
+				Title:    "Why I weigh my flour now",
+				Category: "Food",
+				Tags:     []string{"baking", "kitchen", "tips"},
+				Content: `A cup of flour can weigh anywhere from 120 to 160 grams depending on how you fill it. That is the difference between a tender cake and a dry one.
+
+A cheap kitchen scale fixed more of my baking than any recipe did. It also means fewer dishes: put the bowl on the scale, press tare, and add each ingredient straight in.
+
+If a recipe only gives cups, spoon the flour into the cup and level it off without packing; that is how most American recipes are tested.`,
+				ViewCount: 330,
+				Age:       2 * day,
+				LikedBy:   []int{priya, daniel},
+			},
+		},
+	}
+}
@@ -0,0 +1,162 @@
+// internal/services/session_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/britinogn/quillhub/pkg/utils"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionRevoked  = errors.New("session has been revoked or expired")
+)
+
+type SessionRepo interface {
+	Create(ctx context.Context, session *model.Session) error
+	FindByID(ctx context.Context, sessionID string) (*model.Session, error)
+	FindActiveByUserID(ctx context.Context, userID string) ([]*model.Session, error)
+	Touch(ctx context.Context, sessionID string) error
+	Revoke(ctx context.Context, sessionID, userID string) error
+	RevokeAllByUserID(ctx context.Context, userID string) (int64, error)
+}
+
+type SessionService struct {
+	repo   SessionRepo
+	audit  *AuditService
+	tokens *utils.TokenSigner
+}
+
+func NewSessionService(repo SessionRepo, audit *AuditService, tokens *utils.TokenSigner) *SessionService {
+	return &SessionService{repo: repo, audit: audit, tokens: tokens}
+}
+
+// IssueToken - Create a session for the user and sign a JWT bound to it
+func (s *SessionService) IssueToken(ctx context.Context, user *model.User, meta model.SessionMeta) (string, error) {
+	session := &model.Session{
+		UserID:    user.ID,
+		UserAgent: optionalString(truncate(strings.TrimSpace(meta.UserAgent), 512)),
+		IPAddress: optionalString(strings.TrimSpace(meta.IPAddress)),
+		ExpiresAt: time.Now().Add(s.tokens.TTL()),
+	}
+
+	if err := s.repo.Create(ctx, session); err != nil {
+		return "", err
+	}
+
+	token, err := s.tokens.GenerateToken(user.ID.String(), user.Email, user.Username, user.Role, session.ID.String())
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return token, nil
+}
+
+// VerifyToken - Check a JWT's signature and expiry; used by AuthMiddleware
+func (s *SessionService) VerifyToken(token string) (*utils.Claims, error) {
+	return s.tokens.VerifyToken(token)
+}
+
+// ValidateSession - Ensure the session behind a token is still active; used by AuthMiddleware
+func (s *SessionService) ValidateSession(ctx context.Context, sessionID, userID string) error {
+	if sessionID == "" {
+		return ErrSessionRevoked
+	}
+
+	session, err := s.repo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session == nil ||
+		session.UserID.String() != userID ||
+		session.RevokedAt != nil ||
+		time.Now().After(session.ExpiresAt) {
+		return ErrSessionRevoked
+	}
+
+	if err := s.repo.Touch(ctx, sessionID); err != nil {
+		logger.Errorf(ctx, "[SESSION-SERVICE] Failed to touch session %s: %v", sessionID, err)
+	}
+
+	return nil
+}
+
+// ListSessions - Active sessions of a user, flagging the one making the request
+func (s *SessionService) ListSessions(ctx context.Context, userID, currentSessionID string) ([]model.SessionResponse, error) {
+	sessions, err := s.repo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, model.SessionResponse{
+			ID:         session.ID.String(),
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			Current:    session.ID.String() == currentSessionID,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+		})
+	}
+
+	return responses, nil
+}
+
+// RevokeSession - Sign out a single device
+func (s *SessionService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	var sessionUUID pgtype.UUID
+	if err := sessionUUID.Scan(sessionID); err != nil {
+		return ErrSessionNotFound
+	}
+
+	session, err := s.repo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID.String() != userID || session.RevokedAt != nil {
+		return ErrSessionNotFound
+	}
+
+	if err := s.repo.Revoke(ctx, sessionID, userID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	s.audit.Record(ctx, AuditEvent{Action: AuditActionSessionRevoke, TargetType: "session", TargetID: sessionID})
+
+	logger.Infof(ctx, "[SESSION-SERVICE] Session %s revoked by user %s", sessionID, userID)
+	return nil
+}
+
+// RevokeAllSessions - Sign out everywhere, including the current device
+func (s *SessionService) RevokeAllSessions(ctx context.Context, userID string) (int64, error) {
+	revoked, err := s.repo.RevokeAllByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionSessionRevokeAll,
+		TargetType: "user",
+		TargetID:   userID,
+		After:      map[string]int64{"revoked": revoked},
+	})
+
+	return revoked, nil
+}
+
+func truncate(value string, max int) string {
+	if len(value) > max {
+		return value[:max]
+	}
+	return value
+}
@@ -0,0 +1,54 @@
+// internal/services/stats_service.go
+package services
+
+import (
+	"context"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+)
+
+type StatsRepo interface {
+	RecountTopicUsage(ctx context.Context) (int64, error)
+	RecountAssistantTokens(ctx context.Context) (int64, error)
+}
+
+// StatsService - Repairs stored values that can drift from the rows they summarise,
+// e.g. after a failed write or a manual fix in the database. Counts shown on the
+// dashboards are computed live and never need this.
+type StatsService struct {
+	repo      StatsRepo
+	dashboard *DashboardService
+}
+
+func NewStatsService(repo StatsRepo, dashboard *DashboardService) *StatsService {
+	return &StatsService{repo: repo, dashboard: dashboard}
+}
+
+// Recount - Rebuild every derived value, then report the site totals
+func (s *StatsService) Recount(ctx context.Context) (*model.StatsRecount, error) {
+	topics, err := s.repo.RecountTopicUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assistantDays, err := s.repo.RecountAssistantTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.dashboard.GetAdminDashboard(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof(ctx, "[STATS-SERVICE] Recounted: %d topic(s) and %d assistant usage day(s) corrected", topics, assistantDays)
+	return &model.StatsRecount{
+		TopicsUpdated:        topics,
+		AssistantDaysUpdated: assistantDays,
+		TotalUsers:           totals.TotalUsers,
+		TotalPosts:           totals.TotalPosts,
+		TotalComments:        totals.TotalComments,
+		TotalLikes:           totals.TotalLikes,
+	}, nil
+}
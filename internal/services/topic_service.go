@@ -0,0 +1,464 @@
+// internal/services/topic_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// defaultAICategory - Used when no category is enabled
+const defaultAICategory = "General"
+
+var (
+	ErrTopicNotFound     = errors.New("topic not found")
+	ErrTopicExists       = errors.New("a topic with this title already exists")
+	ErrCategoryNotFound  = errors.New("category not found")
+	ErrCategoryExists    = errors.New("a category with this name already exists")
+	ErrNoTopicAvailable  = errors.New("no enabled topic is available (all disabled or cooling down)")
+	ErrInvalidTopicInput = errors.New("title and name must not be empty")
+)
+
+type TopicRepo interface {
+	ListCategories(ctx context.Context) ([]*model.AICategory, error)
+	FindCategoryByID(ctx context.Context, categoryID string) (*model.AICategory, error)
+	FindCategoryByName(ctx context.Context, name string) (*model.AICategory, error)
+	CreateCategory(ctx context.Context, category *model.AICategory) error
+	UpdateCategory(ctx context.Context, category *model.AICategory) error
+	DeleteCategory(ctx context.Context, categoryID string) error
+	ListTopics(ctx context.Context) ([]*model.AITopic, error)
+	FindTopicByID(ctx context.Context, topicID string) (*model.AITopic, error)
+	FindTopicByTitle(ctx context.Context, title string) (*model.AITopic, error)
+	CreateTopic(ctx context.Context, topic *model.AITopic) error
+	UpdateTopic(ctx context.Context, topic *model.AITopic) error
+	DeleteTopic(ctx context.Context, topicID string) error
+	PickTopic(ctx context.Context) (*model.AITopic, error)
+	PickCategory(ctx context.Context) (*model.AICategory, error)
+	MarkTopicUsed(ctx context.Context, topicID, postID string) error
+}
+
+type TopicService struct {
+	repo  TopicRepo
+	audit *AuditService
+}
+
+func NewTopicService(repo TopicRepo, audit *AuditService) *TopicService {
+	return &TopicService{repo: repo, audit: audit}
+}
+
+// PickTopic - Weighted choice of topic and category for the next AI post
+func (s *TopicService) PickTopic(ctx context.Context) (*model.TopicSelection, error) {
+	topic, err := s.repo.PickTopic(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if topic == nil {
+		return nil, ErrNoTopicAvailable
+	}
+
+	selection := &model.TopicSelection{
+		TopicID: topic.ID.String(),
+		Topic:   topic.Title,
+	}
+
+	// Topics pinned to a category keep it; others get a weighted random one
+	if topic.CategoryName != nil {
+		selection.Category = *topic.CategoryName
+		return selection, nil
+	}
+
+	category, err := s.repo.PickCategory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if category != nil {
+		selection.Category = category.Name
+	} else {
+		selection.Category = defaultAICategory
+	}
+
+	return selection, nil
+}
+
+// SelectTopic - A caller-chosen topic instead of a weighted pick; a title from the pool keeps
+// its pinned category and gets its cooldown started, anything else is written about as given.
+// An empty category uses the pinned one, then a weighted pick.
+func (s *TopicService) SelectTopic(ctx context.Context, title, category string) (*model.TopicSelection, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, ErrInvalidTopicInput
+	}
+
+	selection := &model.TopicSelection{Topic: title, Category: strings.TrimSpace(category)}
+
+	topic, err := s.repo.FindTopicByTitle(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+	if topic != nil {
+		selection.TopicID = topic.ID.String()
+		selection.Topic = topic.Title
+		if selection.Category == "" && topic.CategoryName != nil {
+			selection.Category = *topic.CategoryName
+		}
+	}
+	if selection.Category != "" {
+		return selection, nil
+	}
+
+	picked, err := s.repo.PickCategory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if picked != nil {
+		selection.Category = picked.Name
+	} else {
+		selection.Category = defaultAICategory
+	}
+
+	return selection, nil
+}
+
+// MarkUsed - Start the cooldown of a topic that produced a post
+func (s *TopicService) MarkUsed(ctx context.Context, topicID, postID string) error {
+	return s.repo.MarkTopicUsed(ctx, topicID, postID)
+}
+
+// ListCategories - All categories
+func (s *TopicService) ListCategories(ctx context.Context) ([]model.AICategoryResponse, error) {
+	categories, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.AICategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		responses = append(responses, toAICategoryResponse(category))
+	}
+	return responses, nil
+}
+
+// CreateCategory - Add a category to the pool
+func (s *TopicService) CreateCategory(ctx context.Context, req *model.CreateAICategoryRequest) (*model.AICategoryResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrInvalidTopicInput
+	}
+
+	existing, err := s.repo.FindCategoryByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrCategoryExists
+	}
+
+	category := &model.AICategory{Name: name, Weight: 1, Enabled: true}
+	if req.Weight != nil {
+		category.Weight = *req.Weight
+	}
+	if req.Enabled != nil {
+		category.Enabled = *req.Enabled
+	}
+
+	if err := s.repo.CreateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	response := toAICategoryResponse(category)
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAICategoryCreate,
+		TargetType: "ai_category",
+		TargetID:   response.ID,
+		After:      response,
+	})
+	return &response, nil
+}
+
+// UpdateCategory - Rename, reweight or toggle a category
+func (s *TopicService) UpdateCategory(ctx context.Context, categoryID string, req *model.UpdateAICategoryRequest) (*model.AICategoryResponse, error) {
+	category, err := s.findCategory(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	before := toAICategoryResponse(category)
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, ErrInvalidTopicInput
+		}
+		if !strings.EqualFold(name, category.Name) {
+			existing, err := s.repo.FindCategoryByName(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				return nil, ErrCategoryExists
+			}
+		}
+		category.Name = name
+	}
+	if req.Weight != nil {
+		category.Weight = *req.Weight
+	}
+	if req.Enabled != nil {
+		category.Enabled = *req.Enabled
+	}
+
+	if err := s.repo.UpdateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	response := toAICategoryResponse(category)
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAICategoryUpdate,
+		TargetType: "ai_category",
+		TargetID:   response.ID,
+		Before:     before,
+		After:      response,
+	})
+	return &response, nil
+}
+
+// DeleteCategory - Remove a category from the pool
+func (s *TopicService) DeleteCategory(ctx context.Context, categoryID string) error {
+	category, err := s.findCategory(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteCategory(ctx, categoryID); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAICategoryDelete,
+		TargetType: "ai_category",
+		TargetID:   categoryID,
+		Before:     toAICategoryResponse(category),
+	})
+	return nil
+}
+
+func (s *TopicService) findCategory(ctx context.Context, categoryID string) (*model.AICategory, error) {
+	var categoryUUID pgtype.UUID
+	if err := categoryUUID.Scan(categoryID); err != nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	category, err := s.repo.FindCategoryByID(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, ErrCategoryNotFound
+	}
+	return category, nil
+}
+
+// ListTopics - All topics with their cooldown state
+func (s *TopicService) ListTopics(ctx context.Context) ([]model.AITopicResponse, error) {
+	topics, err := s.repo.ListTopics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.AITopicResponse, 0, len(topics))
+	for _, topic := range topics {
+		responses = append(responses, toAITopicResponse(topic))
+	}
+	return responses, nil
+}
+
+// CreateTopic - Add a topic to the pool
+func (s *TopicService) CreateTopic(ctx context.Context, req *model.CreateAITopicRequest) (*model.AITopicResponse, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, ErrInvalidTopicInput
+	}
+
+	existing, err := s.repo.FindTopicByTitle(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrTopicExists
+	}
+
+	topic := &model.AITopic{Title: title, Weight: 1, Enabled: true, CooldownHours: 72}
+	if req.Weight != nil {
+		topic.Weight = *req.Weight
+	}
+	if req.Enabled != nil {
+		topic.Enabled = *req.Enabled
+	}
+	if req.CooldownHours != nil {
+		topic.CooldownHours = *req.CooldownHours
+	}
+	if req.CategoryID != nil && *req.CategoryID != "" {
+		if err := s.assignCategory(ctx, topic, *req.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.CreateTopic(ctx, topic); err != nil {
+		return nil, err
+	}
+
+	response := toAITopicResponse(topic)
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAITopicCreate,
+		TargetType: "ai_topic",
+		TargetID:   response.ID,
+		After:      response,
+	})
+	return &response, nil
+}
+
+// UpdateTopic - Edit a topic; an empty category_id clears its category
+func (s *TopicService) UpdateTopic(ctx context.Context, topicID string, req *model.UpdateAITopicRequest) (*model.AITopicResponse, error) {
+	topic, err := s.findTopic(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+	before := toAITopicResponse(topic)
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, ErrInvalidTopicInput
+		}
+		if !strings.EqualFold(title, topic.Title) {
+			existing, err := s.repo.FindTopicByTitle(ctx, title)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				return nil, ErrTopicExists
+			}
+		}
+		topic.Title = title
+	}
+	if req.Weight != nil {
+		topic.Weight = *req.Weight
+	}
+	if req.Enabled != nil {
+		topic.Enabled = *req.Enabled
+	}
+	if req.CooldownHours != nil {
+		topic.CooldownHours = *req.CooldownHours
+	}
+	if req.CategoryID != nil {
+		if *req.CategoryID == "" {
+			topic.CategoryID = pgtype.UUID{}
+			topic.CategoryName = nil
+		} else if err := s.assignCategory(ctx, topic, *req.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpdateTopic(ctx, topic); err != nil {
+		return nil, err
+	}
+
+	response := toAITopicResponse(topic)
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAITopicUpdate,
+		TargetType: "ai_topic",
+		TargetID:   response.ID,
+		Before:     before,
+		After:      response,
+	})
+	return &response, nil
+}
+
+// DeleteTopic - Remove a topic from the pool
+func (s *TopicService) DeleteTopic(ctx context.Context, topicID string) error {
+	topic, err := s.findTopic(ctx, topicID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteTopic(ctx, topicID); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionAITopicDelete,
+		TargetType: "ai_topic",
+		TargetID:   topicID,
+		Before:     toAITopicResponse(topic),
+	})
+	return nil
+}
+
+func (s *TopicService) findTopic(ctx context.Context, topicID string) (*model.AITopic, error) {
+	var topicUUID pgtype.UUID
+	if err := topicUUID.Scan(topicID); err != nil {
+		return nil, ErrTopicNotFound
+	}
+
+	topic, err := s.repo.FindTopicByID(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+	if topic == nil {
+		return nil, ErrTopicNotFound
+	}
+	return topic, nil
+}
+
+func (s *TopicService) assignCategory(ctx context.Context, topic *model.AITopic, categoryID string) error {
+	category, err := s.findCategory(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+	topic.CategoryID = category.ID
+	topic.CategoryName = &category.Name
+	return nil
+}
+
+func toAICategoryResponse(category *model.AICategory) model.AICategoryResponse {
+	return model.AICategoryResponse{
+		ID:        category.ID.String(),
+		Name:      category.Name,
+		Weight:    category.Weight,
+		Enabled:   category.Enabled,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+func toAITopicResponse(topic *model.AITopic) model.AITopicResponse {
+	response := model.AITopicResponse{
+		ID:            topic.ID.String(),
+		Title:         topic.Title,
+		CategoryName:  topic.CategoryName,
+		Weight:        topic.Weight,
+		Enabled:       topic.Enabled,
+		CooldownHours: topic.CooldownHours,
+		LastUsedAt:    topic.LastUsedAt,
+		CreatedAt:     topic.CreatedAt,
+		UpdatedAt:     topic.UpdatedAt,
+	}
+	if topic.CategoryID.Valid {
+		categoryID := topic.CategoryID.String()
+		response.CategoryID = &categoryID
+	}
+	if topic.LastPostID.Valid {
+		lastPostID := topic.LastPostID.String()
+		response.LastPostID = &lastPostID
+	}
+	if topic.LastUsedAt != nil {
+		availableAt := topic.LastUsedAt.Add(time.Duration(topic.CooldownHours) * time.Hour)
+		if availableAt.After(time.Now()) {
+			response.AvailableAt = &availableAt
+		}
+	}
+	return response
+}
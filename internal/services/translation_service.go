@@ -0,0 +1,556 @@
+// internal/services/translation_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/britinogn/quillhub/internal/model"
+	"github.com/britinogn/quillhub/pkg/logger"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// DefaultPostLanguage - Language of posts created without one
+const DefaultPostLanguage = "en"
+
+// Where a translation's text came from
+const (
+	TranslationSourceAI     = "ai"
+	TranslationSourceManual = "manual"
+)
+
+// States of the latest AI translation request
+const (
+	TranslationStatusPending = "pending"
+	TranslationStatusReady   = "ready"
+	TranslationStatusFailed  = "failed"
+)
+
+const (
+	translationTimeout    = 3 * time.Minute
+	maxTranslationContent = 50000 // characters of post content sent to the model
+	maxLanguageCodeLen    = 35
+	translationWorkers    = 2 // AI translations running at once
+)
+
+var (
+	ErrInvalidLanguage       = errors.New("invalid language code")
+	ErrTranslationNotFound   = errors.New("translation not found")
+	ErrTranslationInProgress = errors.New("a translation into this language is already in progress")
+	ErrTranslationExists     = errors.New("this translation was written by hand; set overwrite to replace it")
+	ErrSameLanguage          = errors.New("the post is already written in this language")
+	ErrInvalidTranslation    = errors.New("invalid translation")
+	ErrTranslationTooLong    = errors.New("post is too long to translate")
+)
+
+type TranslationRepo interface {
+	Get(ctx context.Context, postID, language string) (*model.PostTranslation, error)
+	ListByPost(ctx context.Context, postID string) ([]*model.PostTranslation, error)
+	ListReadable(ctx context.Context, postIDs []string, language string) ([]*model.PostTranslation, error)
+	StartAI(ctx context.Context, postID, language, requestedBy string) (*model.PostTranslation, error)
+	CompleteAI(ctx context.Context, translation *model.PostTranslation) (bool, error)
+	FailAI(ctx context.Context, translationID, reason string) error
+	FailPending(ctx context.Context) (int64, error)
+	SaveManual(ctx context.Context, translation *model.PostTranslation) error
+	Delete(ctx context.Context, postID, language string) error
+}
+
+type TranslationPostRepo interface {
+	FindByID(ctx context.Context, postID string) (*model.Post, error)
+}
+
+// TranslationService - Post translations written by the AI or by the author, and language negotiation for readers
+type TranslationService struct {
+	repo  TranslationRepo
+	posts TranslationPostRepo
+	ai    *AIService
+	audit *AuditService
+	slots chan struct{}
+}
+
+func NewTranslationService(repo TranslationRepo, posts TranslationPostRepo, ai *AIService, audit *AuditService) *TranslationService {
+	return &TranslationService{
+		repo:  repo,
+		posts: posts,
+		ai:    ai,
+		audit: audit,
+		slots: make(chan struct{}, translationWorkers),
+	}
+}
+
+// NormalizeLanguage - Canonical BCP 47 form of a language code, e.g. "PT_br" → "pt-BR"
+func NormalizeLanguage(code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if code == "" || len(code) > maxLanguageCodeLen {
+		return "", fmt.Errorf("%w: %q", ErrInvalidLanguage, code)
+	}
+
+	tag, err := language.Parse(code)
+	if err != nil || tag == language.Und {
+		return "", fmt.Errorf("%w: %q", ErrInvalidLanguage, code)
+	}
+	return tag.String(), nil
+}
+
+// RecoverStale - Fail translations a previous process left pending so they can be requested again
+func (s *TranslationService) RecoverStale(ctx context.Context) {
+	count, err := s.repo.FailPending(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "[TRANSLATION-SERVICE] ❌ Failed to close pending translations: %v", err)
+		return
+	}
+	if count > 0 {
+		logger.Warnf(ctx, "[TRANSLATION-SERVICE] ⚠️  Marked %d interrupted translation(s) as failed", count)
+	}
+}
+
+// RequestTranslation - Start an AI translation of a post in the background (author or admin)
+func (s *TranslationService) RequestTranslation(ctx context.Context, postID, userID, role string, req *model.RequestTranslationRequest) (*model.PostTranslationResponse, error) {
+	lang, err := NormalizeLanguage(req.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.findPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.AuthorID.String() != userID && role != "admin" {
+		return nil, ErrUnauthorizedPost
+	}
+	if lang == post.Language {
+		return nil, ErrSameLanguage
+	}
+	if utf8.RuneCountInString(post.Content) > maxTranslationContent {
+		return nil, fmt.Errorf("%w (max %d characters)", ErrTranslationTooLong, maxTranslationContent)
+	}
+	if s.ai.Provider() == nil {
+		return nil, ErrNoLLMProvider
+	}
+	if err := s.ai.Usage().CheckBudget(ctx, UsageFeatureTranslation); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.Get(ctx, postID, lang)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Source == TranslationSourceManual && !req.Overwrite {
+		return nil, ErrTranslationExists
+	}
+
+	translation, err := s.repo.StartAI(ctx, postID, lang, userID)
+	if err != nil {
+		return nil, err
+	}
+	if translation == nil {
+		return nil, ErrTranslationInProgress
+	}
+
+	logger.Infof(ctx, "[TRANSLATION-SERVICE] 🌐 Translating post %s into %s", postID, lang)
+	go s.translate(post, translation)
+
+	return toTranslationResponse(translation, post), nil
+}
+
+// UpdateTranslation - Create or edit a translation by hand (author only); it is no longer touched by the AI
+func (s *TranslationService) UpdateTranslation(ctx context.Context, postID, lang, userID string, req *model.UpdateTranslationRequest) (*model.PostTranslationResponse, error) {
+	lang, err := NormalizeLanguage(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.findPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.AuthorID.String() != userID {
+		return nil, ErrUnauthorizedPost
+	}
+	if lang == post.Language {
+		return nil, ErrSameLanguage
+	}
+
+	existing, err := s.repo.Get(ctx, postID, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	translation := &model.PostTranslation{PostID: post.ID, Language: lang}
+	if existing != nil {
+		translation.Title = existing.Title
+		translation.Content = existing.Content
+		translation.Tags = existing.Tags
+	}
+
+	if req.Title != nil {
+		translation.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Content != nil {
+		translation.Content = strings.TrimSpace(*req.Content)
+	}
+	if req.Tags != nil {
+		translation.Tags = cleanGeneratedTags(req.Tags)
+	}
+
+	if translation.Title == "" || translation.Content == "" {
+		return nil, fmt.Errorf("%w: title and content are required", ErrInvalidTranslation)
+	}
+	if utf8.RuneCountInString(translation.Title) > maxGeneratedTitleLen {
+		return nil, fmt.Errorf("%w: title must not exceed %d characters", ErrInvalidTranslation, maxGeneratedTitleLen)
+	}
+
+	_ = translation.TranslatedBy.Scan(userID)
+	updatedAt := post.UpdatedAt
+	translation.SourceUpdatedAt = &updatedAt
+
+	if err := s.repo.SaveManual(ctx, translation); err != nil {
+		return nil, err
+	}
+
+	return toTranslationResponse(translation, post), nil
+}
+
+// DeleteTranslation - Remove a translation (author or admin)
+func (s *TranslationService) DeleteTranslation(ctx context.Context, postID, lang, userID, role string) error {
+	lang, err := NormalizeLanguage(lang)
+	if err != nil {
+		return err
+	}
+
+	post, err := s.findPost(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post.AuthorID.String() != userID && role != "admin" {
+		return ErrUnauthorizedPost
+	}
+
+	existing, err := s.repo.Get(ctx, postID, lang)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrTranslationNotFound
+	}
+
+	if err := s.repo.Delete(ctx, postID, lang); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, AuditEvent{
+		Action:     AuditActionTranslationDelete,
+		TargetType: "post",
+		TargetID:   postID,
+		Before:     toTranslationResponse(existing, post),
+	})
+
+	return nil
+}
+
+// ListTranslations - A public post's translations without their text
+func (s *TranslationService) ListTranslations(ctx context.Context, postID string) ([]model.PostTranslationResponse, error) {
+	post, err := s.findPublicPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := s.repo.ListByPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.PostTranslationResponse, 0, len(translations))
+	for _, translation := range translations {
+		response := toTranslationResponse(translation, post)
+		response.Title = ""
+		response.Content = ""
+		response.Tags = nil
+		responses = append(responses, *response)
+	}
+	return responses, nil
+}
+
+// GetTranslation - One translation of a public post
+func (s *TranslationService) GetTranslation(ctx context.Context, postID, lang string) (*model.PostTranslationResponse, error) {
+	lang, err := NormalizeLanguage(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.findPublicPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	translation, err := s.repo.Get(ctx, postID, lang)
+	if err != nil {
+		return nil, err
+	}
+	if translation == nil {
+		return nil, ErrTranslationNotFound
+	}
+
+	return toTranslationResponse(translation, post), nil
+}
+
+// Localize - Show a post in the best language for an Accept-Language style preference ("fr", "de-CH, de;q=0.8");
+// without a match the original is kept. Also lists the languages the post can be read in.
+func (s *TranslationService) Localize(ctx context.Context, post *model.Post, preference string) error {
+	translations, err := s.repo.ListByPost(ctx, post.ID.String())
+	if err != nil {
+		return err
+	}
+
+	readable := []*model.PostTranslation{nil} // index 0 is the original
+	supported := []language.Tag{language.Make(post.Language)}
+	post.AvailableLanguages = []string{post.Language}
+	for _, translation := range translations {
+		if translation.Content == "" || translation.Language == post.Language {
+			continue
+		}
+		readable = append(readable, translation)
+		supported = append(supported, language.Make(translation.Language))
+		post.AvailableLanguages = append(post.AvailableLanguages, translation.Language)
+	}
+
+	if strings.TrimSpace(preference) == "" || len(readable) == 1 {
+		return nil
+	}
+
+	wanted, _, err := language.ParseAcceptLanguage(preference)
+	if err != nil || len(wanted) == 0 {
+		// A malformed header is not worth failing the read over
+		return nil
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(wanted...)
+	if confidence != language.No && index > 0 {
+		applyTranslation(post, readable[index])
+	}
+	return nil
+}
+
+// LocalizeList - Show posts in lang wherever a translation exists; lang must be normalized
+func (s *TranslationService) LocalizeList(ctx context.Context, posts []*model.Post, lang string) error {
+	if lang == "" {
+		return nil
+	}
+
+	byID := make(map[string]*model.Post, len(posts))
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		if post.Language == lang {
+			continue
+		}
+		id := post.ID.String()
+		byID[id] = post
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	translations, err := s.repo.ListReadable(ctx, ids, lang)
+	if err != nil {
+		return err
+	}
+	for _, translation := range translations {
+		if post := byID[translation.PostID.String()]; post != nil {
+			applyTranslation(post, translation)
+		}
+	}
+	return nil
+}
+
+// translate - Ask the model for the translation and save it; runs in the background
+func (s *TranslationService) translate(post *model.Post, translation *model.PostTranslation) {
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), translationTimeout)
+	defer cancel()
+
+	postID := post.ID.String()
+	fail := func(reason string) {
+		if err := s.repo.FailAI(context.WithoutCancel(ctx), translation.ID.String(), reason); err != nil {
+			logger.Warnf(ctx, "[TRANSLATION-SERVICE] ⚠️  %v", err)
+		}
+	}
+
+	temperature := 0.2
+	resp, err := s.ai.Complete(ctx, LLMRequest{
+		Prompt:      translationPrompt(post, translation.Language),
+		JSON:        true,
+		Temperature: &temperature,
+		Feature:     UsageFeatureTranslation,
+		UserID:      translation.TranslatedBy.String(),
+	})
+	if err != nil {
+		logger.Errorf(ctx, "[TRANSLATION-SERVICE] ❌ Translation of post %s into %s failed: %v", postID, translation.Language, err)
+		if errors.Is(err, ErrAIBudgetExceeded) {
+			fail("the AI budget for translations is used up")
+		} else {
+			fail("the AI provider returned an error")
+		}
+		return
+	}
+
+	title, content, tags, err := parseTranslation(resp.Text)
+	if err != nil {
+		logger.Errorf(ctx, "[TRANSLATION-SERVICE] ❌ Unusable translation of post %s (%v). Raw response: %s", postID, err, resp.Text)
+		fail(err.Error())
+		return
+	}
+
+	translation.Title = title
+	translation.Content = content
+	translation.Tags = tags
+	translation.Model = &resp.Model
+	updatedAt := post.UpdatedAt
+	translation.SourceUpdatedAt = &updatedAt
+
+	saved, err := s.repo.CompleteAI(ctx, translation)
+	if err != nil {
+		logger.Errorf(ctx, "[TRANSLATION-SERVICE] ❌ %v", err)
+		fail("the translation could not be saved")
+		return
+	}
+	if !saved {
+		logger.Infof(ctx, "[TRANSLATION-SERVICE] Translation of post %s into %s was edited by hand meanwhile, discarding the AI result", postID, translation.Language)
+		return
+	}
+
+	logger.Infof(ctx, "[TRANSLATION-SERVICE] ✅ Translated post %s into %s", postID, translation.Language)
+}
+
+func translationPrompt(post *model.Post, target string) string {
+	tags := "(none)"
+	if len(post.Tags) > 0 {
+		tags = strings.Join(post.Tags, ", ")
+	}
+
+	return fmt.Sprintf(`Translate the blog post below from %s into %s.
+
+Rules:
+- Translate the title, the content and every tag
+- Keep the markdown structure, links and image URLs exactly as they are
+- Do not translate code blocks, inline code or product names
+- Keep the author's tone; do not add, drop or summarize anything
+- Tags stay short and lowercase
+
+Return ONLY JSON: {"title": "...", "content": "...", "tags": ["..."]}
+
+Title: %q
+Tags: %s
+Content:
+"""
+%s
+"""`, languageName(post.Language), languageName(target), post.Title, tags, post.Content)
+}
+
+// parseTranslation - Decode the model's answer; title and content are required
+func parseTranslation(text string) (string, string, []string, error) {
+	var raw generatedPostJSON
+	if err := json.Unmarshal([]byte(cleanJSONResponse(text)), &raw); err != nil {
+		return "", "", nil, fmt.Errorf("%w: the AI answer was not valid JSON", ErrInvalidTranslation)
+	}
+
+	tags, err := decodeGeneratedTags(raw.Tags)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("%w: %v", ErrInvalidTranslation, err)
+	}
+
+	title := cleanGeneratedTitle(raw.Title)
+	content := strings.TrimSpace(raw.Content)
+	switch {
+	case title == "" || content == "":
+		return "", "", nil, fmt.Errorf("%w: the AI answer is missing the title or content", ErrInvalidTranslation)
+	case utf8.RuneCountInString(title) > maxGeneratedTitleLen:
+		return "", "", nil, fmt.Errorf("%w: the translated title is longer than %d characters", ErrInvalidTranslation, maxGeneratedTitleLen)
+	}
+
+	return title, content, cleanGeneratedTags(tags), nil
+}
+
+// languageName - English name of a language for prompts, e.g. "pt-BR" → "Brazilian Portuguese (pt-BR)"
+func languageName(code string) string {
+	tag := language.Make(code)
+	if name := display.English.Tags().Name(tag); name != "" {
+		return fmt.Sprintf("%s (%s)", name, code)
+	}
+	return code
+}
+
+// applyTranslation - Swap a post's text for a translation; the original language is kept alongside
+func applyTranslation(post *model.Post, translation *model.PostTranslation) {
+	original := post.Language
+	post.OriginalLanguage = &original
+	post.Language = translation.Language
+	post.Title = translation.Title
+	post.Content = translation.Content
+	if len(translation.Tags) > 0 {
+		post.Tags = translation.Tags
+	}
+}
+
+func (s *TranslationService) findPost(ctx context.Context, postID string) (*model.Post, error) {
+	var id pgtype.UUID
+	if err := id.Scan(postID); err != nil {
+		return nil, ErrPostNotFound
+	}
+
+	post, err := s.posts.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post == nil {
+		return nil, ErrPostNotFound
+	}
+	return post, nil
+}
+
+// findPublicPost - Posts held for review have no public translations either
+func (s *TranslationService) findPublicPost(ctx context.Context, postID string) (*model.Post, error) {
+	post, err := s.findPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if heldForReview(post) {
+		return nil, ErrPostNotFound
+	}
+	return post, nil
+}
+
+func toTranslationResponse(translation *model.PostTranslation, post *model.Post) *model.PostTranslationResponse {
+	response := &model.PostTranslationResponse{
+		ID:        translation.ID.String(),
+		PostID:    translation.PostID.String(),
+		Language:  translation.Language,
+		Title:     translation.Title,
+		Content:   translation.Content,
+		Tags:      translation.Tags,
+		Source:    translation.Source,
+		Status:    translation.Status,
+		Error:     translation.Error,
+		Model:     translation.Model,
+		Available: translation.Content != "",
+		CreatedAt: translation.CreatedAt,
+		UpdatedAt: translation.UpdatedAt,
+	}
+	if translation.TranslatedBy.Valid {
+		translatedBy := translation.TranslatedBy.String()
+		response.TranslatedBy = &translatedBy
+	}
+	if translation.SourceUpdatedAt != nil && post != nil {
+		response.Outdated = post.UpdatedAt.After(*translation.SourceUpdatedAt)
+	}
+	return response
+}
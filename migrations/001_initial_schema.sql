@@ -1,60 +0,0 @@
-
--- -- Create users table
--- CREATE TABLE IF NOT EXISTS users (
---     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
---     name VARCHAR(250) NOT NULL,
---     username VARCHAR(255) UNIQUE NOT NULL,
---     email VARCHAR(255) UNIQUE NOT NULL,
---     password TEXT NOT NULL,
---     role VARCHAR(50) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin', 'bot')),
---     gender VARCHAR(25),
---     profile_url VARCHAR(255),
---     bio TEXT,
---     is_verified BOOLEAN DEFAULT false,
---     is_active BOOLEAN DEFAULT true,
---     last_login TIMESTAMP,
---     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
---     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
--- );
-
--- -- Create posts table
--- CREATE TABLE IF NOT EXISTS posts (
---     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
---     author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
---     title VARCHAR(200) NOT NULL,
---     content TEXT NOT NULL,
---     image_url TEXT[],
---     tags TEXT[],
---     category VARCHAR(100),
---     is_published BOOLEAN DEFAULT true,
---     view_count INTEGER DEFAULT 0,
---     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
---     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
--- );
-
--- -- Create comments table
--- CREATE TABLE IF NOT EXISTS comments (
---     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
---     post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
---     author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
---     text TEXT NOT NULL,
---     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
---     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
--- );
-
--- -- Create likes table
--- CREATE TABLE IF NOT EXISTS likes (
---     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
---     post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
---     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
---     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
---     UNIQUE(post_id, user_id)
--- );
-
--- -- Create indexes
--- CREATE INDEX IF NOT EXISTS idx_posts_author_id ON posts(author_id);
--- CREATE INDEX IF NOT EXISTS idx_posts_created_at ON posts(created_at DESC);
--- CREATE INDEX IF NOT EXISTS idx_comments_post_id ON comments(post_id);
--- CREATE INDEX IF NOT EXISTS idx_comments_author_id ON comments(author_id);
--- CREATE INDEX IF NOT EXISTS idx_likes_post_id ON likes(post_id);
--- CREATE INDEX IF NOT EXISTS idx_likes_user_id ON likes(user_id);
\ No newline at end of file
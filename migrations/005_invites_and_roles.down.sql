@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS invite_codes;
+
+-- Moderators keep their rows; NOT VALID only checks new writes
+ALTER TABLE users DROP CONSTRAINT IF EXISTS check_role;
+ALTER TABLE users ADD CONSTRAINT check_role CHECK (role IN ('user', 'admin', 'bot')) NOT VALID;
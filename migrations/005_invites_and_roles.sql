@@ -0,0 +1,19 @@
+-- Single-use invite codes for invite-only registration
+CREATE TABLE IF NOT EXISTS invite_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(64) NOT NULL UNIQUE,
+    email VARCHAR(255),                 -- optional: only this address may redeem it
+    role VARCHAR(50) NOT NULL DEFAULT 'user',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    used_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    used_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_invite_codes_created_at ON invite_codes(created_at DESC);
+
+-- One role constraint for every role the application uses
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS check_role;
+ALTER TABLE users ADD CONSTRAINT check_role CHECK (role IN ('user', 'moderator', 'admin', 'bot'));
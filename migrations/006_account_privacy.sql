@@ -0,0 +1,26 @@
+-- Personal data exports (ZIP archives built in the background)
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    file_path TEXT,
+    file_size BIGINT,
+    error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    expires_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_id ON data_exports(user_id);
+CREATE INDEX IF NOT EXISTS idx_data_exports_status ON data_exports(status);
+
+-- Account deletion requests wait out a cool-off period before they become final
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_mode VARCHAR(20)
+    CHECK (deletion_mode IN ('delete', 'anonymize'));
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_requested_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_for TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled_for ON users(deletion_scheduled_for)
+    WHERE deletion_scheduled_for IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ai_topics;
+DROP TABLE IF EXISTS ai_categories;
@@ -0,0 +1,126 @@
+-- Weighted topic and category pools for AI-generated posts
+CREATE TABLE IF NOT EXISTS ai_categories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    weight INTEGER NOT NULL DEFAULT 1 CHECK (weight > 0),
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS ai_topics (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    title VARCHAR(255) NOT NULL UNIQUE,
+    category_id UUID REFERENCES ai_categories(id) ON DELETE SET NULL, -- optional: always post under this category
+    weight INTEGER NOT NULL DEFAULT 1 CHECK (weight > 0),
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    cooldown_hours INTEGER NOT NULL DEFAULT 72 CHECK (cooldown_hours >= 0),
+    last_used_at TIMESTAMP,
+    last_post_id UUID REFERENCES posts(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_topics_enabled ON ai_topics(enabled);
+
+-- Seed with the pools that used to be hard-coded in AIService
+INSERT INTO ai_categories (name) VALUES
+    ('Backend Development'),
+    ('DevOps'),
+    ('Architecture'),
+    ('Security'),
+    ('Databases'),
+    ('Cloud Computing'),
+    ('Best Practices'),
+    ('Microservices'),
+    ('API Design'),
+    ('System Design'),
+    ('Performance Optimization'),
+    ('Scalability'),
+    ('Distributed Systems'),
+    ('Containerization'),
+    ('Orchestration'),
+    ('Infrastructure as Code'),
+    ('CI/CD Pipelines'),
+    ('Observability & Monitoring'),
+    ('Logging & Tracing'),
+    ('Testing Strategies'),
+    ('Fun & Weird Facts'),
+    ('Travel & Hidden Places'),
+    ('Jokes & Humor'),
+    ('Psychology & Mind'),
+    ('Countries & Cultures'),
+    ('Health & Body Science'),
+    ('Animals & Nature'),
+    ('Life Hacks & Tips'),
+    ('Memes & Internet Culture'),
+    ('History & True Stories'),
+    ('Love & Relationships'),
+    ('Food & Eating Habits'),
+    ('Daily Life & Adulthood'),
+    ('Conspiracy & Mysteries'),
+    ('Optical Illusions & Brain Tricks'),
+    ('Education & Learning Hacks'),
+    ('Budget & Money Saving'),
+    ('Strange Traditions'),
+    ('Dark Humor & Edgy Jokes'),
+    ('Personal Development')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO ai_topics (title) VALUES
+    ('Go programming best practices'),
+    ('Building scalable microservices'),
+    ('Docker and containerization'),
+    ('Kubernetes deployment strategies'),
+    ('RESTful API design patterns'),
+    ('PostgreSQL performance optimization'),
+    ('JWT authentication implementation'),
+    ('GraphQL vs REST APIs'),
+    ('CI/CD pipeline setup'),
+    ('Cloud architecture patterns'),
+    ('Git workflow strategies'),
+    ('Test-driven development'),
+    ('Database indexing strategies'),
+    ('Caching strategies with Redis'),
+    ('Message queues and async processing'),
+    ('gRPC vs HTTP/REST'),
+    ('Serverless architecture'),
+    ('API rate limiting techniques'),
+    ('Monitoring and observability'),
+    ('Security best practices in web development'),
+    ('Weird food combinations people actually love'),
+    ('Hidden gems in your city you probably never noticed'),
+    ('The psychology behind why we procrastinate (and how to stop)'),
+    ('Best cheap date ideas that actually work'),
+    ('Why adults still love cartoons and nostalgic games'),
+    ('The most underrated travel destinations in 2025'),
+    ('How to travel the world on a tight budget'),
+    ('Scary travel stories that actually happened'),
+    ('Beautiful small towns you should visit before they become tourist traps'),
+    ('The world''s strangest museums and why they''re worth seeing'),
+    ('Dark humor jokes that are too good to be true'),
+    ('Dad jokes so bad they''re actually good'),
+    ('Memes that perfectly describe adult life in 2025'),
+    ('The funniest autocorrect fails of all time'),
+    ('Why Gen Z humor is completely different from Millennials'),
+    ('Mind-blowing historical facts most people don''t know'),
+    ('How the human brain actually learns new things'),
+    ('The real story behind everyday inventions'),
+    ('Why some people are naturally good at languages'),
+    ('The science of why music makes us feel emotions'),
+    ('Countries with the weirdest laws still in effect'),
+    ('The world''s happiest countries — and why they’re happy'),
+    ('Secret traditions only locals know about'),
+    ('Countries where time moves differently (literally)'),
+    ('The most polite and most rude countries according to travelers'),
+    ('Why we yawn — and why it’s contagious'),
+    ('The weirdest things doctors have found inside people'),
+    ('How your body changes when you fall in love'),
+    ('Foods that are secretly good for your brain'),
+    ('Why some people never get sick (genetics or luck?)'),
+    ('Animals with the strangest superpowers'),
+    ('Why cats are secretly plotting world domination'),
+    ('The psychology of why we love true crime'),
+    ('Conspiracy theories that turned out to be true'),
+    ('The most satisfying optical illusions ever')
+ON CONFLICT (title) DO NOTHING;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ai_generation_attempts;
+DROP TABLE IF EXISTS ai_generation_jobs;
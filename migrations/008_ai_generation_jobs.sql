@@ -0,0 +1,38 @@
+-- One row per AI post generation, with every attempt recorded for debugging
+CREATE TABLE IF NOT EXISTS ai_generation_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    trigger VARCHAR(20) NOT NULL DEFAULT 'schedule',   -- schedule | manual
+    status VARCHAR(20) NOT NULL DEFAULT 'running'
+        CHECK (status IN ('running', 'succeeded', 'failed')),
+    topic_id UUID REFERENCES ai_topics(id) ON DELETE SET NULL,
+    topic TEXT NOT NULL,
+    category VARCHAR(100),
+    provider VARCHAR(50),
+    model VARCHAR(100),
+    prompt TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    raw_response TEXT,
+    error TEXT,
+    latency_ms BIGINT,
+    post_id UUID REFERENCES posts(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_generation_jobs_created_at ON ai_generation_jobs(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_ai_generation_jobs_status ON ai_generation_jobs(status);
+
+CREATE TABLE IF NOT EXISTS ai_generation_attempts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    job_id UUID NOT NULL REFERENCES ai_generation_jobs(id) ON DELETE CASCADE,
+    attempt INTEGER NOT NULL,
+    model VARCHAR(100),
+    raw_response TEXT,
+    error TEXT,
+    error_kind VARCHAR(20),             -- api | parse
+    latency_ms BIGINT NOT NULL,
+    input_tokens INTEGER NOT NULL DEFAULT 0,
+    output_tokens INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(job_id, attempt)
+);
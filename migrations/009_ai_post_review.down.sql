@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_posts_review_status;
+ALTER TABLE posts DROP COLUMN IF EXISTS generation_job_id;
+ALTER TABLE posts DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS reviewed_by;
+ALTER TABLE posts DROP COLUMN IF EXISTS review_reason;
+ALTER TABLE posts DROP COLUMN IF EXISTS review_status;
+ALTER TABLE posts DROP COLUMN IF EXISTS ai_generated;
@@ -0,0 +1,15 @@
+-- AI disclosure flag and human review queue for bot posts
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS ai_generated BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS review_status VARCHAR(20)
+    CHECK (review_status IN ('pending', 'approved', 'rejected'));     -- NULL: never went through review
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS review_reason TEXT;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS generation_job_id UUID REFERENCES ai_generation_jobs(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_posts_review_status ON posts(review_status) WHERE review_status IS NOT NULL;
+
+-- Everything the bot published before this migration was AI-generated
+UPDATE posts SET ai_generated = true
+WHERE ai_generated = false
+    AND author_id IN (SELECT id FROM users WHERE role = 'bot');
@@ -0,0 +1,12 @@
+-- Per-user daily usage of the AI writing assistant, for quotas and cost tracking
+CREATE TABLE IF NOT EXISTS ai_assistant_usage (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    requests INTEGER NOT NULL DEFAULT 0,
+    input_tokens BIGINT NOT NULL DEFAULT 0,
+    output_tokens BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_assistant_usage_day ON ai_assistant_usage(day);
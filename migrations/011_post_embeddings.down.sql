@@ -0,0 +1,3 @@
+-- The pgvector extension is left installed; other database objects may use it
+DROP INDEX IF EXISTS idx_posts_search;
+DROP TABLE IF EXISTS post_embeddings;
@@ -0,0 +1,24 @@
+-- Post embeddings for related posts and semantic search.
+-- pgvector is used for similarity when installed; otherwise plain REAL[] math is used.
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS vector;
+EXCEPTION WHEN OTHERS THEN
+    RAISE NOTICE 'pgvector is not available, similarity falls back to float arrays';
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS post_embeddings (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    model VARCHAR(100) NOT NULL,
+    dims INTEGER NOT NULL,
+    embedding REAL[] NOT NULL,              -- L2-normalized, so cosine similarity is a dot product
+    content_hash CHAR(32) NOT NULL,         -- md5 of the embedded text; a mismatch means re-embed
+    embedded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_embeddings_model ON post_embeddings(model);
+
+-- Keyword search
+CREATE INDEX IF NOT EXISTS idx_posts_search ON posts
+    USING GIN (to_tsvector('english', title || ' ' || content));
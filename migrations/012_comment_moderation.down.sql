@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_comments_moderation_status;
+ALTER TABLE comments DROP COLUMN IF EXISTS moderated_at;
+ALTER TABLE comments DROP COLUMN IF EXISTS moderated_by;
+ALTER TABLE comments DROP COLUMN IF EXISTS moderation_note;
+ALTER TABLE comments DROP COLUMN IF EXISTS moderation_source;
+ALTER TABLE comments DROP COLUMN IF EXISTS moderation_reasons;
+ALTER TABLE comments DROP COLUMN IF EXISTS spam_score;
+ALTER TABLE comments DROP COLUMN IF EXISTS toxicity_score;
+ALTER TABLE comments DROP COLUMN IF EXISTS moderation_status;
@@ -0,0 +1,13 @@
+-- Moderation state of comments: rule and AI scores, and the moderator's decision
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS moderation_status VARCHAR(20) NOT NULL DEFAULT 'approved'
+    CHECK (moderation_status IN ('approved', 'pending', 'rejected'));
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS toxicity_score REAL;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS spam_score REAL;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS moderation_reasons TEXT[];
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS moderation_source VARCHAR(20);    -- rules | llm
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS moderation_note TEXT;             -- moderator's reason on reject
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS moderated_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS moderated_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_comments_moderation_status ON comments(moderation_status, created_at)
+    WHERE moderation_status <> 'approved';
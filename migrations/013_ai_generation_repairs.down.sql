@@ -0,0 +1 @@
+ALTER TABLE ai_generation_attempts DROP COLUMN IF EXISTS purpose;
@@ -0,0 +1,4 @@
+-- Generation attempts can be repair round-trips that fix an invalid answer
+ALTER TABLE ai_generation_attempts ADD COLUMN IF NOT EXISTS purpose VARCHAR(20) NOT NULL DEFAULT 'generate';  -- generate | repair
+
+COMMENT ON COLUMN ai_generation_attempts.error_kind IS 'api | parse | validation';
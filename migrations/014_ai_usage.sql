@@ -0,0 +1,21 @@
+-- One row per LLM call with token counts and estimated cost, for budgets and the admin dashboard
+CREATE TABLE IF NOT EXISTS ai_usage_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    feature VARCHAR(50) NOT NULL,                   -- post_generation | assistant | moderation | ...
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    provider VARCHAR(50) NOT NULL,
+    model VARCHAR(100) NOT NULL,
+    input_tokens INTEGER NOT NULL DEFAULT 0,
+    output_tokens INTEGER NOT NULL DEFAULT 0,
+    estimated_tokens BOOLEAN NOT NULL DEFAULT false, -- provider reported no usage, counts are guessed
+    cost_usd NUMERIC(12, 6) NOT NULL DEFAULT 0,
+    success BOOLEAN NOT NULL DEFAULT true,
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC')
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_usage_events_created_at ON ai_usage_events(created_at);
+CREATE INDEX IF NOT EXISTS idx_ai_usage_events_feature ON ai_usage_events(feature, created_at);
+CREATE INDEX IF NOT EXISTS idx_ai_usage_events_user ON ai_usage_events(user_id, created_at) WHERE user_id IS NOT NULL;
+
+COMMENT ON COLUMN ai_generation_attempts.error_kind IS 'api | parse | validation | budget';
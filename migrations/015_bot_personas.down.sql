@@ -0,0 +1,2 @@
+ALTER TABLE ai_generation_jobs DROP COLUMN IF EXISTS persona_id;
+DROP TABLE IF EXISTS ai_personas;
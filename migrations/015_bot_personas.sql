@@ -0,0 +1,27 @@
+-- Bot personas: each writes as its own user with its own prompt, model settings and schedule
+CREATE TABLE IF NOT EXISTS ai_personas (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) UNIQUE NOT NULL,
+    description TEXT,
+    user_id UUID UNIQUE NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    prompt_template TEXT,                 -- Go text/template; NULL uses the built-in template
+    tone VARCHAR(255),                    -- NULL lets the model adapt tone to the topic
+    model VARCHAR(100),                   -- NULL uses LLM_MODEL
+    temperature NUMERIC(3, 2) CHECK (temperature >= 0 AND temperature <= 2),
+    max_tokens INTEGER CHECK (max_tokens > 0),
+    min_words INTEGER CHECK (min_words > 0),
+    max_words INTEGER CHECK (max_words > 0),
+    schedule_interval VARCHAR(50),        -- Go duration; NULL with no cron follows the main auto-poster schedule
+    schedule_cron VARCHAR(100),
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    is_default BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (schedule_interval IS NULL OR schedule_cron IS NULL)
+);
+
+-- At most one default persona (the original QuillHub AI bot)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ai_personas_default ON ai_personas(is_default) WHERE is_default;
+
+ALTER TABLE ai_generation_jobs
+    ADD COLUMN IF NOT EXISTS persona_id UUID REFERENCES ai_personas(id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS post_translations;
+DROP INDEX IF EXISTS idx_posts_language;
+ALTER TABLE posts DROP COLUMN IF EXISTS language;
@@ -0,0 +1,26 @@
+-- Post translations: one row per post and language (BCP 47 code such as 'fr' or 'pt-BR')
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS language VARCHAR(35) NOT NULL DEFAULT 'en';
+
+CREATE INDEX IF NOT EXISTS idx_posts_language ON posts(language);
+
+CREATE TABLE IF NOT EXISTS post_translations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    language VARCHAR(35) NOT NULL,
+    title VARCHAR(200) NOT NULL DEFAULT '',  -- empty until the first translation is saved
+    content TEXT NOT NULL DEFAULT '',
+    tags TEXT[],
+    source VARCHAR(20) NOT NULL CHECK (source IN ('ai', 'manual')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'ready', 'failed')),  -- of the latest AI request
+    error TEXT,
+    model VARCHAR(100),
+    translated_by UUID REFERENCES users(id) ON DELETE SET NULL,  -- who requested or wrote it
+    source_updated_at TIMESTAMP,  -- posts.updated_at when the text was translated
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (post_id, language)
+);
+
+-- Readers see a translation once it has text; a re-translation keeps the old text until it finishes
+CREATE INDEX IF NOT EXISTS idx_post_translations_language ON post_translations(language) WHERE content <> '';
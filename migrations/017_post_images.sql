@@ -0,0 +1,21 @@
+-- Accessibility text for post images: AI descriptions plus the author's own overrides.
+-- posts.image_url stays the list of images and their order; rows follow it by URL.
+CREATE TABLE IF NOT EXISTS post_images (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    alt_text TEXT,                -- written by the AI
+    caption TEXT,
+    author_alt_text TEXT,         -- set by the author; wins over the AI text
+    author_caption TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'ready', 'failed')),
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    model VARCHAR(100),
+    described_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (post_id, url)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_images_queue ON post_images(status, updated_at) WHERE status <> 'ready';
@@ -0,0 +1,8 @@
+-- Bot replies: personas answer top-level comments on their own posts.
+-- A reply points at the comment it answers; is_ai_reply marks text written by the AI.
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES comments(id) ON DELETE CASCADE;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS is_ai_reply BOOLEAN NOT NULL DEFAULT false;
+-- moderation_source is now rules | llm | ai_reply (AI replies held for approval)
+
+CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON comments(parent_id) WHERE parent_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_ai_replies ON comments(post_id, created_at) WHERE is_ai_reply;
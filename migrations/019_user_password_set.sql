@@ -0,0 +1,9 @@
+-- Accounts created through an external identity have no usable password; they cannot unlink
+-- their last identity. Existing ones are recognized by an identity linked when they signed up.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_set BOOLEAN NOT NULL DEFAULT true;
+
+UPDATE users u SET password_set = false
+WHERE EXISTS (
+    SELECT 1 FROM user_identities i
+    WHERE i.user_id = u.id AND i.created_at <= u.created_at + INTERVAL '1 minute'
+);
@@ -0,0 +1,8 @@
+// Package migrations holds the versioned SQL schema, embedded into the binary.
+// NNN_name.sql applies version NNN and NNN_name.down.sql reverts it.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
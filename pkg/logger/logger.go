@@ -1 +1,152 @@
-package logger
\ No newline at end of file
+// Package logger sets up structured logging on log/slog. Records carry the request ID and
+// user from the context, so a request can be followed from the access log through the
+// services and repositories it touched.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/britinogn/quillhub/pkg/utils"
+)
+
+// Options configures the process-wide logger
+type Options struct {
+	Level  string    // debug | info | warn | error
+	Format string    // text | json
+	Output io.Writer // defaults to os.Stderr
+}
+
+// level is shared by every logger Setup creates, so SetLevel applies at once
+var level = new(slog.LevelVar)
+
+// Setup installs the logger as slog's default. The standard log package writes through
+// it too, so log.Printf calls that have no context still come out structured.
+func Setup(opts Options) error {
+	lvl, err := ParseLevel(opts.Level)
+	if err != nil {
+		return err
+	}
+	level.Set(lvl)
+
+	output := opts.Output
+	if output == nil {
+		output = os.Stderr
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch strings.ToLower(opts.Format) {
+	case "", "text":
+		handler = slog.NewTextHandler(output, handlerOpts)
+	case "json":
+		handler = slog.NewJSONHandler(output, handlerOpts)
+	default:
+		return fmt.Errorf("unknown log format %q (want text or json)", opts.Format)
+	}
+
+	slog.SetDefault(slog.New(&contextHandler{Handler: handler}))
+	return nil
+}
+
+// ParseLevel turns debug, info, warn or error into a slog level
+func ParseLevel(name string) (slog.Level, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(name)); err != nil {
+		return lvl, fmt.Errorf("unknown log level %q (want debug, info, warn or error)", name)
+	}
+	return lvl, nil
+}
+
+// SetLevel changes the minimum level of the running process
+func SetLevel(lvl slog.Level) {
+	level.Set(lvl)
+}
+
+// Debugf logs a printf-style message at debug level with the request details in ctx
+func Debugf(ctx context.Context, format string, args ...any) {
+	logf(ctx, slog.LevelDebug, format, args...)
+}
+
+// Infof logs a printf-style message at info level with the request details in ctx
+func Infof(ctx context.Context, format string, args ...any) {
+	logf(ctx, slog.LevelInfo, format, args...)
+}
+
+// Warnf logs a printf-style message at warn level with the request details in ctx
+func Warnf(ctx context.Context, format string, args ...any) {
+	logf(ctx, slog.LevelWarn, format, args...)
+}
+
+// Errorf logs a printf-style message at error level with the request details in ctx
+func Errorf(ctx context.Context, format string, args ...any) {
+	logf(ctx, slog.LevelError, format, args...)
+}
+
+func logf(ctx context.Context, lvl slog.Level, format string, args ...any) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	l := slog.Default()
+	if !l.Enabled(ctx, lvl) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // skip Callers, logf and the exported wrapper
+	r := slog.NewRecord(time.Now(), lvl, fmt.Sprintf(format, args...), pcs[0])
+	_ = l.Handler().Handle(ctx, r)
+}
+
+// contextHandler adds request_id and user_id from the context, and turns the
+// "[POST-SERVICE] ..." prefix used across the code base into a component attribute
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if component, message, ok := splitComponent(r.Message); ok {
+		rewritten := slog.NewRecord(r.Time, r.Level, message, r.PC)
+		rewritten.AddAttrs(slog.String("component", component))
+		r.Attrs(func(a slog.Attr) bool {
+			rewritten.AddAttrs(a)
+			return true
+		})
+		r = rewritten
+	}
+
+	if info := utils.RequestInfoFrom(ctx); info.RequestID != "" {
+		r.AddAttrs(slog.String("request_id", info.RequestID))
+	}
+	if actor, ok := utils.ActorFrom(ctx); ok && actor.UserID != "" {
+		r.AddAttrs(slog.String("user_id", actor.UserID))
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// splitComponent - "[POST-REPO] Saved" becomes ("POST-REPO", "Saved")
+func splitComponent(message string) (string, string, bool) {
+	if !strings.HasPrefix(message, "[") {
+		return "", message, false
+	}
+	end := strings.IndexByte(message, ']')
+	if end < 2 || end > 32 || strings.ContainsAny(message[1:end], " \t") {
+		return "", message, false
+	}
+	return message[1:end], strings.TrimSpace(message[end+1:]), true
+}
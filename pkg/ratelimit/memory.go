@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// How often MemoryStore drops counters that have gone quiet
+const memorySweepInterval = time.Minute
+
+// MemoryStore - Counters kept in the process. Each instance counts on its own, so behind
+// a load balancer the effective limit is multiplied by the number of instances.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+type memoryEntry struct {
+	// Sliding window: the fixed window the counts belong to, and its and the previous count
+	window     int64
+	curr, prev int64
+
+	// Token bucket
+	tokens  float64
+	updated time.Time
+
+	expires time.Time
+}
+
+// NewMemoryStore - Returns a store that sweeps idle counters until Stop is called
+func NewMemoryStore() *MemoryStore {
+	s := &MemoryStore{
+		entries: make(map[string]*memoryEntry),
+		stop:    make(chan struct{}),
+	}
+	go s.sweep()
+	return s
+}
+
+// Stop - Ends the sweeper; the store keeps counting
+func (s *MemoryStore) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+func (s *MemoryStore) Allow(_ context.Context, policy Policy, key string) (Result, error) {
+	return s.allow(policy, key, time.Now()), nil
+}
+
+// allow - Allow at a given time, so tests control the clock
+func (s *MemoryStore) allow(policy Policy, key string, now time.Time) Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := policy.Name + ":" + key
+	entry, ok := s.entries[id]
+	if !ok {
+		entry = &memoryEntry{tokens: float64(policy.Limit), updated: now}
+		s.entries[id] = entry
+	}
+
+	if policy.Algorithm == TokenBucket {
+		return s.takeToken(policy, entry, now)
+	}
+	return s.countInWindow(policy, entry, now)
+}
+
+func (s *MemoryStore) countInWindow(policy Policy, entry *memoryEntry, now time.Time) Result {
+	windowMs := policy.Window.Milliseconds()
+	nowMs := now.UnixMilli()
+	index := nowMs / windowMs
+	elapsed := time.Duration(nowMs-index*windowMs) * time.Millisecond
+
+	// Roll the counters forward to the current window
+	if entry.window != index {
+		if entry.window == index-1 {
+			entry.prev = entry.curr
+		} else {
+			entry.prev = 0
+		}
+		entry.curr = 0
+		entry.window = index
+	}
+
+	allowed := slidingWindow(policy.Window, entry.prev, entry.curr, elapsed) < float64(policy.Limit)
+	if allowed {
+		entry.curr++
+	}
+	entry.expires = now.Add(2 * policy.Window)
+
+	return slidingResult(policy, allowed, entry.prev, entry.curr, elapsed)
+}
+
+func (s *MemoryStore) takeToken(policy Policy, entry *memoryEntry, now time.Time) Result {
+	entry.tokens = min(float64(policy.Limit), entry.tokens+float64(now.Sub(entry.updated))*refill(policy))
+	entry.updated = now
+
+	allowed := entry.tokens >= 1
+	if allowed {
+		entry.tokens--
+	}
+	entry.expires = now.Add(policy.Window)
+
+	return bucketResult(policy, allowed, entry.tokens)
+}
+
+// sweep - Counters past their expiry would read as fresh anyway, so they are dropped
+func (s *MemoryStore) sweep() {
+	ticker := time.NewTicker(memorySweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			for id, entry := range s.entries {
+				if now.After(entry.expires) {
+					delete(s.entries, id)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
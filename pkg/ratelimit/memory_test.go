@@ -0,0 +1,181 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// start - The beginning of a fixed window for any window that divides a minute
+var start = time.UnixMilli(1_700_000_040_000)
+
+type step struct {
+	at         time.Duration // since start
+	count      int           // identical requests at this time; 0 means 1
+	allowed    bool          // expected for the last of them
+	remaining  int
+	reset      time.Duration // checked when non-zero
+	retryAfter time.Duration // checked when denied
+}
+
+func runSteps(t *testing.T, policy Policy, steps []step) {
+	t.Helper()
+
+	s := &MemoryStore{entries: make(map[string]*memoryEntry)}
+	for i, st := range steps {
+		var result Result
+		for range max(st.count, 1) {
+			result = s.allow(policy, "client", start.Add(st.at))
+		}
+
+		if result.Allowed != st.allowed {
+			t.Fatalf("step %d at %s: allowed = %v, want %v", i, st.at, result.Allowed, st.allowed)
+		}
+		if result.Remaining != st.remaining {
+			t.Errorf("step %d at %s: remaining = %d, want %d", i, st.at, result.Remaining, st.remaining)
+		}
+		if st.reset != 0 && !near(result.Reset, st.reset) {
+			t.Errorf("step %d at %s: reset = %s, want %s", i, st.at, result.Reset, st.reset)
+		}
+		if !st.allowed && !near(result.RetryAfter, st.retryAfter) {
+			t.Errorf("step %d at %s: retry after = %s, want %s", i, st.at, result.RetryAfter, st.retryAfter)
+		}
+		if st.allowed && result.RetryAfter != 0 {
+			t.Errorf("step %d at %s: retry after %s on an allowed request", i, st.at, result.RetryAfter)
+		}
+	}
+}
+
+// near - Within a millisecond, the resolution both stores count in
+func near(got, want time.Duration) bool {
+	diff := got - want
+	return diff > -time.Millisecond && diff < time.Millisecond
+}
+
+func TestSlidingWindow(t *testing.T) {
+	policy := Policy{Name: "test", Limit: 10, Window: time.Minute, Algorithm: SlidingWindow}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "limit within one window",
+			steps: []step{
+				{at: 30 * time.Second, allowed: true, remaining: 9, reset: 90 * time.Second},
+				{at: 30 * time.Second, count: 9, allowed: true, remaining: 0},
+				// The full current window has to become the previous one and start sliding out
+				{at: 30 * time.Second, allowed: false, remaining: 0, retryAfter: 30 * time.Second},
+			},
+		},
+		{
+			name: "previous window still counts at the boundary",
+			steps: []step{
+				{at: 30 * time.Second, count: 10, allowed: true, remaining: 0},
+				{at: time.Minute, allowed: false, remaining: 0, retryAfter: time.Millisecond},
+				// 5% of the window has passed: the previous ten weigh 9.5
+				{at: time.Minute + 3*time.Second, allowed: true, remaining: 0},
+				// 9.5 + 1 is over: wait until the previous window weighs under 9
+				{at: time.Minute + 3*time.Second, allowed: false, remaining: 0, retryAfter: 3 * time.Second},
+				{at: time.Minute + 6*time.Second + time.Millisecond, allowed: true, remaining: 0},
+			},
+		},
+		{
+			name: "into the next window",
+			steps: []step{
+				{at: 0, count: 10, allowed: true, remaining: 0},
+				// A quarter of the window has passed: the previous ten weigh 7.5
+				{at: 75 * time.Second, count: 3, allowed: true, remaining: 0},
+				// 7.5 + 3 is over: wait until the previous window weighs under 7
+				{at: 75 * time.Second, allowed: false, remaining: 0, retryAfter: 3 * time.Second},
+			},
+		},
+		{
+			name: "a skipped window forgets everything",
+			steps: []step{
+				{at: 0, count: 10, allowed: true, remaining: 0},
+				{at: 2*time.Minute + time.Second, allowed: true, remaining: 9, reset: 119 * time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runSteps(t, policy, tt.steps)
+		})
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	// One token every two seconds
+	policy := Policy{Name: "test", Limit: 5, Window: 10 * time.Second, Algorithm: TokenBucket}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "burst up to the limit",
+			steps: []step{
+				{at: 0, allowed: true, remaining: 4, reset: 2 * time.Second},
+				{at: 0, count: 4, allowed: true, remaining: 0, reset: 10 * time.Second},
+				{at: 0, allowed: false, remaining: 0, retryAfter: 2 * time.Second},
+			},
+		},
+		{
+			name: "refill",
+			steps: []step{
+				{at: 0, count: 5, allowed: true, remaining: 0},
+				{at: time.Second, allowed: false, remaining: 0, retryAfter: time.Second},
+				{at: 2 * time.Second, allowed: true, remaining: 0},
+				{at: 5 * time.Second, allowed: true, remaining: 0, reset: 9 * time.Second},
+				{at: 5 * time.Second, allowed: false, remaining: 0, retryAfter: time.Second},
+			},
+		},
+		{
+			name: "refill stops at the limit",
+			steps: []step{
+				{at: 0, count: 5, allowed: true, remaining: 0},
+				{at: time.Hour, allowed: true, remaining: 4},
+				{at: time.Hour, count: 4, allowed: true, remaining: 0},
+				{at: time.Hour, allowed: false, remaining: 0, retryAfter: 2 * time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runSteps(t, policy, tt.steps)
+		})
+	}
+}
+
+func TestPoliciesCountSeparately(t *testing.T) {
+	s := &MemoryStore{entries: make(map[string]*memoryEntry)}
+	strict := Policy{Name: "strict", Limit: 1, Window: time.Minute, Algorithm: SlidingWindow}
+	loose := Policy{Name: "loose", Limit: 100, Window: time.Minute, Algorithm: SlidingWindow}
+
+	if !s.allow(strict, "client", start).Allowed || s.allow(strict, "client", start).Allowed {
+		t.Fatal("strict policy did not stop the second request")
+	}
+	if !s.allow(loose, "client", start).Allowed {
+		t.Error("the strict policy's count leaked into the loose one")
+	}
+	if !s.allow(strict, "other", start).Allowed {
+		t.Error("one client's count leaked into another's")
+	}
+}
+
+func TestPolicyString(t *testing.T) {
+	tests := []struct {
+		policy Policy
+		want   string
+	}{
+		{Policy{Limit: 10, Window: time.Minute}, "10;w=60"},
+		{Policy{Limit: 300, Window: 1500 * time.Millisecond}, "300;w=2"},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.String(); got != tt.want {
+			t.Errorf("%+v: String() = %q, want %q", tt.policy, got, tt.want)
+		}
+	}
+}
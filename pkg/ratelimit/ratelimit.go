@@ -0,0 +1,117 @@
+// Package ratelimit counts requests per key against a policy.
+//
+// Two algorithms are offered. A sliding window weighs the previous fixed window's count
+// by how much of it still overlaps the last Window, which smooths the burst a plain fixed
+// window allows at its edges. A token bucket holds Limit tokens, refilled evenly over
+// Window, so short bursts are fine as long as the average rate stays under the limit.
+//
+// MemoryStore keeps the counters in the process and suits a single instance and tests;
+// RedisStore shares them between every instance behind a load balancer.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Algorithm - How a policy counts requests
+type Algorithm string
+
+const (
+	SlidingWindow Algorithm = "sliding_window"
+	TokenBucket   Algorithm = "token_bucket"
+)
+
+// Policy - Limit requests per Window for each key. Name namespaces the counters, so one
+// key can be limited by several policies at once.
+type Policy struct {
+	Name      string
+	Limit     int
+	Window    time.Duration
+	Algorithm Algorithm
+}
+
+// String - The policy in RateLimit-Policy header form, e.g. 10;w=60
+func (p Policy) String() string {
+	return fmt.Sprintf("%d;w=%d", p.Limit, int(math.Ceil(p.Window.Seconds())))
+}
+
+// Result - The outcome of one request
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // until the full limit is available again
+	RetryAfter time.Duration // when denied, until a request would be allowed
+}
+
+// Store - Counts a request against policy for key and reports whether it is allowed
+type Store interface {
+	Allow(ctx context.Context, policy Policy, key string) (Result, error)
+}
+
+// slidingWindow - The request count estimated over the last window: the current fixed
+// window's count plus the share of the previous one that still falls inside it
+func slidingWindow(window time.Duration, prev, curr int64, elapsed time.Duration) float64 {
+	overlap := 1 - float64(elapsed)/float64(window)
+	return float64(prev)*overlap + float64(curr)
+}
+
+// slidingResult - The result for counters read after the check; elapsed is how far into
+// the current fixed window the request fell
+func slidingResult(p Policy, allowed bool, prev, curr int64, elapsed time.Duration) Result {
+	window := p.Window
+	used := slidingWindow(window, prev, curr, elapsed)
+	result := Result{
+		Allowed:   allowed,
+		Limit:     p.Limit,
+		Remaining: max(int(math.Floor(float64(p.Limit)-used)), 0),
+	}
+
+	// Requests in the current window count until the end of the next one
+	switch {
+	case curr > 0:
+		result.Reset = 2*window - elapsed
+	case prev > 0:
+		result.Reset = window - elapsed
+	}
+
+	if !allowed {
+		limit := float64(p.Limit)
+		if float64(curr) < limit && prev > 0 {
+			// Wait for enough of the previous window to slide out
+			at := time.Duration(float64(window) * (1 - (limit-float64(curr))/float64(prev)))
+			result.RetryAfter = at - elapsed
+		} else {
+			// The current window alone is full: it has to become the previous one
+			// and slide out far enough
+			at := time.Duration(float64(window) * (1 - limit/float64(curr)))
+			result.RetryAfter = window - elapsed + at
+		}
+		result.RetryAfter = max(result.RetryAfter, time.Millisecond)
+	}
+
+	return result
+}
+
+// refill - Tokens per nanosecond for a bucket of p.Limit refilled over p.Window
+func refill(p Policy) float64 {
+	return float64(p.Limit) / float64(p.Window)
+}
+
+// bucketResult - The result for the tokens left after the check
+func bucketResult(p Policy, allowed bool, tokens float64) Result {
+	rate := refill(p)
+	result := Result{
+		Allowed:   allowed,
+		Limit:     p.Limit,
+		Remaining: max(int(math.Floor(tokens)), 0),
+		Reset:     time.Duration((float64(p.Limit) - tokens) / rate),
+	}
+	if !allowed {
+		result.RetryAfter = max(time.Duration((1-tokens)/rate), time.Millisecond)
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisKeyPrefix - Prepended to every counter key
+const RedisKeyPrefix = "quillhub:ratelimit:"
+
+// The scripts keep each policy's state for a key in one hash, so a check is a single
+// atomic round trip and works on Redis Cluster. Both read the clock from Redis, so
+// instances with drifting clocks still agree.
+
+// slidingWindowScript - ARGV: window in ms, limit. Returns allowed, prev, curr, elapsed ms.
+var slidingWindowScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local index = math.floor(now / window)
+local elapsed = now - index * window
+
+local state = redis.call('HMGET', KEYS[1], 'window', 'curr', 'prev')
+local stored = tonumber(state[1])
+local curr = tonumber(state[2]) or 0
+local prev = tonumber(state[3]) or 0
+if stored ~= index then
+	if stored == index - 1 then prev = curr else prev = 0 end
+	curr = 0
+end
+
+local allowed = 0
+if prev * (window - elapsed) / window + curr < limit then
+	allowed = 1
+	curr = curr + 1
+end
+
+redis.call('HSET', KEYS[1], 'window', index, 'curr', curr, 'prev', prev)
+redis.call('PEXPIRE', KEYS[1], window * 2)
+return {allowed, prev, curr, elapsed}
+`)
+
+// tokenBucketScript - ARGV: window in ms, limit. Returns allowed and the tokens left as
+// a string, since Redis truncates Lua numbers to integers.
+var tokenBucketScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(state[1]) or limit
+local updated = tonumber(state[2]) or now
+tokens = math.min(limit, tokens + (now - updated) * limit / window)
+
+local allowed = 0
+if tokens >= 1 then
+	allowed = 1
+	tokens = tokens - 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+redis.call('PEXPIRE', KEYS[1], window)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore - Counters shared by every instance through Redis
+type RedisStore struct {
+	client redis.Scripter
+}
+
+// NewRedisStore - client is a *redis.Client or a *redis.ClusterClient
+func NewRedisStore(client redis.Scripter) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Allow(ctx context.Context, policy Policy, key string) (Result, error) {
+	redisKey := RedisKeyPrefix + policy.Name + ":" + key
+	args := []any{policy.Window.Milliseconds(), policy.Limit}
+
+	if policy.Algorithm == TokenBucket {
+		reply, err := tokenBucketScript.Run(ctx, s.client, []string{redisKey}, args...).Slice()
+		if err != nil {
+			return Result{}, fmt.Errorf("token bucket check: %w", err)
+		}
+		if len(reply) != 2 {
+			return Result{}, fmt.Errorf("token bucket check: unexpected reply %v", reply)
+		}
+		raw, _ := reply[1].(string)
+		tokens, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return Result{}, fmt.Errorf("token bucket check: bad token count %q", raw)
+		}
+		return bucketResult(policy, reply[0] == int64(1), tokens), nil
+	}
+
+	reply, err := slidingWindowScript.Run(ctx, s.client, []string{redisKey}, args...).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("sliding window check: %w", err)
+	}
+	if len(reply) != 4 {
+		return Result{}, fmt.Errorf("sliding window check: unexpected reply %v", reply)
+	}
+	elapsed := time.Duration(reply[3]) * time.Millisecond
+	return slidingResult(policy, reply[0] == 1, reply[1], reply[2], elapsed), nil
+}
@@ -0,0 +1,127 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/britinogn/quillhub/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Report fields by the names clients send (json, then form), not the Go field names
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+}
+
+// BindError answers a failed ShouldBind* call: 400 with the fields at fault when the
+// binding tags were broken, or invalid_body when the body could not be decoded
+func BindError(c *gin.Context, err error) {
+	Fail(c, bindingError(err))
+}
+
+func bindingError(err error) *Error {
+	var invalid validator.ValidationErrors
+	if errors.As(err, &invalid) {
+		fields := make([]utils.FieldError, len(invalid))
+		for i, fe := range invalid {
+			fields[i] = utils.FieldError{Field: fe.Field(), Message: fieldMessage(fe)}
+		}
+		verr := &utils.ValidationError{Fields: fields}
+		return &Error{
+			Status:  http.StatusBadRequest,
+			Code:    CodeValidation,
+			Message: capitalize(verr.Error()),
+			Fields:  fields,
+			Err:     err,
+		}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		field := utils.FieldError{
+			Field:   typeErr.Field,
+			Message: fmt.Sprintf("%s must be a %s", typeErr.Field, jsonKind(typeErr.Type)),
+		}
+		return &Error{
+			Status:  http.StatusBadRequest,
+			Code:    CodeValidation,
+			Message: capitalize(field.Message),
+			Fields:  []utils.FieldError{field},
+			Err:     err,
+		}
+	}
+
+	message := "Invalid request body"
+	if errors.Is(err, io.EOF) {
+		message = "Request body is required"
+	}
+	return &Error{Status: http.StatusBadRequest, Code: CodeInvalidBody, Message: message, Err: err}
+}
+
+// fieldMessage - A sentence for the binding tags used on the request models
+func fieldMessage(fe validator.FieldError) string {
+	name := fe.Field()
+	switch fe.Tag() {
+	case "required":
+		return name + " is required"
+	case "email":
+		return name + " must be a valid email address"
+	case "url", "http_url":
+		return name + " must be a valid URL"
+	case "uuid", "uuid4":
+		return name + " must be a valid UUID"
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", name, strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "min", "max", "len":
+		bound := map[string]string{"min": "at least", "max": "at most", "len": "exactly"}[fe.Tag()]
+		switch fe.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("%s must be %s %s characters", name, bound, fe.Param())
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Sprintf("%s must have %s %s items", name, bound, fe.Param())
+		default:
+			return fmt.Sprintf("%s must be %s %s", name, bound, fe.Param())
+		}
+	default:
+		return name + " is invalid"
+	}
+}
+
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,202 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/britinogn/quillhub/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Codes for errors that are not tied to one service error. Registered service errors
+// carry their own, such as post_not_found.
+const (
+	CodeBadRequest      = "bad_request"
+	CodeValidation      = "validation_failed"
+	CodeInvalidBody     = "invalid_body"
+	CodeUnauthorized    = "unauthorized"
+	CodeForbidden       = "forbidden"
+	CodeNotFound        = "not_found"
+	CodeConflict        = "conflict"
+	CodeTooManyRequests = "too_many_requests"
+	CodeInternal        = "internal_error"
+	CodeUnavailable     = "service_unavailable"
+)
+
+// Error is an API error: the status, a stable machine-readable code, a message safe to
+// show the client and, for validation failures, the fields at fault. Err is the cause;
+// it is logged but never sent.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Fields  []utils.FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewError returns an API error with the given status, code and message
+func NewError(status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+type mapping struct {
+	target error
+	status int
+	code   string
+}
+
+var (
+	mappingsMu sync.RWMutex
+	mappings   []mapping
+)
+
+// Register answers errors that match target (errors.Is) with status and code. Below
+// 500 the client sees the error's text from target's on, so details wrapped after it
+// such as "invalid persona: name is required" come through while callers' prefixes do
+// not; from 500 up only target's text is shown. The first registration that matches wins.
+func Register(target error, status int, code string) {
+	mappingsMu.Lock()
+	defer mappingsMu.Unlock()
+	mappings = append(mappings, mapping{target: target, status: status, code: code})
+}
+
+// From turns err into an API error: a *Error as is, a *utils.ValidationError as 400,
+// a registered error with its status and code, anything else as a 500
+func From(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	var invalid *utils.ValidationError
+	if errors.As(err, &invalid) {
+		return &Error{
+			Status:  http.StatusBadRequest,
+			Code:    CodeValidation,
+			Message: capitalize(invalid.Error()),
+			Fields:  invalid.Fields,
+			Err:     err,
+		}
+	}
+
+	mappingsMu.RLock()
+	defer mappingsMu.RUnlock()
+	for _, m := range mappings {
+		if !errors.Is(err, m.target) {
+			continue
+		}
+		message := m.target.Error()
+		if m.status < http.StatusInternalServerError {
+			if i := strings.Index(err.Error(), message); i >= 0 {
+				message = err.Error()[i:]
+			}
+		}
+		return &Error{Status: m.status, Code: m.code, Message: capitalize(message), Err: err}
+	}
+
+	return &Error{
+		Status:  http.StatusInternalServerError,
+		Code:    CodeInternal,
+		Message: "Internal server error",
+		Err:     err,
+	}
+}
+
+// Fail answers with err (see From) and aborts the chain. The error is attached to the
+// gin context, so the access log line for the request records the cause.
+func Fail(c *gin.Context, err error) {
+	apiErr := From(err)
+	_ = c.Error(err)
+
+	requestID := utils.RequestInfoFrom(c.Request.Context()).RequestID
+	if wantsProblem(c.Request) {
+		writeProblem(c, apiErr, requestID)
+		return
+	}
+
+	c.AbortWithStatusJSON(apiErr.Status, ErrorBody{
+		Error:     apiErr.Message,
+		Code:      apiErr.Code,
+		Status:    apiErr.Status,
+		Fields:    apiErr.Fields,
+		RequestID: requestID,
+	})
+}
+
+// FailMessage answers like Fail, with message in place of the error's own text
+func FailMessage(c *gin.Context, err error, message string) {
+	apiErr := *From(err)
+	apiErr.Message = message
+	if apiErr.Err == nil {
+		apiErr.Err = err
+	}
+	Fail(c, &apiErr)
+}
+
+// ErrorBody is the error envelope
+type ErrorBody struct {
+	Error     string             `json:"error"`
+	Code      string             `json:"code"`
+	Status    int                `json:"status"`
+	Fields    []utils.FieldError `json:"fields,omitempty"`
+	RequestID string             `json:"request_id,omitempty"`
+}
+
+// BadRequest answers 400 with message
+func BadRequest(c *gin.Context, message string) {
+	Fail(c, NewError(http.StatusBadRequest, CodeBadRequest, message))
+}
+
+// Unauthorized answers 401 with message
+func Unauthorized(c *gin.Context, message string) {
+	Fail(c, NewError(http.StatusUnauthorized, CodeUnauthorized, message))
+}
+
+// Forbidden answers 403 with message
+func Forbidden(c *gin.Context, message string) {
+	Fail(c, NewError(http.StatusForbidden, CodeForbidden, message))
+}
+
+// NotFound answers 404 with message
+func NotFound(c *gin.Context, message string) {
+	Fail(c, NewError(http.StatusNotFound, CodeNotFound, message))
+}
+
+// Conflict answers 409 with message
+func Conflict(c *gin.Context, message string) {
+	Fail(c, NewError(http.StatusConflict, CodeConflict, message))
+}
+
+// Internal answers 500 with a generic message; cause is logged with the request
+func Internal(c *gin.Context, cause error) {
+	Fail(c, &Error{
+		Status:  http.StatusInternalServerError,
+		Code:    CodeInternal,
+		Message: "Internal server error",
+		Err:     cause,
+	})
+}
+
+// capitalize - Service errors are lower case ("post not found"); messages are sentences
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError || unicode.IsUpper(r) {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/britinogn/quillhub/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem documents
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem document. Type is about:blank, so Title is the HTTP
+// status text; code, fields and request_id are extension members.
+type Problem struct {
+	Type      string             `json:"type"`
+	Title     string             `json:"title"`
+	Status    int                `json:"status"`
+	Detail    string             `json:"detail,omitempty"`
+	Instance  string             `json:"instance,omitempty"`
+	Code      string             `json:"code"`
+	Fields    []utils.FieldError `json:"fields,omitempty"`
+	RequestID string             `json:"request_id,omitempty"`
+}
+
+// wantsProblem - The client listed application/problem+json in Accept
+func wantsProblem(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mediaType == ProblemContentType {
+			return true
+		}
+	}
+	return false
+}
+
+func writeProblem(c *gin.Context, apiErr *Error, requestID string) {
+	// gin keeps a Content-Type that is already set
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(apiErr.Status, Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(apiErr.Status),
+		Status:    apiErr.Status,
+		Detail:    apiErr.Message,
+		Instance:  c.Request.URL.Path,
+		Code:      apiErr.Code,
+		Fields:    apiErr.Fields,
+		RequestID: requestID,
+	})
+}
@@ -1 +1,51 @@
-package response
\ No newline at end of file
+// Package response writes every API response in one shape.
+//
+// Success:
+//
+//	{"message": "Post created successfully", "data": {...}}
+//
+// Failure:
+//
+//	{"error": "Post not found", "code": "post_not_found", "status": 404, "request_id": "..."}
+//
+// Clients that send Accept: application/problem+json get failures as RFC 7807 problem
+// documents instead.
+package response
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Body is the success envelope. Data is left out when there is none; an empty list is
+// still sent as [].
+type Body struct {
+	Message string `json:"message,omitempty"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// Success writes status with an optional message and data
+func Success(c *gin.Context, status int, message string, data any) {
+	c.JSON(status, Body{Message: message, Data: data})
+}
+
+// OK writes 200 with data
+func OK(c *gin.Context, data any) {
+	Success(c, http.StatusOK, "", data)
+}
+
+// Created writes 201 with the new resource
+func Created(c *gin.Context, message string, data any) {
+	Success(c, http.StatusCreated, message, data)
+}
+
+// Accepted writes 202 for work that finishes in the background
+func Accepted(c *gin.Context, message string, data any) {
+	Success(c, http.StatusAccepted, message, data)
+}
+
+// Message writes 200 with only a message
+func Message(c *gin.Context, message string) {
+	Success(c, http.StatusOK, message, nil)
+}
@@ -0,0 +1,45 @@
+package utils
+
+import "context"
+
+type contextKey string
+
+const (
+	requestInfoKey contextKey = "requestInfo"
+	actorKey       contextKey = "actor"
+)
+
+// RequestInfo carries per-request metadata from the HTTP layer into services
+type RequestInfo struct {
+	RequestID string
+	IPAddress string
+	UserAgent string
+}
+
+// Actor identifies the authenticated user performing a request
+type Actor struct {
+	UserID string
+	Role   string
+}
+
+// WithRequestInfo returns a copy of ctx carrying the request metadata
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey, info)
+}
+
+// RequestInfoFrom returns the request metadata stored in ctx, if any
+func RequestInfoFrom(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey).(RequestInfo)
+	return info
+}
+
+// WithActor returns a copy of ctx carrying the authenticated user
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// ActorFrom returns the authenticated user stored in ctx, if any
+func ActorFrom(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey).(Actor)
+	return actor, ok
+}
@@ -3,7 +3,6 @@ package utils
 import (
 	"errors"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -21,36 +20,35 @@ type Claims struct {
 	Email    string `json:"email"`
 	Username string `json:"username,omitempty"` // optional
 	Role     string `json:"role,omitempty"`     // very useful for authorization
+	SessionID string `json:"sid,omitempty"`     // login session, checked against revocation
 	jwt.RegisteredClaims
 }
 
-func getSecret() []byte {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		// In production → return error instead of panic
-		panic("JWT_SECRET environment variable is not set")
-	}
-	return []byte(secret)
+// TokenSigner signs and verifies JWTs with the configured secret and lifetime
+type TokenSigner struct {
+	secret []byte
+	ttl    time.Duration
 }
 
-// GenerateToken creates a signed JWT
-func GenerateToken(userID, email, username, role string) (string, error) {
-	// Read expiration from env or default to 24h
-	expiresIn := os.Getenv("JWT_EXPIRES_IN")
-	if expiresIn == "" {
-		expiresIn = "24h"
-	}
+func NewTokenSigner(secret string, ttl time.Duration) *TokenSigner {
+	return &TokenSigner{secret: []byte(secret), ttl: ttl}
+}
 
-	duration, err := time.ParseDuration(expiresIn)
-	if err != nil {
-		duration = 24 * time.Hour // fallback
-	}
+// TTL returns how long issued tokens stay valid
+func (s *TokenSigner) TTL() time.Duration {
+	return s.ttl
+}
+
+// GenerateToken creates a signed JWT bound to a login session
+func (s *TokenSigner) GenerateToken(userID, email, username, role, sessionID string) (string, error) {
+	duration := s.ttl
 
 	claims := Claims{
-		UserID:   userID,
-		Email:    email,
-		Username: username,
-		Role:     role,
+		UserID:    userID,
+		Email:     email,
+		Username:  username,
+		Role:      role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   userID,                           // standard "sub"
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -60,7 +58,7 @@ func GenerateToken(userID, email, username, role string) (string, error) {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(getSecret())
+	signed, err := token.SignedString(s.secret)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -68,12 +66,12 @@ func GenerateToken(userID, email, username, role string) (string, error) {
 }
 
 // VerifyToken parses and validates the token, returns the full claims
-func VerifyToken(tokenStr string) (*Claims, error) {
+func (s *TokenSigner) VerifyToken(tokenStr string) (*Claims, error) {
 	parsed, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(t *jwt.Token) (interface{}, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, ErrUnexpectedSigning
 		}
-		return getSecret(), nil
+		return s.secret, nil
 	})
 
 	if err != nil {
@@ -1 +0,0 @@
-package utils
\ No newline at end of file
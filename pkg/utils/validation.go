@@ -0,0 +1,28 @@
+package utils
+
+import "strings"
+
+// FieldError is one rule an input field breaks
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError reports input that breaks one or more rules, field by field.
+// Services return it for bad input; the API answers 400 with the fields listed.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Invalid returns a validation error for a single field
+func Invalid(field, message string) *ValidationError {
+	return &ValidationError{Fields: []FieldError{{Field: field, Message: message}}}
+}